@@ -6,6 +6,27 @@ diki-operator
 {{ .Values.config.controllers.complianceScan.dikiRunner.namespace | default .Release.Namespace }}
 {{- end -}}
 
+{{- define "diki-runner.namespaces" -}}
+{{- $namespaces := list (include "diki-runner.namespace" .) }}
+{{- range $name, $profile := .Values.config.controllers.complianceScan.dikiRunnerProfiles }}
+{{- $namespaces = append $namespaces ($profile.namespace | default "kube-system") }}
+{{- end }}
+{{- $namespaces | uniq | toJson }}
+{{- end -}}
+
+{{- define "controller.options" -}}
+{{- if .concurrentSyncs }}
+concurrentSyncs: {{ .concurrentSyncs }}
+{{- end }}
+{{- if .reconciliationTimeout }}
+reconciliationTimeout: {{ .reconciliationTimeout }}
+{{- end }}
+{{- if .rateLimiter }}
+rateLimiter:
+{{ toYaml .rateLimiter | indent 2 }}
+{{- end }}
+{{- end -}}
+
 {{- define "leaderelection.id" -}}
 diki-operator-leader-election
 {{- end -}}
@@ -54,11 +75,66 @@ log:
 controllers:
   complianceScan:
     syncPeriod: {{ .Values.config.controllers.complianceScan.syncPeriod }}
+    {{- include "controller.options" .Values.config.controllers.complianceScan | nindent 4 }}
     dikiRunner:
       waitInterval: {{ .Values.config.controllers.complianceScan.dikiRunner.waitInterval }}
       podCompletionTimeout: {{ .Values.config.controllers.complianceScan.dikiRunner.podCompletionTimeout }}
       execTimeout: {{ .Values.config.controllers.complianceScan.dikiRunner.execTimeout }}
       namespace: {{ include "diki-runner.namespace" . }}
+      {{- if .Values.config.controllers.complianceScan.dikiRunner.podTemplate }}
+      podTemplate:
+{{ toYaml .Values.config.controllers.complianceScan.dikiRunner.podTemplate | indent 8 }}
+      {{- end }}
+      {{- if .Values.config.controllers.complianceScan.dikiRunner.networkPolicy }}
+      networkPolicy:
+{{ toYaml .Values.config.controllers.complianceScan.dikiRunner.networkPolicy | indent 8 }}
+      {{- end }}
+      {{- if .Values.config.controllers.complianceScan.dikiRunner.jobLayout }}
+      jobLayout: {{ .Values.config.controllers.complianceScan.dikiRunner.jobLayout }}
+      {{- end }}
+      {{- if .Values.config.controllers.complianceScan.dikiRunner.maxConcurrentJobs }}
+      maxConcurrentJobs: {{ .Values.config.controllers.complianceScan.dikiRunner.maxConcurrentJobs }}
+      {{- end }}
+      {{- if .Values.config.controllers.complianceScan.dikiRunner.priorityClasses }}
+      priorityClasses:
+{{ toYaml .Values.config.controllers.complianceScan.dikiRunner.priorityClasses | indent 6 }}
+      {{- end }}
+    {{- if .Values.config.controllers.complianceScan.maxConcurrentJobs }}
+    maxConcurrentJobs: {{ .Values.config.controllers.complianceScan.maxConcurrentJobs }}
+    {{- end }}
+    {{- if .Values.config.controllers.complianceScan.allowedImageRepositories }}
+    allowedImageRepositories:
+{{ toYaml .Values.config.controllers.complianceScan.allowedImageRepositories | indent 4 }}
+    {{- end }}
+    {{- if .Values.config.controllers.complianceScan.dikiRunnerProfiles }}
+    dikiRunnerProfiles:
+{{ toYaml .Values.config.controllers.complianceScan.dikiRunnerProfiles | indent 6 }}
+    {{- end }}
+    {{- if .Values.config.controllers.complianceScan.defaultReportOutputs }}
+    defaultReportOutputs:
+{{ toYaml .Values.config.controllers.complianceScan.defaultReportOutputs | indent 4 }}
+    {{- end }}
+  {{- with .Values.config.controllers.scheduledComplianceScan }}
+  scheduledComplianceScan:
+    {{- include "controller.options" . | nindent 4 }}
+  {{- end }}
+  {{- with .Values.config.controllers.namespaceComplianceScan }}
+  namespaceComplianceScan:
+    {{- if .runnerProfile }}
+    runnerProfile: {{ .runnerProfile }}
+    {{- end }}
+    {{- include "controller.options" . | nindent 4 }}
+  {{- end }}
+  {{- with .Values.config.controllers.garbageCollector }}
+  garbageCollector:
+    {{- if .syncPeriod }}
+    syncPeriod: {{ .syncPeriod }}
+    {{- end }}
+    {{- if hasKey . "complianceScanTTLSecondsAfterFinished" }}
+    complianceScanTTLSecondsAfterFinished: {{ .complianceScanTTLSecondsAfterFinished }}
+    {{- end }}
+    {{- include "controller.options" . | nindent 4 }}
+  {{- end }}
 server:
   healthProbes:
     port: {{ .Values.config.server.healthProbes.port }}
@@ -90,3 +166,15 @@ leaderElection:
   resourceLock: {{ .Values.config.leaderElection.resourceLock }}
   {{- end }}
 {{- end -}}
+
+{{- define "diki-runner.tokenrequest.serviceaccounts" -}}
+{{- $serviceAccounts := list }}
+{{- range $name, $profile := .Values.config.controllers.complianceScan.dikiRunnerProfiles }}
+{{- if and $profile.targetKubeconfig $profile.targetKubeconfig.tokenRequest }}
+{{- if not $profile.targetKubeconfig.tokenRequest.managementKubeconfigSecretRef }}
+{{- $serviceAccounts = append $serviceAccounts $profile.targetKubeconfig.tokenRequest.serviceAccount }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- $serviceAccounts | uniq | toJson }}
+{{- end -}}
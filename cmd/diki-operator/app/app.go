@@ -18,7 +18,11 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -30,18 +34,23 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	controllerconfig "sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/gardener/diki-operator/internal/config"
+	"github.com/gardener/diki-operator/internal/constants"
 	compliancescan "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
 	garbagecollector "github.com/gardener/diki-operator/internal/reconciler/garbagecollector"
+	namespacecompliancescan "github.com/gardener/diki-operator/internal/reconciler/namespacecompliancescan"
 	scheduledcompliancescan "github.com/gardener/diki-operator/internal/reconciler/scheduledcompliancescan"
 	compliancescanwebhook "github.com/gardener/diki-operator/internal/webhook/compliancescan"
+	reportoutputwebhook "github.com/gardener/diki-operator/internal/webhook/reportoutput"
 	scheduledcompliancescanwebhook "github.com/gardener/diki-operator/internal/webhook/scheduledcompliancescan"
-	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
 	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
 )
 
@@ -77,7 +86,7 @@ func NewCommand() *cobra.Command {
 				log.Info("Flag", "name", flag.Name, "value", flag.Value, "default", flag.DefValue)
 			})
 
-			return run(cmd.Context(), log, opt.config)
+			return run(cmd.Context(), log, opt)
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			verflag.PrintAndExitIfRequested()
@@ -92,7 +101,9 @@ func NewCommand() *cobra.Command {
 	return cmd
 }
 
-func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.DikiOperatorConfiguration) error {
+func run(ctx context.Context, log logr.Logger, opt *options) error {
+	cfg := opt.config
+
 	conf, err := ctrl.GetConfig()
 	if err != nil {
 		return err
@@ -105,12 +116,29 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.DikiOperatorC
 
 	log.Info("Setting up manager")
 
+	dikiRunnerNamespaces := configv1alpha1helper.DikiRunnerNamespaces(&cfg.Controllers.ComplianceScan)
+
+	jobNamespaces := make(map[string]cache.Config, len(dikiRunnerNamespaces))
+	for _, namespace := range dikiRunnerNamespaces {
+		jobNamespaces[namespace] = cache.Config{}
+	}
+
 	var cacheOpts cache.Options
 	if cfg.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig == nil {
 		cacheOpts.ByObject = map[client.Object]cache.ByObject{
-			&batchv1.Job{}: {Namespaces: map[string]cache.Config{
-				cfg.Controllers.ComplianceScan.DikiRunner.Namespace: {},
-			}},
+			&batchv1.Job{}: {Namespaces: jobNamespaces},
+			&corev1.Pod{}: {
+				Namespaces: jobNamespaces,
+				Label:      labels.SelectorFromSet(labels.Set{constants.LabelAppManagedBy: constants.LabelValueDikiOperator}),
+			},
+			&corev1.Secret{}: {
+				Namespaces: jobNamespaces,
+				Label:      labels.SelectorFromSet(labels.Set{constants.LabelAppManagedBy: constants.LabelValueDikiOperator}),
+			},
+			&networkingv1.NetworkPolicy{}: {
+				Namespaces: jobNamespaces,
+				Label:      labels.SelectorFromSet(labels.Set{constants.LabelAppManagedBy: constants.LabelValueDikiOperator}),
+			},
 		}
 	}
 
@@ -172,7 +200,23 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.DikiOperatorC
 		return err
 	}
 
-	var sourceClient client.Client
+	configStore := config.NewStore(&cfg.Controllers.ComplianceScan)
+	if opt.configReloadInterval > 0 {
+		if err := mgr.Add(&config.Reloader{
+			Path:     opt.configFile,
+			Decoder:  configDecoder,
+			Store:    configStore,
+			Interval: opt.configReloadInterval,
+			Log:      log.WithName("config-reloader"),
+		}); err != nil {
+			return fmt.Errorf("unable to add config reloader: %w", err)
+		}
+	}
+
+	var (
+		sourceClient client.Client
+		sourceCache  cache.Cache
+	)
 	if cfg.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig != nil {
 		sourceConfig, err := rest.InClusterConfig()
 		if err != nil {
@@ -182,42 +226,87 @@ func run(ctx context.Context, log logr.Logger, cfg *configv1alpha1.DikiOperatorC
 		if err != nil {
 			return fmt.Errorf("unable to create source client: %w", err)
 		}
+
+		// The diki-run Jobs are watched in the cluster the operator runs on, while ComplianceScans reside in the target cluster.
+		sourceCluster, err := cluster.New(sourceConfig, func(opts *cluster.Options) {
+			opts.Scheme = mgr.GetScheme()
+			opts.Cache.DefaultNamespaces = jobNamespaces
+		})
+		if err != nil {
+			return fmt.Errorf("unable to create source cluster: %w", err)
+		}
+		if err := mgr.Add(sourceCluster); err != nil {
+			return fmt.Errorf("unable to add source cluster to manager: %w", err)
+		}
+		sourceCache = sourceCluster.GetCache()
 	} else {
 		sourceClient = mgr.GetClient()
+		sourceCache = mgr.GetCache()
 	}
 
 	// Setup ComplianceScan controller
 	complianceScanReconciler := &compliancescan.Reconciler{
 		SourceClient: sourceClient,
-		Config:       cfg.Controllers.ComplianceScan,
+		SourceCache:  sourceCache,
+		ConfigStore:  configStore,
 	}
 
 	if err := complianceScanReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create complianceScan reconcile controller: %w", err)
 	}
 	// Setup ScheduledComplianceScan controller
-	if err := (&scheduledcompliancescan.Reconciler{}).SetupWithManager(mgr); err != nil {
+	if err := (&scheduledcompliancescan.Reconciler{
+		Config: cfg.Controllers.ScheduledComplianceScan,
+	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create scheduledComplianceScan reconcile controller: %w", err)
 	}
+	// Setup NamespaceComplianceScan controller
+	if err := (&namespacecompliancescan.Reconciler{
+		Config: cfg.Controllers.NamespaceComplianceScan,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create namespaceComplianceScan reconcile controller: %w", err)
+	}
 	// Setup GarbageCollector controller
 	if err := (&garbagecollector.Reconciler{
 		SourceClient: sourceClient,
 		Config: garbagecollector.Config{
-			Namespace:       cfg.Controllers.ComplianceScan.DikiRunner.Namespace,
-			RequeueInterval: 2 * time.Minute,
+			ControllerOptions:                     cfg.Controllers.GarbageCollector.ControllerOptions,
+			Namespaces:                            dikiRunnerNamespaces,
+			RequeueInterval:                       cfg.Controllers.GarbageCollector.SyncPeriod.Duration,
+			ComplianceScanTTLSecondsAfterFinished: cfg.Controllers.GarbageCollector.ComplianceScanTTLSecondsAfterFinished,
 		},
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create garbagecollector controller: %w", err)
 	}
 
+	operatorUsername, err := getOperatorUsername(ctx, mgr.GetClient())
+	if err != nil {
+		return err
+	}
+	log.Info("Determined operator identity", "username", operatorUsername)
+
 	log.Info("Adding webhook handler to manager")
-	if err := compliancescanwebhook.AddToManager(mgr); err != nil {
+	if err := compliancescanwebhook.AddToManager(mgr, configStore, operatorUsername); err != nil {
 		return fmt.Errorf("failed adding webhook handler to manager: %w", err)
 	}
-	if err := scheduledcompliancescanwebhook.AddToManager(mgr); err != nil {
+	if err := scheduledcompliancescanwebhook.AddToManager(mgr, configStore, operatorUsername); err != nil {
 		return fmt.Errorf("failed adding scheduledcompliancescan webhook handler to manager: %w", err)
 	}
+	if err := reportoutputwebhook.AddToManager(mgr); err != nil {
+		return fmt.Errorf("failed adding reportoutput webhook handler to manager: %w", err)
+	}
 
 	log.Info("Starting manager")
 	return mgr.Start(ctx)
 }
+
+// getOperatorUsername returns the username the operator is authenticated as. The webhooks do not authorize the
+// references of ComplianceScans created by the operator itself, e.g. for ScheduledComplianceScans.
+func getOperatorUsername(ctx context.Context, c client.Client) (string, error) {
+	review := &authenticationv1.SelfSubjectReview{}
+	if err := c.Create(ctx, review); err != nil {
+		return "", fmt.Errorf("failed to review the identity of the operator: %w", err)
+	}
+
+	return review.Status.UserInfo.Username, nil
+}
@@ -7,12 +7,14 @@ package app
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
+	"github.com/gardener/diki-operator/internal/config"
 	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/validation"
 )
@@ -26,18 +28,22 @@ func init() {
 }
 
 type options struct {
-	configFile string
-	config     *configv1alpha1.DikiOperatorConfiguration
+	configFile           string
+	configReloadInterval time.Duration
+	config               *configv1alpha1.DikiOperatorConfiguration
 }
 
 // newOptions return options with default values.
 func newOptions() *options {
-	return &options{}
+	return &options{
+		configReloadInterval: config.DefaultReloadInterval,
+	}
 }
 
 // addFlags binds the command options to a given flagset.
 func (o *options) addFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.configFile, "config", o.configFile, "Path to configuration file.")
+	flags.DurationVar(&o.configReloadInterval, "config-reload-interval", o.configReloadInterval, "Interval in which the configuration file is checked for changes. Reloading is disabled if it is 0.")
 }
 
 // Complete adapts from the command line args to the data required.
@@ -61,6 +67,9 @@ func (o *options) Complete() error {
 
 // Validate validates the provided command options.
 func (o *options) Validate() error {
+	if o.configReloadInterval < 0 {
+		return fmt.Errorf("config reload interval must not be negative")
+	}
 	if errs := validation.ValidateDikiOperatorConfiguration(o.config); len(errs) > 0 {
 		return errs.ToAggregate()
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/component-base/version"
@@ -90,5 +91,12 @@ func run(ctx context.Context, log logr.Logger, cfg *v1alpha1.ReportExporterConfi
 	reportExporter := reportexporter.NewReportExporter(c, *cfg)
 
 	log.Info("Starting report-exporter")
-	return reportExporter.Export(ctx)
+	if err := reportExporter.Export(ctx); err != nil {
+		if writeErr := reportexporter.WriteTerminationMessage(corev1.TerminationMessagePathDefault, err); writeErr != nil {
+			log.Error(writeErr, "Failed to write termination message")
+		}
+		return err
+	}
+
+	return nil
 }
@@ -24,6 +24,7 @@ import (
 // ConfigMapExporter is responsible for exporting the Diki report to a ConfigMap.
 type ConfigMapExporter struct {
 	Client         client.Client
+	OutputName     string
 	Config         dikiv1alpha1.OutputConfigMap
 	ComplianceScan *dikiv1alpha1.ComplianceScan
 }
@@ -42,9 +43,10 @@ type ConfigMapRef struct {
 }
 
 // NewConfigMapExporter creates a new instance of ConfigMapExporter.
-func NewConfigMapExporter(client client.Client, config dikiv1alpha1.OutputConfigMap, complianceScan *dikiv1alpha1.ComplianceScan) *ConfigMapExporter {
+func NewConfigMapExporter(client client.Client, outputName string, config dikiv1alpha1.OutputConfigMap, complianceScan *dikiv1alpha1.ComplianceScan) *ConfigMapExporter {
 	return &ConfigMapExporter{
 		Client:         client,
+		OutputName:     outputName,
 		Config:         config,
 		ComplianceScan: complianceScan,
 	}
@@ -99,11 +101,44 @@ func (c *ConfigMapExporter) Export(ctx context.Context, report dikireport.Report
 	}, nil
 }
 
+// Cleanup deletes the ConfigMaps which were exported for the ComplianceScan.
+func (c *ConfigMapExporter) Cleanup(ctx context.Context) error {
+	configMapList := &corev1.ConfigMapList{}
+	if err := c.Client.List(ctx, configMapList,
+		client.InNamespace(c.Config.Namespace),
+		client.MatchingLabels{
+			constants.LabelAppManagedBy:      constants.LabelValueDikiOperator,
+			constants.LabelComplianceScanUID: string(c.ComplianceScan.UID),
+			constants.LabelReportOutputName:  c.OutputName,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to list ConfigMaps: %w", err)
+	}
+
+	for _, configMap := range configMapList.Items {
+		if err := c.Client.Delete(ctx, &configMap); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ConfigMap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *ConfigMapExporter) getLabels() map[string]string {
-	return map[string]string{
+	labels := map[string]string{
 		constants.LabelAppName:            constants.LabelValueDiki,
 		constants.LabelAppManagedBy:       constants.LabelValueDikiOperator,
 		constants.LabelComplianceScanName: c.ComplianceScan.Name,
 		constants.LabelComplianceScanUID:  string(c.ComplianceScan.UID),
+		constants.LabelReportOutputName:   c.OutputName,
 	}
+
+	// The ScheduledComplianceScan labels are propagated so that the retention of the reports can be applied per schedule.
+	for _, key := range []string{constants.LabelScheduledComplianceScanName, constants.LabelScheduledComplianceScanUID} {
+		if value, ok := c.ComplianceScan.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+
+	return labels
 }
@@ -60,7 +60,8 @@ var _ = Describe("Controller", func() {
 		}
 
 		cmExporter = outputs.ConfigMapExporter{
-			Client: fakeClient,
+			Client:     fakeClient,
+			OutputName: "output",
 			Config: dikiv1alpha1.OutputConfigMap{
 				Namespace:  "default",
 				NamePrefix: "diki-report-",
@@ -96,6 +97,7 @@ var _ = Describe("Controller", func() {
 			"app.kubernetes.io/managed-by":            "diki-operator",
 			"compliancescan.diki.gardener.cloud/name": "foo",
 			"compliancescan.diki.gardener.cloud/uid":  "111",
+			"reportoutput.diki.gardener.cloud/name":   "output",
 		}))
 		reportData := configMap.BinaryData["report.json.gz"]
 		Expect(reportData).ToNot(BeEmpty())
@@ -131,4 +133,63 @@ var _ = Describe("Controller", func() {
 		}, configMap)
 		Expect(err).ToNot(HaveOccurred())
 	})
+
+	It("should propagate the ScheduledComplianceScan labels of the ComplianceScan", func() {
+		cmExporter.ComplianceScan.Labels = map[string]string{
+			"scheduledcompliancescan.diki.gardener.cloud/name": "schedule",
+			"scheduledcompliancescan.diki.gardener.cloud/uid":  "222",
+			"foo": "bar",
+		}
+
+		details, err := cmExporter.Export(ctx, *dikiReport)
+		Expect(err).ToNot(HaveOccurred())
+
+		cmDetails, ok := details.(*outputs.ConfigMapDetails)
+		Expect(ok).To(BeTrue(), "details should be of type *ConfigMapDetails")
+
+		configMap := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{
+			Name:      cmDetails.ConfigMapRef.Name,
+			Namespace: cmDetails.ConfigMapRef.Namespace,
+		}, configMap)).To(Succeed())
+
+		Expect(configMap.Labels).To(Equal(map[string]string{
+			"app.kubernetes.io/name":                           "diki",
+			"app.kubernetes.io/managed-by":                     "diki-operator",
+			"compliancescan.diki.gardener.cloud/name":          "foo",
+			"compliancescan.diki.gardener.cloud/uid":           "111",
+			"reportoutput.diki.gardener.cloud/name":            "output",
+			"scheduledcompliancescan.diki.gardener.cloud/name": "schedule",
+			"scheduledcompliancescan.diki.gardener.cloud/uid":  "222",
+		}))
+	})
+
+	Describe("#Cleanup", func() {
+		It("should only delete the ConfigMaps exported for the ComplianceScan to the output", func() {
+			details, err := cmExporter.Export(ctx, *dikiReport)
+			Expect(err).ToNot(HaveOccurred())
+			exported := details.(*outputs.ConfigMapDetails).ConfigMapRef
+
+			otherOutputExporter := cmExporter
+			otherOutputExporter.OutputName = "other-output"
+			details, err = otherOutputExporter.Export(ctx, *dikiReport)
+			Expect(err).ToNot(HaveOccurred())
+			otherOutput := details.(*outputs.ConfigMapDetails).ConfigMapRef
+
+			otherScanExporter := cmExporter
+			otherScanExporter.ComplianceScan = &dikiv1alpha1.ComplianceScan{ObjectMeta: metav1.ObjectMeta{Name: "bar", UID: types.UID("222")}}
+			details, err = otherScanExporter.Export(ctx, *dikiReport)
+			Expect(err).ToNot(HaveOccurred())
+			otherScan := details.(*outputs.ConfigMapDetails).ConfigMapRef
+
+			Expect(cmExporter.Cleanup(ctx)).To(Succeed())
+
+			err = fakeClient.Get(ctx, client.ObjectKey{Name: exported.Name, Namespace: exported.Namespace}, &corev1.ConfigMap{})
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: otherOutput.Name, Namespace: otherOutput.Namespace}, &corev1.ConfigMap{})).To(Succeed())
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: otherScan.Name, Namespace: otherScan.Namespace}, &corev1.ConfigMap{})).To(Succeed())
+		})
+	})
 })
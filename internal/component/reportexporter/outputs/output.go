@@ -6,9 +6,12 @@ package outputs
 
 import (
 	"context"
+	"fmt"
 
 	dikireport "github.com/gardener/diki/pkg/report"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
 )
 
@@ -16,4 +19,15 @@ import (
 type Output interface {
 	Type() v1alpha1.OutputType
 	Export(ctx context.Context, report dikireport.Report) (exportDetails any, err error)
+	// Cleanup deletes the artifacts which were exported for the ComplianceScan.
+	Cleanup(ctx context.Context) error
+}
+
+// NewOutput creates the Output of the given ReportOutput for the ComplianceScan.
+func NewOutput(client client.Client, reportOutput *dikiv1alpha1.ReportOutput, complianceScan *dikiv1alpha1.ComplianceScan) (Output, error) {
+	if reportOutput.Spec.Output.ConfigMap != nil {
+		return NewConfigMapExporter(client, reportOutput.Name, *reportOutput.Spec.Output.ConfigMap, complianceScan), nil
+	}
+
+	return nil, fmt.Errorf("unsupported output type in ReportOutput %q", reportOutput.Name)
 }
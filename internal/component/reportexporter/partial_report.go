@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reportexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	dikireport "github.com/gardener/diki/pkg/report"
+	"github.com/gardener/diki/pkg/rule"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki-operator/internal/constants"
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
+)
+
+// maxPartialReportSize is the maximum size of the data stored in a partial report ConfigMap, as ConfigMaps
+// cannot hold more than 1 MiB of data.
+const maxPartialReportSize = 1024 * 1024
+
+// writePartialReport stores the gzip compressed report in the configured partial report ConfigMap.
+// The ConfigMap is owned by the ComplianceScan.
+func (d *ReportExporter) writePartialReport(ctx context.Context, complianceScan *dikiv1alpha1.ComplianceScan, report *dikireport.Report) error {
+	removeShardSkippedRules(report)
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report to JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gzWriter := gzip.NewWriter(&buf)
+	if _, err := gzWriter.Write(reportJSON); err != nil {
+		// call gzWriter.Close for the sake of completeness
+		// ignore the error as this would probably be the same error as the error returned by gzWriter.Write
+		_ = gzWriter.Close()
+		return fmt.Errorf("failed to compress report with gzip: %w", err)
+	}
+	if err := gzWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+
+	if size := buf.Len() + len(v1alpha1.PartialReportKey); size > maxPartialReportSize {
+		return fmt.Errorf("compressed partial report has %d bytes and exceeds the maximum size of %d bytes of a ConfigMap, increase the parallelism of the ComplianceScan to split the report", size, maxPartialReportSize)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.Config.PartialReport.Name,
+			Namespace: d.Config.PartialReport.Namespace,
+			Labels: map[string]string{
+				constants.LabelAppName:            constants.LabelValueDiki,
+				constants.LabelAppManagedBy:       constants.LabelValueDikiOperator,
+				constants.LabelComplianceScanName: complianceScan.Name,
+				constants.LabelComplianceScanUID:  string(complianceScan.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: dikiv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "ComplianceScan",
+					Name:       complianceScan.Name,
+					UID:        complianceScan.UID,
+				},
+			},
+		},
+		BinaryData: map[string][]byte{
+			v1alpha1.PartialReportKey: buf.Bytes(),
+		},
+	}
+
+	if err := d.Client.Create(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to create partial report ConfigMap: %w", err)
+	}
+
+	return nil
+}
+
+// readPartialReports reads the configured partial reports and merges them into a single report.
+func (d *ReportExporter) readPartialReports(ctx context.Context) (*dikireport.Report, error) {
+	reports := make([]dikireport.Report, 0, len(d.Config.PartialReports))
+	for _, ref := range d.Config.PartialReports {
+		configMap := &corev1.ConfigMap{}
+		if err := d.Client.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get partial report ConfigMap %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+
+		data, ok := configMap.BinaryData[v1alpha1.PartialReportKey]
+		if !ok {
+			return nil, fmt.Errorf("partial report ConfigMap %s/%s does not contain key %q", ref.Namespace, ref.Name, v1alpha1.PartialReportKey)
+		}
+
+		gzReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress partial report %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+		reportJSON, err := io.ReadAll(gzReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress partial report %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+
+		var report dikireport.Report
+		if err := json.Unmarshal(reportJSON, &report); err != nil {
+			return nil, fmt.Errorf("error unmarshaling partial report %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+
+		reports = append(reports, report)
+	}
+
+	return mergeReports(reports), nil
+}
+
+// mergeReports merges the given reports into a single report. Providers are merged by their ID and
+// rulesets by their ID and version. The time of the merged report is the time of the latest report.
+func mergeReports(reports []dikireport.Report) *dikireport.Report {
+	merged := &dikireport.Report{}
+
+	for i, report := range reports {
+		if i == 0 {
+			merged.MinStatus = report.MinStatus
+			merged.DikiVersion = report.DikiVersion
+		}
+
+		if report.Time.After(merged.Time) {
+			merged.Time = report.Time
+		}
+
+		if len(report.Metadata) > 0 {
+			if merged.Metadata == nil {
+				merged.Metadata = make(map[string]any, len(report.Metadata))
+			}
+			maps.Copy(merged.Metadata, report.Metadata)
+		}
+
+		for _, provider := range report.Providers {
+			pIdx := slices.IndexFunc(merged.Providers, func(p dikireport.Provider) bool {
+				return p.ID == provider.ID
+			})
+			if pIdx < 0 {
+				merged.Providers = append(merged.Providers, dikireport.Provider{
+					ID:       provider.ID,
+					Name:     provider.Name,
+					Metadata: maps.Clone(provider.Metadata),
+				})
+				pIdx = len(merged.Providers) - 1
+			} else if len(provider.Metadata) > 0 {
+				if merged.Providers[pIdx].Metadata == nil {
+					merged.Providers[pIdx].Metadata = make(map[string]string, len(provider.Metadata))
+				}
+				maps.Copy(merged.Providers[pIdx].Metadata, provider.Metadata)
+			}
+
+			mergedProvider := &merged.Providers[pIdx]
+			for _, ruleset := range provider.Rulesets {
+				rIdx := slices.IndexFunc(mergedProvider.Rulesets, func(r dikireport.Ruleset) bool {
+					return r.ID == ruleset.ID && r.Version == ruleset.Version
+				})
+				if rIdx < 0 {
+					ruleset.Rules = slices.Clone(ruleset.Rules)
+					mergedProvider.Rulesets = append(mergedProvider.Rulesets, ruleset)
+					continue
+				}
+				mergedProvider.Rulesets[rIdx].Rules = append(mergedProvider.Rulesets[rIdx].Rules, ruleset.Rules...)
+				slices.SortStableFunc(mergedProvider.Rulesets[rIdx].Rules, func(a, b dikireport.Rule) int {
+					return strings.Compare(a.ID, b.ID)
+				})
+			}
+		}
+	}
+
+	return merged
+}
+
+// removeShardSkippedRules removes the rules from the report which are skipped because they are scanned by another
+// shard of the compliance scan.
+func removeShardSkippedRules(report *dikireport.Report) {
+	for pIdx := range report.Providers {
+		for rIdx := range report.Providers[pIdx].Rulesets {
+			ruleset := &report.Providers[pIdx].Rulesets[rIdx]
+			ruleset.Rules = slices.DeleteFunc(ruleset.Rules, func(r dikireport.Rule) bool {
+				return len(r.Checks) == 1 && r.Checks[0].Status == rule.Accepted && r.Checks[0].Message == v1alpha1.ShardSkippedRuleJustification
+			})
+		}
+	}
+}
@@ -59,15 +59,32 @@ func (d *ReportExporter) Export(ctx context.Context) error {
 		return fmt.Errorf("complianceScan is in phase %q, expected %q", complianceScan.Status.Phase, dikiv1alpha1.ComplianceScanRunning)
 	}
 
-	if d.Config.WaitForReport {
-		if err := d.waitForReportFile(ctx); err != nil {
-			return fmt.Errorf("error waiting for report file: %w", err)
+	var (
+		report *dikireport.Report
+		err    error
+	)
+
+	if len(d.Config.PartialReports) > 0 {
+		report, err = d.readPartialReports(ctx)
+		if err != nil {
+			return fmt.Errorf("error reading partial reports: %w", err)
+		}
+	} else {
+		if d.Config.WaitForReport {
+			if err := d.waitForReportFile(ctx); err != nil {
+				return fmt.Errorf("error waiting for report file: %w", err)
+			}
+		}
+
+		report, err = d.readDikiReport()
+		if err != nil {
+			return fmt.Errorf("error reading diki report: %w", err)
 		}
 	}
 
-	report, err := d.readDikiReport()
-	if err != nil {
-		return fmt.Errorf("error reading diki report: %w", err)
+	// Partial reports of sharded compliance scans are only stored and exported once they are merged.
+	if d.Config.PartialReport != nil {
+		return d.writePartialReport(ctx, complianceScan, report)
 	}
 
 	outputs, err := d.createOutputs(complianceScan)
@@ -133,7 +150,7 @@ func (d *ReportExporter) createOutputs(complianceScan *dikiv1alpha1.ComplianceSc
 				return nil, fmt.Errorf("failed to unmarshal ConfigMapOutput: %w", err)
 			}
 
-			outputs[output.Name] = dikioutputs.NewConfigMapExporter(d.Client, configMapOutput, complianceScan)
+			outputs[output.Name] = dikioutputs.NewConfigMapExporter(d.Client, output.Name, configMapOutput, complianceScan)
 		default:
 			return nil, fmt.Errorf("unsupported output type: %s", output.Type)
 		}
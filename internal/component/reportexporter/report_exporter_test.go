@@ -5,11 +5,17 @@
 package reportexporter_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	cryptorand "crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	dikireport "github.com/gardener/diki/pkg/report"
@@ -595,6 +601,179 @@ var _ = Describe("ReportExporter", func() {
 		})
 	})
 
+	Describe("partial reports", func() {
+		gzipReport := func(report *dikireport.Report) []byte {
+			reportJSON, err := json.Marshal(report)
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			gzWriter := gzip.NewWriter(&buf)
+			_, err = gzWriter.Write(reportJSON)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gzWriter.Close()).To(Succeed())
+
+			return buf.Bytes()
+		}
+
+		It("should store the report as partial report without exporting it", func() {
+			exporter.Config.PartialReport = &v1alpha1.ConfigMapReference{
+				Name:      "diki-partial-report-0",
+				Namespace: "kube-system",
+			}
+
+			Expect(exporter.Export(ctx)).To(Succeed())
+
+			configMaps := &corev1.ConfigMapList{}
+			Expect(fakeClient.List(ctx, configMaps)).To(Succeed())
+			Expect(configMaps.Items).To(HaveLen(1))
+
+			partialReport := configMaps.Items[0]
+			Expect(partialReport.Name).To(Equal("diki-partial-report-0"))
+			Expect(partialReport.Namespace).To(Equal("kube-system"))
+			Expect(partialReport.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("ComplianceScan"),
+				"Name": Equal(complianceScan.Name),
+			})))
+			Expect(partialReport.BinaryData).To(HaveKeyWithValue(v1alpha1.PartialReportKey, gzipReport(dikiReport)))
+
+			updatedScan := &dikiv1alpha1.ComplianceScan{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, updatedScan)).To(Succeed())
+			Expect(updatedScan.Status.Rulesets).To(BeEmpty())
+			Expect(updatedScan.Status.Outputs).To(BeEmpty())
+		})
+
+		Context("with rules scanned by other shards", func() {
+			var expectedPartialReport []byte
+
+			BeforeEach(func() {
+				expectedPartialReport = gzipReport(dikiReport)
+
+				ruleset := &dikiReport.Providers[0].Rulesets[0]
+				ruleset.Rules = append(slices.Clone(ruleset.Rules), dikireport.Rule{
+					ID:     "rule-other-shard",
+					Name:   "Rule of other shard",
+					Checks: []dikireport.Check{{Status: rule.Accepted, Message: v1alpha1.ShardSkippedRuleJustification}},
+				})
+			})
+
+			It("should remove the rules scanned by other shards from the partial report", func() {
+				exporter.Config.PartialReport = &v1alpha1.ConfigMapReference{
+					Name:      "diki-partial-report-0",
+					Namespace: "kube-system",
+				}
+
+				Expect(exporter.Export(ctx)).To(Succeed())
+
+				partialReport := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "diki-partial-report-0", Namespace: "kube-system"}, partialReport)).To(Succeed())
+				Expect(partialReport.BinaryData).To(HaveKeyWithValue(v1alpha1.PartialReportKey, expectedPartialReport))
+			})
+		})
+
+		Context("with a partial report exceeding the size of a ConfigMap", func() {
+			BeforeEach(func() {
+				data := make([]byte, 1024*1024)
+				_, err := cryptorand.Read(data)
+				Expect(err).NotTo(HaveOccurred())
+
+				dikiReport.Providers[0].Rulesets[0].Rules[0].Checks[0].Message = base64.StdEncoding.EncodeToString(data)
+			})
+
+			It("should return an error instead of storing the partial report", func() {
+				exporter.Config.PartialReport = &v1alpha1.ConfigMapReference{
+					Name:      "diki-partial-report-0",
+					Namespace: "kube-system",
+				}
+
+				err := exporter.Export(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("exceeds the maximum size of 1048576 bytes of a ConfigMap"))
+
+				configMaps := &corev1.ConfigMapList{}
+				Expect(fakeClient.List(ctx, configMaps)).To(Succeed())
+				Expect(configMaps.Items).To(BeEmpty())
+			})
+		})
+
+		It("should merge the partial reports and export the merged report", func() {
+			otherReport := &dikireport.Report{
+				Time: time.Now(),
+				Providers: []dikireport.Provider{
+					{
+						ID:   "test-provider",
+						Name: "Test Provider",
+						Rulesets: []dikireport.Ruleset{
+							{
+								ID:      "other-ruleset",
+								Name:    "Other Ruleset",
+								Version: "v2.0.0",
+								Rules: []dikireport.Rule{
+									{
+										ID:     "rule-1",
+										Name:   "Other Rule 1",
+										Checks: []dikireport.Check{{Status: rule.Failed}},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			for i, report := range []*dikireport.Report{dikiReport, otherReport} {
+				Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("diki-partial-report-%d", i),
+						Namespace: "kube-system",
+					},
+					BinaryData: map[string][]byte{
+						v1alpha1.PartialReportKey: gzipReport(report),
+					},
+				})).To(Succeed())
+				exporter.Config.PartialReports = append(exporter.Config.PartialReports, v1alpha1.ConfigMapReference{
+					Name:      fmt.Sprintf("diki-partial-report-%d", i),
+					Namespace: "kube-system",
+				})
+			}
+			exporter.Config.ReportPath = ""
+
+			Expect(exporter.Export(ctx)).To(Succeed())
+
+			updatedScan := &dikiv1alpha1.ComplianceScan{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, updatedScan)).To(Succeed())
+			Expect(updatedScan.Status.Rulesets).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"ID":      Equal("test-ruleset"),
+					"Version": Equal("v1.0.0"),
+					"Results": MatchFields(IgnoreExtras, Fields{
+						"Summary": MatchFields(IgnoreExtras, Fields{"Passed": Equal(int32(3)), "Failed": Equal(int32(2))}),
+					}),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"ID":      Equal("other-ruleset"),
+					"Version": Equal("v2.0.0"),
+					"Results": MatchFields(IgnoreExtras, Fields{
+						"Summary": MatchFields(IgnoreExtras, Fields{"Passed": Equal(int32(0)), "Failed": Equal(int32(1))}),
+					}),
+				}),
+			))
+			Expect(updatedScan.Status.Outputs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"OutputName": Equal("test-output"),
+				"Phase":      Equal(dikiv1alpha1.OutputStatusCompleted),
+			})))
+		})
+
+		It("should return error if a partial report does not exist", func() {
+			exporter.Config.PartialReports = []v1alpha1.ConfigMapReference{
+				{Name: "diki-partial-report-0", Namespace: "kube-system"},
+			}
+
+			err := exporter.Export(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to get partial report ConfigMap kube-system/diki-partial-report-0"))
+		})
+	})
+
 	Describe("NewReportExporter", func() {
 		It("should create a new ReportExporter instance", func() {
 			config := v1alpha1.ReportExporterConfiguration{
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reportexporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
+)
+
+// WriteTerminationMessage writes the given error as a structured termination message to the given path,
+// so that it is reported in the status of the report-exporter container.
+func WriteTerminationMessage(path string, err error) error {
+	data, marshalErr := json.Marshal(v1alpha1.TerminationMessage{Error: err.Error()})
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal termination message: %w", marshalErr)
+	}
+
+	if writeErr := os.WriteFile(path, data, 0600); writeErr != nil {
+		return fmt.Errorf("failed to write termination message: %w", writeErr)
+	}
+
+	return nil
+}
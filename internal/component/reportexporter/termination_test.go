@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reportexporter_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki-operator/internal/component/reportexporter"
+)
+
+var _ = Describe("#WriteTerminationMessage", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "report-exporter-termination-test-")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	It("should write the error as structured termination message", func() {
+		path := filepath.Join(tempDir, "termination-log")
+
+		Expect(reportexporter.WriteTerminationMessage(path, errors.New("foo failed"))).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"error":"foo failed"}`))
+	})
+
+	It("should return an error when the termination message cannot be written", func() {
+		path := filepath.Join(tempDir, "non-existent", "termination-log")
+
+		Expect(reportexporter.WriteTerminationMessage(path, errors.New("foo failed"))).To(MatchError(ContainSubstring("failed to write termination message")))
+	})
+})
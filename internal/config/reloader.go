@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
+	"github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/validation"
+)
+
+// DefaultReloadInterval is the default interval in which the configuration file is checked for changes.
+const DefaultReloadInterval = 10 * time.Second
+
+// Reloader watches the configuration file and swaps the ComplianceScan configuration in the Store when it changes.
+// Invalid configurations and changes which require a restart of the operator are rejected, and the last valid
+// configuration is kept.
+type Reloader struct {
+	// Path is the path of the configuration file.
+	Path string
+	// Decoder decodes and defaults the configuration file.
+	Decoder runtime.Decoder
+	// Store is the Store whose configuration is swapped.
+	Store *Store
+	// Interval is the interval in which the configuration file is checked for changes.
+	Interval time.Duration
+	// Log is the logger.
+	Log logr.Logger
+
+	lastData []byte
+}
+
+var (
+	_ manager.Runnable               = &Reloader{}
+	_ manager.LeaderElectionRunnable = &Reloader{}
+)
+
+// Start polls the configuration file until the context is cancelled.
+// The file is polled instead of watched because mounted ConfigMaps are updated by swapping symlinks.
+func (r *Reloader) Start(ctx context.Context) error {
+	r.Log.Info("Watching configuration file for changes", "path", r.Path, "interval", r.Interval)
+
+	wait.UntilWithContext(ctx, func(_ context.Context) {
+		if err := r.Reload(); err != nil {
+			r.Log.Error(err, "Rejected configuration change, keeping the last valid configuration", "path", r.Path)
+		}
+	}, r.Interval)
+
+	return nil
+}
+
+// NeedLeaderElection returns false because the webhooks of all replicas use the configuration.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload reads the configuration file and swaps the ComplianceScan configuration in the Store if it has changed.
+func (r *Reloader) Reload() error {
+	data, err := os.ReadFile(r.Path)
+	if err != nil {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	if r.lastData != nil && bytes.Equal(data, r.lastData) {
+		return nil
+	}
+	r.lastData = data
+
+	config := &configv1alpha1.DikiOperatorConfiguration{}
+	if err := runtime.DecodeInto(r.Decoder, data, config); err != nil {
+		return fmt.Errorf("error decoding config: %w", err)
+	}
+
+	if errs := validation.ValidateDikiOperatorConfiguration(config); len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errs.ToAggregate())
+	}
+
+	var (
+		oldConfig = r.Store.Get()
+		newConfig = &config.Controllers.ComplianceScan
+	)
+
+	if apiequality.Semantic.DeepEqual(oldConfig, newConfig) {
+		return nil
+	}
+
+	if err := checkRestartRequired(oldConfig, newConfig); err != nil {
+		return err
+	}
+
+	r.Store.Set(newConfig)
+	r.Log.Info("Reloaded ComplianceScan configuration", "diff", cmp.Diff(oldConfig, newConfig))
+
+	return nil
+}
+
+// checkRestartRequired returns an error if the given configurations differ in settings which are only
+// evaluated when the operator starts.
+func checkRestartRequired(oldConfig, newConfig *configv1alpha1.ComplianceScanConfig) error {
+	if (oldConfig.DikiRunner.TargetKubeconfig == nil) != (newConfig.DikiRunner.TargetKubeconfig == nil) {
+		return errors.New("switching between scanning the local and a remote cluster requires a restart")
+	}
+
+	if !slices.Equal(configv1alpha1helper.DikiRunnerNamespaces(oldConfig), configv1alpha1helper.DikiRunnerNamespaces(newConfig)) {
+		return errors.New("changing the DikiRunner namespaces requires a restart")
+	}
+
+	if !apiequality.Semantic.DeepEqual(oldConfig.ControllerOptions, newConfig.ControllerOptions) {
+		return errors.New("changing the controller options requires a restart")
+	}
+
+	return nil
+}
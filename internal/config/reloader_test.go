@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/utils/ptr"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/gardener/diki-operator/internal/config"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+)
+
+const baseConfig = `apiVersion: config.diki.gardener.cloud/v1alpha1
+kind: DikiOperatorConfiguration
+server:
+  webhooks:
+    tls:
+      serverCertDir: /tmp/certs
+controllers:
+  complianceScan:
+    dikiRunner:
+      namespace: kube-system
+`
+
+var _ = Describe("Reloader", func() {
+	var (
+		configFile string
+		store      *config.Store
+		reloader   *config.Reloader
+	)
+
+	writeConfig := func(data string) {
+		Expect(os.WriteFile(configFile, []byte(data), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		configFile = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeConfig(baseConfig)
+
+		scheme := runtime.NewScheme()
+		Expect(configv1alpha1.AddToScheme(scheme)).To(Succeed())
+		decoder := serializer.NewCodecFactory(scheme).UniversalDecoder()
+
+		initialConfig := &configv1alpha1.DikiOperatorConfiguration{}
+		Expect(runtime.DecodeInto(decoder, []byte(baseConfig), initialConfig)).To(Succeed())
+
+		store = config.NewStore(&initialConfig.Controllers.ComplianceScan)
+		reloader = &config.Reloader{
+			Path:     configFile,
+			Decoder:  decoder,
+			Store:    store,
+			Interval: 10 * time.Millisecond,
+			Log:      logzap.New(logzap.WriteTo(GinkgoWriter)),
+		}
+	})
+
+	It("should keep the configuration when the file has not changed", func() {
+		oldConfig := store.Get()
+
+		Expect(reloader.Reload()).To(Succeed())
+		Expect(store.Get()).To(BeIdenticalTo(oldConfig))
+	})
+
+	It("should swap the configuration when the file has changed", func() {
+		writeConfig(baseConfig + `      labels:
+        foo: bar
+    maxConcurrentJobs: 5
+`)
+
+		Expect(reloader.Reload()).To(Succeed())
+		Expect(store.Get().DikiRunner.Labels).To(Equal(map[string]string{"foo": "bar"}))
+		Expect(store.Get().MaxConcurrentJobs).To(Equal(ptr.To(int32(5))))
+		Expect(store.Get().DikiRunner.PodCompletionTimeout.Duration).To(Equal(configv1alpha1.DefaultPodCompletionTimeout))
+	})
+
+	It("should reject an invalid configuration and keep the last valid one", func() {
+		oldConfig := store.Get()
+		writeConfig(baseConfig + `    maxConcurrentJobs: 0
+`)
+
+		Expect(reloader.Reload()).To(MatchError(ContainSubstring("controllers.complianceScan.maxConcurrentJobs")))
+		Expect(store.Get()).To(BeIdenticalTo(oldConfig))
+	})
+
+	It("should reject a configuration which cannot be decoded and keep the last valid one", func() {
+		oldConfig := store.Get()
+		writeConfig("controllers: [")
+
+		Expect(reloader.Reload()).To(MatchError(ContainSubstring("error decoding config")))
+		Expect(store.Get()).To(BeIdenticalTo(oldConfig))
+	})
+
+	It("should reject changes which require a restart", func() {
+		oldConfig := store.Get()
+		writeConfig(strings.Replace(baseConfig, "namespace: kube-system", "namespace: diki", 1))
+
+		Expect(reloader.Reload()).To(MatchError("changing the DikiRunner namespaces requires a restart"))
+		Expect(store.Get()).To(BeIdenticalTo(oldConfig))
+
+		writeConfig(baseConfig + `    concurrentSyncs: 10
+`)
+
+		Expect(reloader.Reload()).To(MatchError("changing the controller options requires a restart"))
+		Expect(store.Get()).To(BeIdenticalTo(oldConfig))
+	})
+
+	It("should reload the configuration periodically until the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			Expect(reloader.Start(ctx)).To(Succeed())
+		}()
+
+		writeConfig(baseConfig + `    maxConcurrentJobs: 3
+`)
+		Eventually(func() *int32 { return store.Get().MaxConcurrentJobs }).Should(Equal(ptr.To(int32(3))))
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+})
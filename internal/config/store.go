@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sync/atomic"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+)
+
+// Store holds the ComplianceScan configuration which is shared by the reconcilers and webhooks.
+// The configuration can be swapped atomically while the operator is running.
+type Store struct {
+	config atomic.Pointer[configv1alpha1.ComplianceScanConfig]
+}
+
+// NewStore returns a Store holding a copy of the given configuration.
+func NewStore(config *configv1alpha1.ComplianceScanConfig) *Store {
+	s := &Store{}
+	s.Set(config)
+	return s
+}
+
+// Get returns the current configuration. It must not be modified by the caller.
+func (s *Store) Get() *configv1alpha1.ComplianceScanConfig {
+	return s.config.Load()
+}
+
+// Set replaces the current configuration with a copy of the given configuration.
+func (s *Store) Set(config *configv1alpha1.ComplianceScanConfig) {
+	s.config.Store(config.DeepCopy())
+}
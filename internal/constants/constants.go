@@ -9,6 +9,18 @@ const (
 	LabelComplianceScanName = "compliancescan.diki.gardener.cloud/name"
 	// LabelComplianceScanUID is the label used to identify resources connected to a ComplianceScan by UID.
 	LabelComplianceScanUID = "compliancescan.diki.gardener.cloud/uid"
+	// LabelScheduledComplianceScanName is the label used to identify resources connected to a ScheduledComplianceScan by name.
+	LabelScheduledComplianceScanName = "scheduledcompliancescan.diki.gardener.cloud/name"
+	// LabelScheduledComplianceScanUID is the label used to identify resources connected to a ScheduledComplianceScan by UID.
+	LabelScheduledComplianceScanUID = "scheduledcompliancescan.diki.gardener.cloud/uid"
+	// LabelNamespaceComplianceScanName is the label used to identify resources connected to a NamespaceComplianceScan by name.
+	LabelNamespaceComplianceScanName = "namespacecompliancescan.diki.gardener.cloud/name"
+	// LabelNamespaceComplianceScanNamespace is the label used to identify resources connected to a NamespaceComplianceScan by namespace.
+	LabelNamespaceComplianceScanNamespace = "namespacecompliancescan.diki.gardener.cloud/namespace"
+	// LabelNamespaceComplianceScanUID is the label used to identify resources connected to a NamespaceComplianceScan by UID.
+	LabelNamespaceComplianceScanUID = "namespacecompliancescan.diki.gardener.cloud/uid"
+	// LabelReportOutputName is the label used to identify resources exported to a ReportOutput by name.
+	LabelReportOutputName = "reportoutput.diki.gardener.cloud/name"
 
 	// LabelAppName is the standard Kubernetes label key for application name.
 	LabelAppName = "app.kubernetes.io/name"
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerutils
+
+import (
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+)
+
+// Options returns the controller options for the given configuration.
+// Options which are not set are left to the defaults of controller-runtime.
+func Options(opts configv1alpha1.ControllerOptions) controller.Options {
+	options := controller.Options{}
+
+	if opts.ConcurrentSyncs != nil {
+		options.MaxConcurrentReconciles = *opts.ConcurrentSyncs
+	}
+
+	if opts.ReconciliationTimeout != nil {
+		options.ReconciliationTimeout = opts.ReconciliationTimeout.Duration
+	}
+
+	if opts.RateLimiter != nil {
+		options.RateLimiter = RateLimiter(*opts.RateLimiter)
+	}
+
+	return options
+}
+
+// RateLimiter returns a rate limiter which combines an exponential per-item backoff with an overall token bucket.
+// Fields which are not set fall back to their defaults.
+func RateLimiter(config configv1alpha1.RateLimiterConfig) workqueue.TypedRateLimiter[reconcile.Request] {
+	var (
+		baseDelay = configv1alpha1.DefaultRateLimiterBaseDelay
+		maxDelay  = configv1alpha1.DefaultRateLimiterMaxDelay
+		qps       = ptr.Deref(config.QPS, configv1alpha1.DefaultRateLimiterQPS)
+		burst     = ptr.Deref(config.Burst, configv1alpha1.DefaultRateLimiterBurst)
+	)
+
+	if config.BaseDelay != nil {
+		baseDelay = config.BaseDelay.Duration
+	}
+	if config.MaxDelay != nil {
+		maxDelay = config.MaxDelay.Duration
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](baseDelay, maxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(qps), int(burst))},
+	)
+}
@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerutils_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	. "github.com/gardener/diki-operator/internal/controllerutils"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+)
+
+var _ = Describe("Options", func() {
+	Describe("#Options", func() {
+		It("should leave unset options to the controller-runtime defaults", func() {
+			options := Options(configv1alpha1.ControllerOptions{})
+
+			Expect(options.MaxConcurrentReconciles).To(BeZero())
+			Expect(options.ReconciliationTimeout).To(BeZero())
+			Expect(options.RateLimiter).To(BeNil())
+		})
+
+		It("should set the configured options", func() {
+			options := Options(configv1alpha1.ControllerOptions{
+				ConcurrentSyncs:       ptr.To(3),
+				ReconciliationTimeout: &metav1.Duration{Duration: time.Minute},
+				RateLimiter:           &configv1alpha1.RateLimiterConfig{},
+			})
+
+			Expect(options.MaxConcurrentReconciles).To(Equal(3))
+			Expect(options.ReconciliationTimeout).To(Equal(time.Minute))
+			Expect(options.RateLimiter).NotTo(BeNil())
+		})
+	})
+
+	Describe("#RateLimiter", func() {
+		var item = reconcile.Request{}
+
+		It("should back off exponentially between the configured delays", func() {
+			rateLimiter := RateLimiter(configv1alpha1.RateLimiterConfig{
+				BaseDelay: &metav1.Duration{Duration: time.Second},
+				MaxDelay:  &metav1.Duration{Duration: 3 * time.Second},
+				QPS:       ptr.To(int32(100)),
+				Burst:     ptr.To(int32(100)),
+			})
+
+			Expect(rateLimiter.When(item)).To(Equal(time.Second))
+			Expect(rateLimiter.When(item)).To(Equal(2 * time.Second))
+			Expect(rateLimiter.When(item)).To(Equal(3 * time.Second))
+
+			rateLimiter.Forget(item)
+			Expect(rateLimiter.When(item)).To(Equal(time.Second))
+		})
+
+		It("should fall back to the default delays", func() {
+			rateLimiter := RateLimiter(configv1alpha1.RateLimiterConfig{})
+
+			Expect(rateLimiter.When(item)).To(Equal(configv1alpha1.DefaultRateLimiterBaseDelay))
+		})
+	})
+})
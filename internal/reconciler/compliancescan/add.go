@@ -5,28 +5,26 @@
 package reconciler
 
 import (
-	"time"
+	"context"
 
-	"golang.org/x/time/rate"
-	"k8s.io/client-go/util/workqueue"
+	batchv1 "k8s.io/api/batch/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/gardener/diki-operator/internal/constants"
+	"github.com/gardener/diki-operator/internal/controllerutils"
 	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
-const (
-	// ControllerName is the name of the compliancescan controller.
-	ControllerName = "compliancescan"
-	// ReconciliationTimeout is the timeout passed to the context of the Reconcile call.
-	ReconciliationTimeout = 10 * time.Minute
-	// MaxConcurrentReconciles is the maximum number of concurrent Reconcile calls.
-	MaxConcurrentReconciles = 5
-)
+// ControllerName is the name of the compliancescan controller.
+const ControllerName = "compliancescan"
 
 // SetupWithManager specifies how the controller is built to watch ComplianceScan resources.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -38,30 +36,119 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		r.SourceClient = mgr.GetClient()
 	}
 
+	if r.SourceCache == nil {
+		r.SourceCache = mgr.GetCache()
+	}
+
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+
 	if r.RESTConfig == nil {
 		r.RESTConfig = mgr.GetConfig()
 	}
 
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorder(ControllerName)
+	}
+
 	return builder.ControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&dikiv1alpha1.ComplianceScan{}, builder.WithPredicates(r.Predicate())).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: MaxConcurrentReconciles,
-			RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
-				workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](5*time.Second, 2*time.Minute),
-				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
-			),
-			ReconciliationTimeout: ReconciliationTimeout,
-		}).
+		Watches(
+			&dikiv1alpha1.ComplianceScan{},
+			handler.EnqueueRequestsFromMapFunc(r.MapToQueuedComplianceScans),
+			builder.WithPredicates(r.ComplianceScanFinishedPredicate()),
+		).
+		WatchesRawSource(source.Kind(
+			r.SourceCache,
+			&batchv1.Job{},
+			handler.TypedEnqueueRequestsFromMapFunc(r.MapJobToComplianceScan),
+			r.JobPredicate(),
+		)).
+		WithOptions(controllerutils.Options(r.getConfig().ControllerOptions)).
 		Complete(r)
 }
 
 // Predicate returns a predicate to filter ComplianceScan events.
+// Updates only pass if the ComplianceScan is being deleted, so that its resources are cleaned up.
 func (r *Reconciler) Predicate() predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc:  func(_ event.CreateEvent) bool { return true },
-		UpdateFunc:  func(_ event.UpdateEvent) bool { return false },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return e.ObjectNew.GetDeletionTimestamp() != nil },
 		DeleteFunc:  func(_ event.DeleteEvent) bool { return false },
 		GenericFunc: func(_ event.GenericEvent) bool { return false },
 	}
 }
+
+// ComplianceScanFinishedPredicate returns a predicate which only lets the updates of ComplianceScans pass
+// which reached a terminal phase, i.e. which released their diki-run Jobs.
+func (r *Reconciler) ComplianceScanFinishedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldComplianceScan, ok := e.ObjectOld.(*dikiv1alpha1.ComplianceScan)
+			if !ok {
+				return false
+			}
+			newComplianceScan, ok := e.ObjectNew.(*dikiv1alpha1.ComplianceScan)
+			if !ok {
+				return false
+			}
+
+			return !isFinished(oldComplianceScan) && isFinished(newComplianceScan)
+		},
+		DeleteFunc:  func(_ event.DeleteEvent) bool { return true },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+	}
+}
+
+// MapToQueuedComplianceScans maps an event of a ComplianceScan which released its diki-run Jobs to all queued
+// ComplianceScans, so that they are admitted without waiting for the next sync.
+func (r *Reconciler) MapToQueuedComplianceScans(ctx context.Context, _ client.Object) []reconcile.Request {
+	complianceScanList := &dikiv1alpha1.ComplianceScanList{}
+	if err := r.Client.List(ctx, complianceScanList); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ComplianceScans")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, complianceScan := range complianceScanList.Items {
+		if complianceScan.Status.Phase == dikiv1alpha1.ComplianceScanQueued {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&complianceScan)})
+		}
+	}
+
+	return requests
+}
+
+// JobPredicate returns a predicate which only lets the events of diki-run Jobs pass which finished or were deleted.
+func (r *Reconciler) JobPredicate() predicate.TypedPredicate[*batchv1.Job] {
+	return predicate.TypedFuncs[*batchv1.Job]{
+		CreateFunc: func(_ event.TypedCreateEvent[*batchv1.Job]) bool { return false },
+		UpdateFunc: func(e event.TypedUpdateEvent[*batchv1.Job]) bool {
+			return !isJobFinished(e.ObjectOld) && isJobFinished(e.ObjectNew)
+		},
+		DeleteFunc:  func(_ event.TypedDeleteEvent[*batchv1.Job]) bool { return true },
+		GenericFunc: func(_ event.TypedGenericEvent[*batchv1.Job]) bool { return false },
+	}
+}
+
+// MapJobToComplianceScan maps a diki-run Job to the ComplianceScan it was created for.
+func (r *Reconciler) MapJobToComplianceScan(_ context.Context, job *batchv1.Job) []reconcile.Request {
+	name, ok := job.Labels[constants.LabelComplianceScanName]
+	if !ok || name == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: name}}}
+}
+
+func isFinished(complianceScan *dikiv1alpha1.ComplianceScan) bool {
+	return complianceScan.Status.Phase == dikiv1alpha1.ComplianceScanCompleted ||
+		complianceScan.Status.Phase == dikiv1alpha1.ComplianceScanFailed
+}
+
+func isJobFinished(job *batchv1.Job) bool {
+	return getJobCondition(job, batchv1.JobComplete) != nil || getJobCondition(job, batchv1.JobFailed) != nil
+}
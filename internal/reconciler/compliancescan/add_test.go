@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	compliancescan "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
+	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+var _ = Describe("Add", func() {
+	var (
+		ctx = context.Background()
+
+		fakeClient client.Client
+		cr         *compliancescan.Reconciler
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(dikiinstall.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		cr = &compliancescan.Reconciler{Client: fakeClient}
+	})
+
+	Describe("#JobPredicate", func() {
+		var (
+			runningJob  *batchv1.Job
+			finishedJob *batchv1.Job
+		)
+
+		BeforeEach(func() {
+			runningJob = &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "diki-run-1"}}
+			finishedJob = runningJob.DeepCopy()
+			finishedJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		})
+
+		It("should ignore create events", func() {
+			Expect(cr.JobPredicate().Create(event.TypedCreateEvent[*batchv1.Job]{Object: finishedJob})).To(BeFalse())
+		})
+
+		It("should only let updates pass which finish the Job", func() {
+			Expect(cr.JobPredicate().Update(event.TypedUpdateEvent[*batchv1.Job]{ObjectOld: runningJob, ObjectNew: finishedJob})).To(BeTrue())
+			Expect(cr.JobPredicate().Update(event.TypedUpdateEvent[*batchv1.Job]{ObjectOld: runningJob, ObjectNew: runningJob})).To(BeFalse())
+			Expect(cr.JobPredicate().Update(event.TypedUpdateEvent[*batchv1.Job]{ObjectOld: finishedJob, ObjectNew: finishedJob})).To(BeFalse())
+		})
+
+		It("should let delete events pass", func() {
+			Expect(cr.JobPredicate().Delete(event.TypedDeleteEvent[*batchv1.Job]{Object: runningJob})).To(BeTrue())
+		})
+	})
+
+	Describe("#MapJobToComplianceScan", func() {
+		It("should map the Job to the ComplianceScan it was created for", func() {
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name:   "diki-run-1",
+				Labels: map[string]string{"compliancescan.diki.gardener.cloud/name": "foo"},
+			}}
+
+			Expect(cr.MapJobToComplianceScan(ctx, job)).To(ConsistOf(reconcile.Request{NamespacedName: client.ObjectKey{Name: "foo"}}))
+		})
+
+		It("should not map a Job without ComplianceScan label", func() {
+			Expect(cr.MapJobToComplianceScan(ctx, &batchv1.Job{})).To(BeEmpty())
+		})
+	})
+
+	Describe("#ComplianceScanFinishedPredicate", func() {
+		var (
+			runningScan  *dikiv1alpha1.ComplianceScan
+			finishedScan *dikiv1alpha1.ComplianceScan
+		)
+
+		BeforeEach(func() {
+			runningScan = &dikiv1alpha1.ComplianceScan{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Status:     dikiv1alpha1.ComplianceScanStatus{Phase: dikiv1alpha1.ComplianceScanRunning},
+			}
+			finishedScan = runningScan.DeepCopy()
+			finishedScan.Status.Phase = dikiv1alpha1.ComplianceScanCompleted
+		})
+
+		It("should only let updates pass which finish the ComplianceScan", func() {
+			Expect(cr.ComplianceScanFinishedPredicate().Update(event.UpdateEvent{ObjectOld: runningScan, ObjectNew: finishedScan})).To(BeTrue())
+			Expect(cr.ComplianceScanFinishedPredicate().Update(event.UpdateEvent{ObjectOld: runningScan, ObjectNew: runningScan})).To(BeFalse())
+			Expect(cr.ComplianceScanFinishedPredicate().Update(event.UpdateEvent{ObjectOld: finishedScan, ObjectNew: finishedScan})).To(BeFalse())
+		})
+
+		It("should ignore create events and let delete events pass", func() {
+			Expect(cr.ComplianceScanFinishedPredicate().Create(event.CreateEvent{Object: runningScan})).To(BeFalse())
+			Expect(cr.ComplianceScanFinishedPredicate().Delete(event.DeleteEvent{Object: runningScan})).To(BeTrue())
+		})
+	})
+
+	Describe("#MapToQueuedComplianceScans", func() {
+		It("should map to all queued ComplianceScans", func() {
+			for name, phase := range map[string]dikiv1alpha1.ComplianceScanPhase{
+				"queued-1":  dikiv1alpha1.ComplianceScanQueued,
+				"queued-2":  dikiv1alpha1.ComplianceScanQueued,
+				"running":   dikiv1alpha1.ComplianceScanRunning,
+				"completed": dikiv1alpha1.ComplianceScanCompleted,
+			} {
+				Expect(fakeClient.Create(ctx, &dikiv1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: name},
+					Status:     dikiv1alpha1.ComplianceScanStatus{Phase: phase},
+				})).To(Succeed())
+			}
+
+			Expect(cr.MapToQueuedComplianceScans(ctx, &dikiv1alpha1.ComplianceScan{})).To(ConsistOf(
+				reconcile.Request{NamespacedName: client.ObjectKey{Name: "queued-1"}},
+				reconcile.Request{NamespacedName: client.ObjectKey{Name: "queued-2"}},
+			))
+		})
+	})
+})
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+	v1alpha1helper "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1/helper"
+)
+
+// admit admits the compliance scan and sets its phase to Running if the configured limits of concurrently running
+// diki-run Jobs allow it. Otherwise, the compliance scan is set to phase Queued together with its queue position.
+// Admissions are serialized, so that concurrent reconciliations cannot exceed the limits.
+func (r *Reconciler) admit(ctx context.Context, cfg *configv1alpha1.ComplianceScanConfig, complianceScan *v1alpha1.ComplianceScan, log logr.Logger) (bool, error) {
+	r.admissionMutex.Lock()
+	defer r.admissionMutex.Unlock()
+
+	queuePosition, err := r.getQueuePosition(ctx, cfg, complianceScan)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine queue position: %w", err)
+	}
+
+	if queuePosition > 0 {
+		return false, r.patchQueued(ctx, complianceScan, queuePosition, log)
+	}
+
+	return true, r.patchRunning(ctx, complianceScan, log)
+}
+
+// getQueuePosition returns the position of the compliance scan in the queue of compliance scans waiting for admission,
+// or 0 if it can be admitted. Waiting compliance scans are admitted in the order of their priority and creation. Preceding
+// compliance scans which fit into the limits reserve their Jobs, hence subsequent ones cannot overtake them.
+func (r *Reconciler) getQueuePosition(ctx context.Context, cfg *configv1alpha1.ComplianceScanConfig, complianceScan *v1alpha1.ComplianceScan) (int32, error) {
+	if !hasConcurrencyLimits(cfg) {
+		return 0, nil
+	}
+
+	// The API reader is used because the cache might not yet contain the phases patched by previous admissions.
+	complianceScanList := &v1alpha1.ComplianceScanList{}
+	if err := r.APIReader.List(ctx, complianceScanList); err != nil {
+		return 0, fmt.Errorf("failed to list ComplianceScans: %w", err)
+	}
+
+	var (
+		runningJobs        int
+		runningProfileJobs = map[string]int{}
+		waiting            = []v1alpha1.ComplianceScan{*complianceScan}
+	)
+
+	for _, cs := range complianceScanList.Items {
+		switch {
+		case cs.UID == complianceScan.UID:
+			continue
+		case cs.Status.Phase == v1alpha1.ComplianceScanRunning:
+			runningJobs += getNumShards(&cs)
+			runningProfileJobs[cs.Spec.RunnerProfile] += getNumShards(&cs)
+		case isWaitingForAdmission(&cs):
+			waiting = append(waiting, cs)
+		}
+	}
+
+	slices.SortFunc(waiting, func(a, b v1alpha1.ComplianceScan) int {
+		if c := cmp.Compare(ptr.Deref(b.Spec.Priority, 0), ptr.Deref(a.Spec.Priority, 0)); c != 0 {
+			return c
+		}
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var queuePosition int32
+	for _, cs := range waiting {
+		numJobs := getNumShards(&cs)
+		if fitsConcurrencyLimit(cfg.MaxConcurrentJobs, runningJobs, numJobs) &&
+			fitsConcurrencyLimit(getProfileMaxConcurrentJobs(cfg, cs.Spec.RunnerProfile), runningProfileJobs[cs.Spec.RunnerProfile], numJobs) {
+			if cs.UID == complianceScan.UID {
+				return 0, nil
+			}
+
+			runningJobs += numJobs
+			runningProfileJobs[cs.Spec.RunnerProfile] += numJobs
+			continue
+		}
+
+		queuePosition++
+		if cs.UID == complianceScan.UID {
+			return queuePosition, nil
+		}
+	}
+
+	return queuePosition, nil
+}
+
+func hasConcurrencyLimits(cfg *configv1alpha1.ComplianceScanConfig) bool {
+	if cfg.MaxConcurrentJobs != nil || cfg.DikiRunner.MaxConcurrentJobs != nil {
+		return true
+	}
+
+	for _, dikiRunner := range cfg.DikiRunnerProfiles {
+		if dikiRunner.MaxConcurrentJobs != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getProfileMaxConcurrentJobs returns the limit of concurrently running Jobs of the given runner profile.
+// Compliance scans referencing an unknown runner profile fail once they are admitted, hence they are not limited.
+func getProfileMaxConcurrentJobs(cfg *configv1alpha1.ComplianceScanConfig, profile string) *int32 {
+	dikiRunner, err := configv1alpha1helper.GetDikiRunnerConfig(cfg, profile)
+	if err != nil {
+		return nil
+	}
+
+	return dikiRunner.MaxConcurrentJobs
+}
+
+// fitsConcurrencyLimit returns whether numJobs additional Jobs fit into the given limit. A compliance scan with more
+// shards than the limit is admitted if no other Job is running, so that it is not queued forever.
+func fitsConcurrencyLimit(limit *int32, runningJobs, numJobs int) bool {
+	return limit == nil || runningJobs == 0 || runningJobs+numJobs <= int(*limit)
+}
+
+func isWaitingForAdmission(complianceScan *v1alpha1.ComplianceScan) bool {
+	return complianceScan.DeletionTimestamp == nil &&
+		(complianceScan.Status.Phase == "" ||
+			complianceScan.Status.Phase == v1alpha1.ComplianceScanPending ||
+			complianceScan.Status.Phase == v1alpha1.ComplianceScanQueued)
+}
+
+func (r *Reconciler) patchQueued(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, queuePosition int32, log logr.Logger) error {
+	if complianceScan.Status.Phase == v1alpha1.ComplianceScanQueued && ptr.Deref(complianceScan.Status.QueuePosition, 0) == queuePosition {
+		return nil
+	}
+
+	patch := client.MergeFrom(complianceScan.DeepCopy())
+	complianceScan.Status.Phase = v1alpha1.ComplianceScanQueued
+	complianceScan.Status.QueuePosition = &queuePosition
+	complianceScan.Status.Conditions = v1alpha1helper.UpdateConditions(
+		complianceScan.Status.Conditions,
+		v1alpha1.ConditionTypeCompleted,
+		v1alpha1.ConditionFalse,
+		ConditionReasonQueued,
+		fmt.Sprintf("ComplianceScan is queued at position %d", queuePosition),
+		time.Now(),
+	)
+
+	if err := r.Client.Status().Patch(ctx, complianceScan, patch); err != nil {
+		return fmt.Errorf("failed to update ComplianceScan status to Queued: %w", err)
+	}
+
+	log.Info("Updated ComplianceScan phase to Queued", "queuePosition", queuePosition)
+
+	return nil
+}
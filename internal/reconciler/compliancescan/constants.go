@@ -4,11 +4,9 @@
 
 package reconciler
 
-import "time"
-
 const (
-	// ReconciliationRequeueInterval is the time window between different reconciliations of a running ComplianceScan.
-	ReconciliationRequeueInterval = time.Second * 5
+	// FinalizerName is the finalizer which is added to ComplianceScans to clean up their resources on deletion.
+	FinalizerName = "diki.gardener.cloud/compliancescan"
 
 	// ConfigMapNamePrefix is the prefix for diki config ConfigMap names.
 	ConfigMapNamePrefix = "diki-config-"
@@ -16,6 +14,10 @@ const (
 	ServiceAccountNameDikiRun = "diki-run"
 	// JobNamePrefix is the prefix for the diki-run Job names.
 	JobNamePrefix = "diki-run-"
+	// MergeJobNameSuffix is the suffix of the names of the Job and ConfigMap which merge the partial reports of a sharded ComplianceScan.
+	MergeJobNameSuffix = "merge"
+	// PartialReportConfigMapNamePrefix is the prefix for the names of ConfigMaps containing partial reports of a sharded ComplianceScan.
+	PartialReportConfigMapNamePrefix = "diki-partial-report-"
 	// DikiConfigVolumeName is the name of the volume mounted in the diki-run Job pods.
 	DikiConfigVolumeName = "diki-config"
 	// DikiConfigKey is the key used to store the YAML configuration in the ConfigMap data.
@@ -44,14 +46,47 @@ const (
 
 	// TokenSecretKey is the key in the token Secret that holds the token data.
 	TokenSecretKey = "token"
+	// TokenSecretNamePrefix is the prefix for the names of Secrets containing requested service account tokens.
+	TokenSecretNamePrefix = "diki-token-"
+
+	// NetworkPolicyNamePrefix is the prefix for the names of NetworkPolicies restricting the network access of diki-run pods.
+	NetworkPolicyNamePrefix = "diki-run-"
+	// KubernetesServiceName is the name of the service in the default namespace which exposes the API server.
+	KubernetesServiceName = "kubernetes"
+	// DefaultAPIServerPort is the port diki-run pods are allowed to connect to when API server CIDRs are configured without ports.
+	DefaultAPIServerPort = 443
+	// DNSPort is the port diki-run pods are allowed to connect to for DNS resolution.
+	DNSPort = 53
+	// LabelValueKubeDNS is the value of the k8s-app label of the cluster DNS pods in the kube-system namespace.
+	LabelValueKubeDNS = "kube-dns"
+
+	// NamespaceScanNamePrefix is the prefix for the names of the ServiceAccounts and RoleBindings of ComplianceScans
+	// which are restricted to a namespace.
+	NamespaceScanNamePrefix = "diki-run-"
+	// NamespaceScannerClusterRoleName is the name of the ClusterRole which is bound in the target namespace of
+	// ComplianceScans which are restricted to a namespace.
+	NamespaceScannerClusterRoleName = "namespace-scanner.diki.gardener.cloud"
 
 	// RuleOptionsSuffix is the suffix appended to ruleset IDs when looking up rule options in ConfigMaps.
 	RuleOptionsSuffix = "-rules"
 
+	// ConditionReasonQueued is the reason for ComplianceScan condition when it is queued.
+	ConditionReasonQueued = "ComplianceScanQueued"
 	// ConditionReasonRunning is the reason for ComplianceScan condition when it is running.
 	ConditionReasonRunning = "ComplianceScanRunning"
 	// ConditionReasonCompleted is the reason for ComplianceScan condition when it has completed successfully.
 	ConditionReasonCompleted = "ComplianceScanCompleted"
 	// ConditionReasonFailed is the reason for ComplianceScan condition when it has failed.
 	ConditionReasonFailed = "ComplianceScanFailed"
+	// ConditionReasonScannerFailed is the reason for ComplianceScan condition when the diki-scan container has failed.
+	ConditionReasonScannerFailed = "ScannerFailed"
+	// ConditionReasonExporterFailed is the reason for ComplianceScan condition when the report-exporter container has failed.
+	ConditionReasonExporterFailed = "ExporterFailed"
+
+	// EventActionScan is the action of events emitted for ComplianceScans.
+	EventActionScan = "Scan"
+
+	// maxTerminationMessageLines is the maximum number of lines of a container's termination message
+	// which are added to the Failed condition of a ComplianceScan.
+	maxTerminationMessageLines = 10
 )
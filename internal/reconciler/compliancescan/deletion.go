@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/gardener/diki-operator/internal/component/reportexporter/outputs"
+	"github.com/gardener/diki-operator/internal/constants"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// delete cleans up the resources of a deleted compliance scan and removes its finalizer afterwards.
+// The ConfigMaps and NetworkPolicies of the diki-run Jobs are owned by the Jobs and deleted by the garbage collection of Kubernetes.
+func (r *Reconciler) delete(ctx context.Context, cfg *configv1alpha1.ComplianceScanConfig, complianceScan *v1alpha1.ComplianceScan, log logr.Logger) error {
+	if !controllerutil.ContainsFinalizer(complianceScan, FinalizerName) {
+		return nil
+	}
+
+	log.Info("Cleaning up resources of deleted ComplianceScan")
+
+	// All DikiRunner namespaces are cleaned up because the runner profile of the compliance scan might have been removed.
+	for _, namespace := range configv1alpha1helper.DikiRunnerNamespaces(cfg) {
+		if err := r.deleteDikiRunResources(ctx, complianceScan, namespace, log); err != nil {
+			return err
+		}
+		r.cleanupPartialReports(ctx, complianceScan, namespace, log)
+		if complianceScan.Spec.TargetNamespace != "" {
+			if err := r.deleteNamespaceScanServiceAccount(ctx, complianceScan, namespace, log); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.cleanupOutputs(ctx, complianceScan, log); err != nil {
+		return err
+	}
+
+	log.Info("Removing finalizer")
+	if err := controllerutils.RemoveFinalizers(ctx, r.Client, complianceScan, FinalizerName); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return nil
+}
+
+// deleteDikiRunResources deletes the diki-run Jobs and token Secrets of the compliance scan in the given namespace.
+func (r *Reconciler) deleteDikiRunResources(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, namespace string, log logr.Logger) error {
+	jobList := &batchv1.JobList{}
+	if err := r.SourceClient.List(ctx, jobList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{constants.LabelComplianceScanUID: string(complianceScan.UID)},
+	); err != nil {
+		return fmt.Errorf("failed to list diki-run Jobs in namespace %s: %w", namespace, err)
+	}
+
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+
+		log.Info("Deleting Job", "job", client.ObjectKeyFromObject(job))
+		if err := r.SourceClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Job %s: %w", client.ObjectKeyFromObject(job), err)
+		}
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := r.SourceClient.List(ctx, secretList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{constants.LabelComplianceScanUID: string(complianceScan.UID)},
+	); err != nil {
+		return fmt.Errorf("failed to list token Secrets in namespace %s: %w", namespace, err)
+	}
+
+	for i := range secretList.Items {
+		secret := &secretList.Items[i]
+
+		log.Info("Deleting token Secret", "secret", client.ObjectKeyFromObject(secret))
+		if err := r.SourceClient.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete Secret %s: %w", client.ObjectKeyFromObject(secret), err)
+		}
+	}
+
+	return nil
+}
+
+// cleanupOutputs deletes the artifacts exported to the outputs of the compliance scan with deletion policy Delete.
+// The outputs are taken from the snapshot of the compliance scan, so that the artifacts are found even if the
+// ReportOutputs were changed or deleted after the compliance scan was started.
+func (r *Reconciler) cleanupOutputs(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, log logr.Logger) error {
+	for _, outputRef := range complianceScan.Spec.Outputs {
+		if outputRef.DeletionPolicy != v1alpha1.DeletionPolicyDelete {
+			continue
+		}
+
+		reportOutput, err := r.getSnapshotReportOutput(ctx, complianceScan, outputRef.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				log.Info("ReportOutput not found, skipping cleanup of its exported artifacts", "reportOutput", outputRef.Name)
+				continue
+			}
+			return fmt.Errorf("failed to get ReportOutput %q: %w", outputRef.Name, err)
+		}
+
+		output, err := outputs.NewOutput(r.Client, reportOutput, complianceScan)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Deleting exported artifacts", "reportOutput", outputRef.Name, "type", output.Type())
+		if err := output.Cleanup(ctx); err != nil {
+			return fmt.Errorf("failed to clean up output %q: %w", outputRef.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	dikiconfig "github.com/gardener/diki/pkg/config"
 	"github.com/gardener/diki/pkg/provider/managedk8s"
@@ -20,24 +21,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 	reportexporterv1alpha1 "github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
 )
 
-func (r *Reconciler) deployDikiConfigMap(ctx context.Context, configMapName string, complianceScan *v1alpha1.ComplianceScan, job *batchv1.Job, exporterConfig *reportexporterv1alpha1.ReportExporterConfiguration) (*corev1.ConfigMap, error) {
+func (r *Reconciler) deployDikiConfigMap(ctx context.Context, configMapName string, complianceScan *v1alpha1.ComplianceScan, rulesets []shardRuleset, dikiRunner *configv1alpha1.DikiRunnerConfig, job *batchv1.Job, exporterConfig *reportexporterv1alpha1.ReportExporterConfiguration) (*corev1.ConfigMap, error) {
 	managedk8sProvider := dikiconfig.ProviderConfig{
 		ID:   managedk8s.ProviderID,
 		Name: managedk8s.ProviderName,
 	}
 
-	for _, ruleset := range complianceScan.Spec.Rulesets {
+	for _, ruleset := range rulesets {
 		switch ruleset.ID {
 		case disak8sstig.RulesetID:
-			ruleOptions, err := r.getRuleOptions(ctx, ruleset.Options, disak8sstig.RulesetID)
+			rulesetSnapshot := getRulesetSnapshot(complianceScan, ruleset.ID)
+			ruleOptions, err := r.getRuleOptions(ctx, ruleset.Options, rulesetSnapshot, disak8sstig.RulesetID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get rule options: %w", err)
 			}
-			rulesetOptions, err := r.getRulesetOptions(ctx, ruleset.Options, disak8sstig.RulesetID)
+			rulesetOptions, err := r.getRulesetOptions(ctx, ruleset.Options, rulesetSnapshot, disak8sstig.RulesetID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get ruleset options: %w", err)
 			}
@@ -47,16 +50,17 @@ func (r *Reconciler) deployDikiConfigMap(ctx context.Context, configMapName stri
 				Name:        disak8sstig.RulesetName,
 				Version:     ruleset.Version,
 				Args:        rulesetOptions,
-				RuleOptions: ruleOptions,
+				RuleOptions: skipRules(ruleOptions, ruleset.skippedRuleIDs),
 			}
 
 			managedk8sProvider.Rulesets = append(managedk8sProvider.Rulesets, dikiRuleset)
 		case securityhardenedk8s.RulesetID:
-			ruleOptions, err := r.getRuleOptions(ctx, ruleset.Options, securityhardenedk8s.RulesetID)
+			rulesetSnapshot := getRulesetSnapshot(complianceScan, ruleset.ID)
+			ruleOptions, err := r.getRuleOptions(ctx, ruleset.Options, rulesetSnapshot, securityhardenedk8s.RulesetID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get rule options: %w", err)
 			}
-			rulesetOptions, err := r.getRulesetOptions(ctx, ruleset.Options, securityhardenedk8s.RulesetID)
+			rulesetOptions, err := r.getRulesetOptions(ctx, ruleset.Options, rulesetSnapshot, securityhardenedk8s.RulesetID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get ruleset options: %w", err)
 			}
@@ -66,7 +70,7 @@ func (r *Reconciler) deployDikiConfigMap(ctx context.Context, configMapName stri
 				Name:        securityhardenedk8s.RulesetName,
 				Version:     ruleset.Version,
 				Args:        rulesetOptions,
-				RuleOptions: ruleOptions,
+				RuleOptions: skipRules(ruleOptions, ruleset.skippedRuleIDs),
 			}
 
 			managedk8sProvider.Rulesets = append(managedk8sProvider.Rulesets, dikiRuleset)
@@ -74,9 +78,9 @@ func (r *Reconciler) deployDikiConfigMap(ctx context.Context, configMapName stri
 		}
 	}
 
-	if r.Config.DikiRunner.TargetKubeconfig != nil {
+	if dikiRunner.TargetKubeconfig != nil {
 		managedk8sProvider.Args = map[string]any{
-			"kubeconfigPath": fmt.Sprintf("%s/%s", r.Config.DikiRunner.TargetKubeconfig.MountPath, KubeconfigSecretKey),
+			"kubeconfigPath": fmt.Sprintf("%s/%s", dikiRunner.TargetKubeconfig.MountPath, KubeconfigSecretKey),
 		}
 	}
 
@@ -95,43 +99,109 @@ func (r *Reconciler) deployDikiConfigMap(ctx context.Context, configMapName stri
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            configMapName,
-			Namespace:       r.Config.DikiRunner.Namespace,
+			Namespace:       dikiRunner.Namespace,
 			OwnerReferences: r.getOwnerReference(job),
-			Labels:          r.getLabels(complianceScan),
+			Labels:          r.getLabels(complianceScan, dikiRunner),
 		},
 		Data: map[string]string{
 			DikiConfigKey: string(dikiConfigYAML),
 		},
 	}
 
+	exporterConfigYAML, err := encodeExporterConfig(exporterConfig)
+	if err != nil {
+		return nil, err
+	}
+	configMap.Data[ExporterConfigKey] = exporterConfigYAML
+
+	if err := r.SourceClient.Create(ctx, configMap); err != nil {
+		return nil, fmt.Errorf("failed to create diki config configMap: %w", err)
+	}
+
+	return configMap, nil
+}
+
+// deployMergeConfigMap creates the ConfigMap for the merge Job of a sharded compliance scan.
+// It only contains the exporter config because the merge Job does not run diki.
+func (r *Reconciler) deployMergeConfigMap(ctx context.Context, configMapName string, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, job *batchv1.Job, exporterConfig *reportexporterv1alpha1.ReportExporterConfiguration) (*corev1.ConfigMap, error) {
+	exporterConfigYAML, err := encodeExporterConfig(exporterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            configMapName,
+			Namespace:       dikiRunner.Namespace,
+			OwnerReferences: r.getOwnerReference(job),
+			Labels:          r.getLabels(complianceScan, dikiRunner),
+		},
+		Data: map[string]string{
+			ExporterConfigKey: exporterConfigYAML,
+		},
+	}
+
+	if err := r.SourceClient.Create(ctx, configMap); err != nil {
+		return nil, fmt.Errorf("failed to create merge config configMap: %w", err)
+	}
+
+	return configMap, nil
+}
+
+// skipRules skips the given rules in the rule options because they are scanned by another shard. The skipped rules
+// are removed from the partial report of the shard.
+func skipRules(ruleOptions []dikiconfig.RuleOptionsConfig, ruleIDs []string) []dikiconfig.RuleOptionsConfig {
+	for _, ruleID := range ruleIDs {
+		skip := &dikiconfig.RuleOptionSkipConfig{
+			Enabled:       true,
+			Justification: reportexporterv1alpha1.ShardSkippedRuleJustification,
+		}
+
+		if idx := slices.IndexFunc(ruleOptions, func(o dikiconfig.RuleOptionsConfig) bool { return o.RuleID == ruleID }); idx >= 0 {
+			ruleOptions[idx].Skip = skip
+			continue
+		}
+		ruleOptions = append(ruleOptions, dikiconfig.RuleOptionsConfig{RuleID: ruleID, Skip: skip})
+	}
+
+	return ruleOptions
+}
+
+func encodeExporterConfig(exporterConfig *reportexporterv1alpha1.ReportExporterConfiguration) (string, error) {
 	// Marshal to JSON first because the YAML library ignores json: struct tags
 	// and embedded upstream types (e.g. metav1.TypeMeta) only have json: tags.
 	exporterConfigJSON, err := json.Marshal(exporterConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal exporter config: %w", err)
+		return "", fmt.Errorf("failed to marshal exporter config: %w", err)
 	}
 
 	var exporterConfigMap any
 	if err := json.Unmarshal(exporterConfigJSON, &exporterConfigMap); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal exporter config: %w", err)
+		return "", fmt.Errorf("failed to unmarshal exporter config: %w", err)
 	}
 
 	var exporterBuf bytes.Buffer
 	exporterEncoder := yaml.NewEncoder(&exporterBuf)
 	exporterEncoder.SetIndent(2)
 	if err := exporterEncoder.Encode(exporterConfigMap); err != nil {
-		return nil, fmt.Errorf("failed to encode exporter config to yaml: %w", err)
-	}
-	configMap.Data[ExporterConfigKey] = exporterBuf.String()
-
-	if err := r.SourceClient.Create(ctx, configMap); err != nil {
-		return nil, fmt.Errorf("failed to create diki config configMap: %w", err)
+		return "", fmt.Errorf("failed to encode exporter config to yaml: %w", err)
 	}
 
-	return configMap, nil
+	return exporterBuf.String(), nil
 }
 
-func (r *Reconciler) getRuleOptions(ctx context.Context, options *v1alpha1.RulesetOptions, rulesetID string) ([]dikiconfig.RuleOptionsConfig, error) {
+// getRuleOptions returns the rule options of a ruleset. The options recorded in the snapshot of the ruleset are used
+// if present, so that the diki config contains the options the compliance scan was started with.
+func (r *Reconciler) getRuleOptions(ctx context.Context, options *v1alpha1.RulesetOptions, rulesetSnapshot *v1alpha1.RulesetSnapshot, rulesetID string) ([]dikiconfig.RuleOptionsConfig, error) {
+	if rulesetSnapshot != nil && rulesetSnapshot.RuleOptions != nil {
+		ruleOptions, err := ParseRuleOptions(rulesetSnapshot.RuleOptions.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rule options from configMap %s/%s: %w", rulesetSnapshot.RuleOptions.ConfigMapRef.Namespace, rulesetSnapshot.RuleOptions.ConfigMapRef.Name, err)
+		}
+
+		return ruleOptions, nil
+	}
+
 	if options == nil || options.Rules == nil || options.Rules.ConfigMapRef == nil {
 		return nil, nil
 	}
@@ -141,15 +211,26 @@ func (r *Reconciler) getRuleOptions(ctx context.Context, options *v1alpha1.Rules
 		return nil, fmt.Errorf("failed to get rule options from configMap: %w", err)
 	}
 
-	var ruleOptions []dikiconfig.RuleOptionsConfig
-	if err := yaml.Unmarshal([]byte(ruleOptionsYAML), &ruleOptions); err != nil {
+	ruleOptions, err := ParseRuleOptions(ruleOptionsYAML)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal rule options from configMap %s/%s: %w", options.Rules.ConfigMapRef.Namespace, options.Rules.ConfigMapRef.Name, err)
 	}
 
 	return ruleOptions, nil
 }
 
-func (r *Reconciler) getRulesetOptions(ctx context.Context, options *v1alpha1.RulesetOptions, rulesetID string) (any, error) {
+// getRulesetOptions returns the global options of a ruleset. The options recorded in the snapshot of the ruleset are
+// used if present, so that the diki config contains the options the compliance scan was started with.
+func (r *Reconciler) getRulesetOptions(ctx context.Context, options *v1alpha1.RulesetOptions, rulesetSnapshot *v1alpha1.RulesetSnapshot, rulesetID string) (any, error) {
+	if rulesetSnapshot != nil && rulesetSnapshot.RulesetOptions != nil {
+		rulesetOptions, err := ParseRulesetOptions(rulesetSnapshot.RulesetOptions.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ruleset options from configMap %s/%s: %w", rulesetSnapshot.RulesetOptions.ConfigMapRef.Namespace, rulesetSnapshot.RulesetOptions.ConfigMapRef.Name, err)
+		}
+
+		return rulesetOptions, nil
+	}
+
 	if options == nil || options.Ruleset == nil || options.Ruleset.ConfigMapRef == nil {
 		return nil, nil
 	}
@@ -159,14 +240,34 @@ func (r *Reconciler) getRulesetOptions(ctx context.Context, options *v1alpha1.Ru
 		return nil, fmt.Errorf("failed to get ruleset options from configMap: %w", err)
 	}
 
-	var rulesetOptions any
-	if err := yaml.Unmarshal([]byte(rulesetOptionsYAML), &rulesetOptions); err != nil {
+	rulesetOptions, err := ParseRulesetOptions(rulesetOptionsYAML)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal ruleset options from configMap %s/%s: %w", options.Ruleset.ConfigMapRef.Namespace, options.Ruleset.ConfigMapRef.Name, err)
 	}
 
 	return rulesetOptions, nil
 }
 
+// ParseRuleOptions parses the rule options of a ruleset stored in a ConfigMap key.
+func ParseRuleOptions(data string) ([]dikiconfig.RuleOptionsConfig, error) {
+	var ruleOptions []dikiconfig.RuleOptionsConfig
+	if err := yaml.Unmarshal([]byte(data), &ruleOptions); err != nil {
+		return nil, err
+	}
+
+	return ruleOptions, nil
+}
+
+// ParseRulesetOptions parses the global options of a ruleset stored in a ConfigMap key.
+func ParseRulesetOptions(data string) (any, error) {
+	var rulesetOptions any
+	if err := yaml.Unmarshal([]byte(data), &rulesetOptions); err != nil {
+		return nil, err
+	}
+
+	return rulesetOptions, nil
+}
+
 func (r *Reconciler) getConfigMapKeyValue(ctx context.Context, configMapRef v1alpha1.OptionsConfigMapRef, defaultKey string) (string, error) {
 	key := defaultKey
 	if configMapRef.Key != nil {
@@ -7,6 +7,8 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -15,42 +17,73 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/gardener/diki-operator/imagevector"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
 // deployDikiRunJob creates a Kubernetes Job that runs the diki compliance scan
 // and exports the report to the configured outputs.
-func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiConfigMapName string) (*batchv1.Job, error) {
-	dikiImage, err := imagevector.ImageVector().FindImage("diki")
+func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, jobName, dikiConfigMapName string) (*batchv1.Job, error) {
+	job, err := r.newDikiRunJob(complianceScan, dikiRunner, jobName, dikiConfigMapName)
 	if err != nil {
 		return nil, err
 	}
 
-	reportExporterImage, err := imagevector.ImageVector().FindImage("report-exporter")
+	if err := r.SourceClient.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create diki runner job: %w", err)
+	}
+
+	return job, nil
+}
+
+// deployMergeJob creates a Kubernetes Job that merges the partial reports of a sharded compliance scan
+// and exports the merged report to the configured outputs. The Job only runs the report-exporter.
+func (r *Reconciler) deployMergeJob(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, jobName, dikiConfigMapName string) (*batchv1.Job, error) {
+	job, err := r.newDikiRunJob(complianceScan, dikiRunner, jobName, dikiConfigMapName)
+	if err != nil {
+		return nil, err
+	}
+
+	isDikiScanContainer := func(container corev1.Container) bool {
+		return container.Name == DikiScanContainerName
+	}
+	job.Spec.Template.Spec.InitContainers = slices.DeleteFunc(job.Spec.Template.Spec.InitContainers, isDikiScanContainer)
+	job.Spec.Template.Spec.Containers = slices.DeleteFunc(job.Spec.Template.Spec.Containers, isDikiScanContainer)
+
+	if err := r.SourceClient.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create merge job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *Reconciler) newDikiRunJob(complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, jobName, dikiConfigMapName string) (*batchv1.Job, error) {
+	dikiImage, reportExporterImage, err := resolveImages(complianceScan)
 	if err != nil {
 		return nil, err
 	}
-	reportExporterImage.WithOptionalTag(version.Get().GitVersion)
 
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      JobNamePrefix + string(complianceScan.UID),
-			Namespace: r.Config.DikiRunner.Namespace,
-			Labels:    r.getLabels(complianceScan),
+			Name:      jobName,
+			Namespace: dikiRunner.Namespace,
+			Labels:    r.getLabels(complianceScan, dikiRunner),
 		},
 		Spec: batchv1.JobSpec{
 			BackoffLimit: ptr.To(int32(0)),
 			Suspend:      ptr.To(true),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: r.getLabels(complianceScan),
+					Labels: r.getLabels(complianceScan, dikiRunner),
 				},
 				Spec: corev1.PodSpec{
-					ActiveDeadlineSeconds: ptr.To(int64(r.Config.DikiRunner.PodCompletionTimeout.Seconds())),
+					ActiveDeadlineSeconds: ptr.To(int64(dikiRunner.PodCompletionTimeout.Seconds())),
 					Containers: []corev1.Container{
 						{
-							Name:  DikiScanContainerName,
-							Image: dikiImage.String(),
+							Name:                     DikiScanContainerName,
+							Image:                    dikiImage,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							Args: []string{
 								"run",
 								fmt.Sprintf("--config=%s/%s", DikiConfigMountPath, DikiConfigKey),
@@ -78,8 +111,9 @@ func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alp
 							},
 						},
 						{
-							Name:  ReportExporterContainerName,
-							Image: reportExporterImage.String(),
+							Name:                     ReportExporterContainerName,
+							Image:                    reportExporterImage,
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							Args: []string{
 								fmt.Sprintf("--config=%s/%s", DikiConfigMountPath, ExporterConfigKey),
 							},
@@ -150,17 +184,17 @@ func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alp
 		},
 	}
 
-	if r.Config.DikiRunner.TargetKubeconfig != nil {
+	if dikiRunner.TargetKubeconfig != nil {
 		kubeconfigKey := KubeconfigSecretKey
-		if r.Config.DikiRunner.TargetKubeconfig.SecretRef.Key != nil {
-			kubeconfigKey = *r.Config.DikiRunner.TargetKubeconfig.SecretRef.Key
+		if dikiRunner.TargetKubeconfig.SecretRef.Key != nil {
+			kubeconfigKey = *dikiRunner.TargetKubeconfig.SecretRef.Key
 		}
 
 		projectedSources := []corev1.VolumeProjection{
 			{
 				Secret: &corev1.SecretProjection{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: r.Config.DikiRunner.TargetKubeconfig.SecretRef.Name,
+						Name: dikiRunner.TargetKubeconfig.SecretRef.Name,
 					},
 					Items: []corev1.KeyToPath{
 						{
@@ -173,16 +207,24 @@ func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alp
 			},
 		}
 
-		if r.Config.DikiRunner.TargetKubeconfig.TokenSecretRef != nil {
-			tokenKey := TokenSecretKey
-			if r.Config.DikiRunner.TargetKubeconfig.TokenSecretRef.Key != nil {
-				tokenKey = *r.Config.DikiRunner.TargetKubeconfig.TokenSecretRef.Key
+		var tokenSecretName, tokenKey string
+		switch {
+		case dikiRunner.TargetKubeconfig.TokenSecretRef != nil:
+			tokenSecretName = dikiRunner.TargetKubeconfig.TokenSecretRef.Name
+			tokenKey = TokenSecretKey
+			if dikiRunner.TargetKubeconfig.TokenSecretRef.Key != nil {
+				tokenKey = *dikiRunner.TargetKubeconfig.TokenSecretRef.Key
 			}
+		case dikiRunner.TargetKubeconfig.TokenRequest != nil:
+			tokenSecretName = TokenSecretNamePrefix + string(complianceScan.UID)
+			tokenKey = TokenSecretKey
+		}
 
+		if tokenSecretName != "" {
 			projectedSources = append(projectedSources, corev1.VolumeProjection{
 				Secret: &corev1.SecretProjection{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: r.Config.DikiRunner.TargetKubeconfig.TokenSecretRef.Name,
+						Name: tokenSecretName,
 					},
 					Items: []corev1.KeyToPath{
 						{
@@ -208,7 +250,7 @@ func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alp
 			job.Spec.Template.Spec.Containers[0].VolumeMounts,
 			corev1.VolumeMount{
 				Name:      KubeconfigVolumeName,
-				MountPath: r.Config.DikiRunner.TargetKubeconfig.MountPath,
+				MountPath: dikiRunner.TargetKubeconfig.MountPath,
 				ReadOnly:  true,
 			},
 		)
@@ -216,7 +258,7 @@ func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alp
 			job.Spec.Template.Spec.Containers[1].VolumeMounts,
 			corev1.VolumeMount{
 				Name:      KubeconfigVolumeName,
-				MountPath: r.Config.DikiRunner.TargetKubeconfig.MountPath,
+				MountPath: dikiRunner.TargetKubeconfig.MountPath,
 				ReadOnly:  true,
 			},
 		)
@@ -224,14 +266,122 @@ func (r *Reconciler) deployDikiRunJob(ctx context.Context, complianceScan *v1alp
 			job.Spec.Template.Spec.Containers[1].Env,
 			corev1.EnvVar{
 				Name:  "KUBECONFIG",
-				Value: fmt.Sprintf("%s/%s", r.Config.DikiRunner.TargetKubeconfig.MountPath, KubeconfigSecretKey),
+				Value: fmt.Sprintf("%s/%s", dikiRunner.TargetKubeconfig.MountPath, KubeconfigSecretKey),
 			},
 		)
 	}
 
-	if err := r.SourceClient.Create(ctx, job); err != nil {
-		return nil, fmt.Errorf("failed to create diki runner job: %w", err)
+	if complianceScan.Spec.TargetNamespace != "" {
+		job.Spec.Template.Spec.ServiceAccountName = namespaceScanResourceName(complianceScan)
+	}
+
+	if dikiRunner.PodTemplate != nil {
+		applyPodTemplate(&job.Spec.Template, dikiRunner.PodTemplate)
+	}
+
+	if priorityClassName := configv1alpha1helper.GetPriorityClassName(dikiRunner, ptr.Deref(complianceScan.Spec.Priority, 0)); priorityClassName != "" {
+		job.Spec.Template.Spec.PriorityClassName = priorityClassName
+	}
+
+	if dikiRunner.JobLayout == configv1alpha1.JobLayoutSequential {
+		applySequentialLayout(&job.Spec.Template.Spec)
 	}
 
 	return job, nil
 }
+
+// applySequentialLayout moves the diki-scan container to the init containers of the pod, so that the report-exporter
+// only starts once the scan has completed successfully and never runs when the scan fails.
+func applySequentialLayout(podSpec *corev1.PodSpec) {
+	idx := slices.IndexFunc(podSpec.Containers, func(container corev1.Container) bool {
+		return container.Name == DikiScanContainerName
+	})
+	if idx < 0 {
+		return
+	}
+
+	podSpec.InitContainers = append(podSpec.InitContainers, podSpec.Containers[idx])
+	podSpec.Containers = slices.Delete(podSpec.Containers, idx, idx+1)
+}
+
+// resolveImages returns the diki and report-exporter images for the given compliance scan.
+// The images recorded in the snapshot of the compliance scan are used if it has been started already. Otherwise,
+// images from the image vector are used unless they are overridden in the compliance scan spec.
+func resolveImages(complianceScan *v1alpha1.ComplianceScan) (string, string, error) {
+	if snapshot := complianceScan.Status.Snapshot; snapshot != nil {
+		return snapshot.Images.Diki, snapshot.Images.ReportExporter, nil
+	}
+
+	dikiImage, err := imagevector.ImageVector().FindImage("diki")
+	if err != nil {
+		return "", "", err
+	}
+
+	reportExporterImage, err := imagevector.ImageVector().FindImage("report-exporter")
+	if err != nil {
+		return "", "", err
+	}
+	reportExporterImage.WithOptionalTag(version.Get().GitVersion)
+
+	var (
+		dikiImageRef           = dikiImage.String()
+		reportExporterImageRef = reportExporterImage.String()
+	)
+
+	if images := complianceScan.Spec.Image; images != nil {
+		if images.Diki != "" {
+			dikiImageRef = images.Diki
+		}
+		if images.ReportExporter != "" {
+			reportExporterImageRef = images.ReportExporter
+		}
+	}
+
+	return dikiImageRef, reportExporterImageRef, nil
+}
+
+// applyPodTemplate merges the configured pod template settings into the pod template of the diki-run Job.
+func applyPodTemplate(template *corev1.PodTemplateSpec, podTemplate *configv1alpha1.DikiRunnerPodTemplate) {
+	if len(podTemplate.Labels) > 0 {
+		labels := maps.Clone(podTemplate.Labels)
+		maps.Copy(labels, template.Labels)
+		template.Labels = labels
+	}
+
+	if len(podTemplate.Annotations) > 0 {
+		annotations := maps.Clone(podTemplate.Annotations)
+		maps.Copy(annotations, template.Annotations)
+		template.Annotations = annotations
+	}
+
+	if podTemplate.Resources != nil {
+		for i, container := range template.Spec.Containers {
+			switch {
+			case container.Name == DikiScanContainerName && podTemplate.Resources.DikiScan != nil:
+				template.Spec.Containers[i].Resources = *podTemplate.Resources.DikiScan.DeepCopy()
+			case container.Name == ReportExporterContainerName && podTemplate.Resources.ReportExporter != nil:
+				template.Spec.Containers[i].Resources = *podTemplate.Resources.ReportExporter.DeepCopy()
+			}
+		}
+	}
+
+	if podTemplate.Tolerations != nil {
+		template.Spec.Tolerations = slices.Clone(podTemplate.Tolerations)
+	}
+
+	if len(podTemplate.NodeSelector) > 0 {
+		template.Spec.NodeSelector = maps.Clone(podTemplate.NodeSelector)
+	}
+	if podTemplate.Affinity != nil {
+		template.Spec.Affinity = podTemplate.Affinity.DeepCopy()
+	}
+	if podTemplate.PriorityClassName != "" {
+		template.Spec.PriorityClassName = podTemplate.PriorityClassName
+	}
+	if len(podTemplate.ImagePullSecrets) > 0 {
+		template.Spec.ImagePullSecrets = slices.Clone(podTemplate.ImagePullSecrets)
+	}
+	if podTemplate.RuntimeClassName != nil {
+		template.Spec.RuntimeClassName = ptr.To(*podTemplate.RuntimeClassName)
+	}
+}
@@ -5,19 +5,19 @@
 package reconciler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 	reportexporterv1alpha1 "github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
 )
 
-func (r *Reconciler) buildExporterConfig(ctx context.Context, complianceScan *v1alpha1.ComplianceScan) (*reportexporterv1alpha1.ReportExporterConfiguration, error) {
+// buildExporterConfig builds the report-exporter configuration from the ReportOutputs recorded in the snapshot of the compliance scan.
+func buildExporterConfig(complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig) (*reportexporterv1alpha1.ReportExporterConfiguration, error) {
 	exporterConfig := &reportexporterv1alpha1.ReportExporterConfiguration{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "exporter.diki.gardener.cloud/v1alpha1",
@@ -25,18 +25,24 @@ func (r *Reconciler) buildExporterConfig(ctx context.Context, complianceScan *v1
 		},
 		ReportPath:         ReportMountPath + "/" + ReportFileName,
 		ComplianceScanName: complianceScan.Name,
-		WaitForReport:      true,
+		// The report-exporter only starts after diki-scan has completed in the sequential layout,
+		// hence the report file already exists.
+		WaitForReport: dikiRunner.JobLayout != configv1alpha1.JobLayoutSequential,
 	}
 
-	for _, outputRef := range complianceScan.Spec.Outputs {
-		reportOutput := &v1alpha1.ReportOutput{}
-		if err := r.Client.Get(ctx, client.ObjectKey{Name: outputRef.Name}, reportOutput); err != nil {
-			return nil, fmt.Errorf("failed to get ReportOutput %q: %w", outputRef.Name, err)
+	if complianceScan.Status.Snapshot == nil {
+		return exporterConfig, nil
+	}
+
+	for _, outputSnapshot := range complianceScan.Status.Snapshot.Outputs {
+		reportOutput := &v1alpha1.ReportOutput{
+			ObjectMeta: metav1.ObjectMeta{Name: outputSnapshot.Name},
+			Spec:       v1alpha1.ReportOutputSpec{Output: outputSnapshot.Output},
 		}
 
 		output, err := convertReportOutput(reportOutput)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert ReportOutput %q: %w", outputRef.Name, err)
+			return nil, fmt.Errorf("failed to convert ReportOutput %q: %w", outputSnapshot.Name, err)
 		}
 
 		exporterConfig.Outputs = append(exporterConfig.Outputs, *output)
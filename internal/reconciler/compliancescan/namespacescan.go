@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// namespaceScanResourceName returns the name of the ServiceAccount and the RoleBinding of a compliance scan
+// which is restricted to a namespace.
+func namespaceScanResourceName(complianceScan *v1alpha1.ComplianceScan) string {
+	return NamespaceScanNamePrefix + string(complianceScan.UID)
+}
+
+// validateTargetNamespace validates that a compliance scan which is restricted to a namespace can be run by the
+// DikiRunner, only scans rulesets which can be restricted to a namespace and only reads options from the target namespace. It is validated before any options are read.
+func validateTargetNamespace(complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig) error {
+	targetNamespace := complianceScan.Spec.TargetNamespace
+
+	if dikiRunner.TargetKubeconfig != nil {
+		return errors.New("compliance scans restricted to a namespace are not supported by DikiRunners with a target kubeconfig")
+	}
+
+	if getNumShards(complianceScan) > 1 {
+		return errors.New("compliance scans restricted to a namespace cannot be run with a parallelism greater than 1")
+	}
+
+	for _, ruleset := range complianceScan.Spec.Rulesets {
+		if !NamespaceScopedRulesets.Has(ruleset.ID) {
+			return fmt.Errorf("ruleset %s cannot be restricted to a namespace", ruleset.ID)
+		}
+
+		if ruleset.Options == nil {
+			continue
+		}
+
+		for _, options := range []*v1alpha1.Options{ruleset.Options.Ruleset, ruleset.Options.Rules} {
+			if options != nil && options.ConfigMapRef != nil && options.ConfigMapRef.Namespace != targetNamespace {
+				return fmt.Errorf("options of ruleset %s reference configMap %s/%s outside of the target namespace %s",
+					ruleset.ID, options.ConfigMapRef.Namespace, options.ConfigMapRef.Name, targetNamespace)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOutputNamespaces validates that the resolved report outputs of a compliance scan which is restricted to a
+// namespace only export to the target namespace.
+func validateOutputNamespaces(complianceScan *v1alpha1.ComplianceScan, snapshot *v1alpha1.ComplianceScanSnapshot) error {
+	for _, output := range snapshot.Outputs {
+		if configMap := output.Output.ConfigMap; configMap != nil && configMap.Namespace != complianceScan.Spec.TargetNamespace {
+			return fmt.Errorf("ReportOutput %q exports to namespace %s outside of the target namespace %s",
+				output.Name, configMap.Namespace, complianceScan.Spec.TargetNamespace)
+		}
+	}
+
+	return nil
+}
+
+// deployNamespaceScanRBAC creates the dedicated ServiceAccount of the diki-run Job of a compliance scan which is
+// restricted to a namespace and binds the static namespace scanner ClusterRole to it in the target namespace. The
+// ServiceAccount is owned by the diki-run Job and deleted once the compliance scan has finished, which revokes its
+// access. The RoleBinding is owned by the compliance scan, so that it is garbage collected together with it.
+func (r *Reconciler) deployNamespaceScanRBAC(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, job *batchv1.Job) (*corev1.ServiceAccount, error) {
+	var (
+		name   = namespaceScanResourceName(complianceScan)
+		labels = r.getLabels(complianceScan, dikiRunner)
+	)
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       dikiRunner.Namespace,
+			Labels:          labels,
+			OwnerReferences: r.getOwnerReference(job),
+		},
+	}
+
+	if err := r.SourceClient.Create(ctx, serviceAccount); err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: complianceScan.Spec.TargetNamespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "ComplianceScan",
+					Name:       complianceScan.Name,
+					UID:        complianceScan.UID,
+				},
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     NamespaceScannerClusterRoleName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccount.Name,
+				Namespace: serviceAccount.Namespace,
+			},
+		},
+	}
+
+	if err := r.SourceClient.Create(ctx, roleBinding); err != nil {
+		return nil, fmt.Errorf("failed to create RoleBinding %s: %w", client.ObjectKeyFromObject(roleBinding), err)
+	}
+
+	return serviceAccount, nil
+}
+
+// deleteNamespaceScanServiceAccount deletes the ServiceAccount of a compliance scan which is restricted to a namespace
+// from the given namespace, so that it loses its access as soon as the compliance scan has finished.
+func (r *Reconciler) deleteNamespaceScanServiceAccount(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, namespace string, log logr.Logger) error {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespaceScanResourceName(complianceScan),
+			Namespace: namespace,
+		},
+	}
+
+	log.Info("Deleting ServiceAccount", "serviceAccount", client.ObjectKeyFromObject(serviceAccount))
+	if err := r.SourceClient.Delete(ctx, serviceAccount); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ServiceAccount %s: %w", client.ObjectKeyFromObject(serviceAccount), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki-operator/internal/constants"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// isNetworkPolicyEnabled returns whether a NetworkPolicy should be created for the pods of the DikiRunner.
+func isNetworkPolicyEnabled(dikiRunner *configv1alpha1.DikiRunnerConfig) bool {
+	return dikiRunner.NetworkPolicy != nil && ptr.Deref(dikiRunner.NetworkPolicy.Enabled, false)
+}
+
+// deployNetworkPolicy creates a NetworkPolicy which denies all ingress traffic to the diki-run pods of the compliance scan
+// and only allows egress traffic to the cluster DNS and the API server(s). The NetworkPolicy is owned by the diki-run Job.
+func (r *Reconciler) deployNetworkPolicy(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, job *batchv1.Job) (*networkingv1.NetworkPolicy, error) {
+	apiServerPeers, apiServerPorts, err := r.getAPIServerEgress(ctx, dikiRunner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine API server endpoints: %w", err)
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            NetworkPolicyNamePrefix + string(complianceScan.UID),
+			Namespace:       dikiRunner.Namespace,
+			Labels:          r.getLabels(complianceScan, dikiRunner),
+			OwnerReferences: r.getOwnerReference(job),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constants.LabelComplianceScanUID: string(complianceScan.UID),
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{
+				networkingv1.PolicyTypeIngress,
+				networkingv1.PolicyTypeEgress,
+			},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					To: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{corev1.LabelMetadataName: metav1.NamespaceSystem},
+							},
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"k8s-app": LabelValueKubeDNS},
+							},
+						},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(DNSPort))},
+						{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(DNSPort))},
+					},
+				},
+				{
+					To:    apiServerPeers,
+					Ports: apiServerPorts,
+				},
+			},
+		},
+	}
+
+	if err := r.SourceClient.Create(ctx, networkPolicy); err != nil {
+		return nil, fmt.Errorf("failed to create network policy: %w", err)
+	}
+
+	return networkPolicy, nil
+}
+
+// getAPIServerEgress returns the peers and ports of the API server(s) the diki-run pods are allowed to connect to.
+// Unless CIDRs are configured, they are determined from the endpoints of the default/kubernetes service. This is only
+// possible if the diki-run pods scan the cluster they run in, i.e. the DikiRunner has no target kubeconfig.
+func (r *Reconciler) getAPIServerEgress(ctx context.Context, dikiRunner *configv1alpha1.DikiRunnerConfig) ([]networkingv1.NetworkPolicyPeer, []networkingv1.NetworkPolicyPort, error) {
+	var (
+		networkPolicyConfig = dikiRunner.NetworkPolicy
+		cidrs               = slices.Clone(networkPolicyConfig.APIServerCIDRs)
+		ports               = slices.Clone(networkPolicyConfig.APIServerPorts)
+	)
+
+	if len(cidrs) == 0 {
+		if dikiRunner.TargetKubeconfig != nil {
+			return nil, nil, errors.New("API server CIDRs must be configured for DikiRunners with a target kubeconfig")
+		}
+
+		endpointSliceList := &discoveryv1.EndpointSliceList{}
+		if err := r.APIReader.List(ctx, endpointSliceList,
+			client.InNamespace(metav1.NamespaceDefault),
+			client.MatchingLabels{discoveryv1.LabelServiceName: KubernetesServiceName},
+		); err != nil {
+			return nil, nil, fmt.Errorf("failed to list endpoint slices of service %s/%s: %w", metav1.NamespaceDefault, KubernetesServiceName, err)
+		}
+
+		for _, endpointSlice := range endpointSliceList.Items {
+			for _, endpoint := range endpointSlice.Endpoints {
+				for _, address := range endpoint.Addresses {
+					if cidr := addressToCIDR(address); cidr != "" && !slices.Contains(cidrs, cidr) {
+						cidrs = append(cidrs, cidr)
+					}
+				}
+			}
+
+			if len(networkPolicyConfig.APIServerPorts) > 0 {
+				continue
+			}
+			for _, port := range endpointSlice.Ports {
+				if port.Port != nil && !slices.Contains(ports, *port.Port) {
+					ports = append(ports, *port.Port)
+				}
+			}
+		}
+
+		if len(cidrs) == 0 {
+			return nil, nil, errors.New("no endpoints found for the default/kubernetes service")
+		}
+	}
+
+	if len(ports) == 0 {
+		ports = []int32{DefaultAPIServerPort}
+	}
+
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromInt32(port)),
+		})
+	}
+
+	return peers, policyPorts, nil
+}
+
+// addressToCIDR returns the single host CIDR of the given IP address.
+func addressToCIDR(address string) string {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return ip.String() + "/32"
+	default:
+		return ip.String() + "/128"
+	}
+}
@@ -9,27 +9,43 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/gardener/gardener/pkg/controllerutils"
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/diki-operator/internal/config"
 	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+	reportexporterv1alpha1 "github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
 )
 
 // Reconciler reconciles compliance scans.
 type Reconciler struct {
 	Client       client.Client
 	SourceClient client.Client
-	RESTConfig   *rest.Config
-	Config       configv1alpha1.ComplianceScanConfig
+	// SourceCache is the cache of the cluster in which the diki-run Jobs are created. It is used to watch the Jobs.
+	SourceCache cache.Cache
+	APIReader   client.Reader
+	RESTConfig  *rest.Config
+	Recorder    events.EventRecorder
+	Config      configv1alpha1.ComplianceScanConfig
+	// ConfigStore holds the configuration if it can be reloaded at runtime. Config is used if it is nil.
+	ConfigStore *config.Store
+
+	admissionMutex sync.Mutex
 }
 
 // Reconcile handles reconciliation requests for ComplianceScan resources.
@@ -46,15 +62,50 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, fmt.Errorf("error retrieving complianceScan: %w", err)
 	}
 
-	if complianceScan.Status.Phase == v1alpha1.ComplianceScanCompleted || complianceScan.Status.Phase == v1alpha1.ComplianceScanFailed {
+	// The configuration is only read once, so that a reload does not affect a running reconciliation.
+	cfg := r.getConfig()
+
+	if complianceScan.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.delete(ctx, cfg, complianceScan, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(complianceScan, FinalizerName) {
+		log.Info("Adding finalizer")
+		if err := controllerutils.AddFinalizers(ctx, r.Client, complianceScan, FinalizerName); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	if isFinished(complianceScan) {
 		log.Info("ComplianceScan already processed, stop reconciling", "phase", complianceScan.Status.Phase)
 		return reconcile.Result{}, nil
 	}
 
+	dikiRunner, err := configv1alpha1helper.GetDikiRunnerConfig(cfg, complianceScan.Spec.RunnerProfile)
+	if err != nil {
+		return reconcile.Result{}, r.patchFailed(ctx, complianceScan, log, err)
+	}
+
 	if complianceScan.Status.Phase == v1alpha1.ComplianceScanRunning {
-		job, err := r.findDikiRunJob(ctx, complianceScan.UID)
-		if err != nil {
-			return reconcile.Result{}, r.patchFailed(ctx, complianceScan, log, err)
+		var (
+			job     *batchv1.Job
+			sharded = getNumShards(complianceScan) > 1
+		)
+
+		if sharded {
+			job, err = r.reconcileShards(ctx, complianceScan, dikiRunner, log)
+			if err != nil {
+				r.cleanupPartialReports(ctx, complianceScan, dikiRunner.Namespace, log)
+				return reconcile.Result{}, r.patchFailedWithReason(ctx, complianceScan, log, getFailureReasonFromError(err), err)
+			}
+			if job == nil {
+				return reconcile.Result{RequeueAfter: cfg.SyncPeriod.Duration}, nil
+			}
+		} else {
+			job, err = r.findDikiRunJob(ctx, JobNamePrefix+string(complianceScan.UID), dikiRunner)
+			if err != nil {
+				return reconcile.Result{}, r.patchFailed(ctx, complianceScan, log, err)
+			}
 		}
 
 		if job.Spec.Suspend != nil && *job.Spec.Suspend {
@@ -62,6 +113,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 
 		for _, condition := range job.Status.Conditions {
+			if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+				if err := r.patchImages(ctx, complianceScan, job, log); err != nil {
+					log.Error(err, "Failed to record images of the diki runner pods", "job", job.Name, "namespace", job.Namespace)
+				}
+				if sharded {
+					r.cleanupPartialReports(ctx, complianceScan, dikiRunner.Namespace, log)
+				}
+				if complianceScan.Spec.TargetNamespace != "" {
+					if err := r.deleteNamespaceScanServiceAccount(ctx, complianceScan, dikiRunner.Namespace, log); err != nil {
+						log.Error(err, "Failed to delete ServiceAccount of the namespace compliance scan")
+					}
+				}
+			}
+
 			if condition.Type == batchv1.JobComplete && condition.Status == corev1.ConditionTrue {
 				log.Info("Job completed successfully", "job", job.Name, "namespace", job.Namespace)
 
@@ -78,39 +143,122 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			}
 
 			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
-				return reconcile.Result{}, r.patchFailed(ctx, complianceScan, log, fmt.Errorf("job failed: %s", condition.Message))
+				jobErr := r.newJobFailedError(ctx, complianceScan, job, fmt.Errorf("job failed: %s", condition.Message), log)
+				return reconcile.Result{}, r.patchFailedWithReason(ctx, complianceScan, log, jobErr.reason, jobErr)
 			}
 		}
 
-		return reconcile.Result{RequeueAfter: ReconciliationRequeueInterval}, nil
+		return reconcile.Result{RequeueAfter: cfg.SyncPeriod.Duration}, nil
 	}
 
-	if err := r.patchRunning(ctx, complianceScan, log); err != nil {
+	if admitted, err := r.admit(ctx, cfg, complianceScan, log); err != nil {
 		return reconcile.Result{}, r.patchFailed(ctx, complianceScan, log, err)
+	} else if !admitted {
+		return reconcile.Result{RequeueAfter: cfg.SyncPeriod.Duration}, nil
 	}
 
-	if err := r.deployResources(ctx, complianceScan, log); err != nil {
+	if err := r.deployResources(ctx, complianceScan, dikiRunner, log); err != nil {
 		return reconcile.Result{}, r.patchFailed(ctx, complianceScan, log, err)
 	}
 
-	return reconcile.Result{RequeueAfter: ReconciliationRequeueInterval}, nil
+	return reconcile.Result{RequeueAfter: cfg.SyncPeriod.Duration}, nil
 }
 
-func (r *Reconciler) deployResources(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, log logr.Logger) error {
-	configMapName := ConfigMapNamePrefix + string(complianceScan.UID)
+func (r *Reconciler) getConfig() *configv1alpha1.ComplianceScanConfig {
+	if r.ConfigStore != nil {
+		return r.ConfigStore.Get()
+	}
+
+	return &r.Config
+}
+
+func (r *Reconciler) deployResources(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, log logr.Logger) error {
+	if complianceScan.Spec.TargetNamespace != "" {
+		if err := validateTargetNamespace(complianceScan, dikiRunner); err != nil {
+			return err
+		}
+	}
+
+	snapshot, err := r.buildSnapshot(ctx, complianceScan)
+	if err != nil {
+		return fmt.Errorf("failed to build snapshot: %w", err)
+	}
+
+	if complianceScan.Spec.TargetNamespace != "" {
+		if err := validateOutputNamespaces(complianceScan, snapshot); err != nil {
+			return err
+		}
+	}
+
+	if err := r.patchSnapshot(ctx, complianceScan, snapshot, log); err != nil {
+		return err
+	}
 
-	exporterConfig, err := r.buildExporterConfig(ctx, complianceScan)
+	exporterConfig, err := buildExporterConfig(complianceScan, dikiRunner)
 	if err != nil {
 		return fmt.Errorf("failed to build exporter config: %w", err)
 	}
 
-	job, err := r.deployDikiRunJob(ctx, complianceScan, configMapName)
+	if getNumShards(complianceScan) > 1 {
+		return r.deployShards(ctx, complianceScan, dikiRunner, exporterConfig, log)
+	}
+
+	rulesets := make([]shardRuleset, 0, len(complianceScan.Spec.Rulesets))
+	for _, ruleset := range complianceScan.Spec.Rulesets {
+		rulesets = append(rulesets, shardRuleset{RulesetConfig: ruleset})
+	}
+
+	return r.deployDikiRun(ctx, complianceScan, rulesets, dikiRunner,
+		JobNamePrefix+string(complianceScan.UID),
+		ConfigMapNamePrefix+string(complianceScan.UID),
+		exporterConfig, true, log,
+	)
+}
+
+// deployDikiRun deploys a diki-run Job which scans the given rulesets together with its ConfigMap and starts it.
+// The token Secret and the NetworkPolicy are only deployed if withSharedResources is true because they are shared
+// by all Jobs of a compliance scan.
+func (r *Reconciler) deployDikiRun(
+	ctx context.Context,
+	complianceScan *v1alpha1.ComplianceScan,
+	rulesets []shardRuleset,
+	dikiRunner *configv1alpha1.DikiRunnerConfig,
+	jobName, configMapName string,
+	exporterConfig *reportexporterv1alpha1.ReportExporterConfiguration,
+	withSharedResources bool,
+	log logr.Logger,
+) error {
+	job, err := r.deployDikiRunJob(ctx, complianceScan, dikiRunner, jobName, configMapName)
 	if err != nil {
 		return err
 	}
 	log.Info("Created Job successfully", "job", job.Name, "namespace", job.Namespace)
 
-	configMap, err := r.deployDikiConfigMap(ctx, configMapName, complianceScan, job, exporterConfig)
+	if withSharedResources && dikiRunner.TargetKubeconfig != nil && dikiRunner.TargetKubeconfig.TokenRequest != nil {
+		secret, err := r.deployTokenSecret(ctx, complianceScan, dikiRunner, job)
+		if err != nil {
+			return err
+		}
+		log.Info("Created token Secret successfully", "secret", secret.Name, "namespace", secret.Namespace)
+	}
+
+	if withSharedResources && complianceScan.Spec.TargetNamespace != "" {
+		serviceAccount, err := r.deployNamespaceScanRBAC(ctx, complianceScan, dikiRunner, job)
+		if err != nil {
+			return err
+		}
+		log.Info("Created ServiceAccount and RoleBinding successfully", "serviceAccount", serviceAccount.Name, "targetNamespace", complianceScan.Spec.TargetNamespace)
+	}
+
+	if withSharedResources && isNetworkPolicyEnabled(dikiRunner) {
+		networkPolicy, err := r.deployNetworkPolicy(ctx, complianceScan, dikiRunner, job)
+		if err != nil {
+			return err
+		}
+		log.Info("Created NetworkPolicy successfully", "networkPolicy", networkPolicy.Name, "namespace", networkPolicy.Namespace)
+	}
+
+	configMap, err := r.deployDikiConfigMap(ctx, configMapName, complianceScan, rulesets, dikiRunner, job, exporterConfig)
 	if err != nil {
 		return err
 	}
@@ -16,10 +16,17 @@ import (
 	gomegatypes "github.com/onsi/gomega/types"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -28,19 +35,24 @@ import (
 	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/diki-operator/internal/config"
 	compliancescan "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
 	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
 	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
+// syncPeriod differs from the default to verify that the configured sync period is used as requeue interval.
+const syncPeriod = 30 * time.Second
+
 var _ = Describe("Controller", func() {
 	var (
 		ctx = logf.IntoContext(context.Background(), logzap.New(logzap.WriteTo(GinkgoWriter)))
 
-		cr         *compliancescan.Reconciler
-		fakeClient client.Client
-		fakeConfig *rest.Config
+		cr           *compliancescan.Reconciler
+		fakeClient   client.Client
+		fakeConfig   *rest.Config
+		fakeRecorder *events.FakeRecorder
 
 		request reconcile.Request
 
@@ -57,12 +69,15 @@ var _ = Describe("Controller", func() {
 		fakeConfig = &rest.Config{
 			Host: "foo",
 		}
+		fakeRecorder = events.NewFakeRecorder(10)
 		cr = &compliancescan.Reconciler{
 			Client:       fakeClient,
 			SourceClient: fakeClient,
+			APIReader:    fakeClient,
 			RESTConfig:   fakeConfig,
+			Recorder:     fakeRecorder,
 			Config: configv1alpha1.ComplianceScanConfig{
-				SyncPeriod: &metav1.Duration{Duration: time.Hour},
+				SyncPeriod: &metav1.Duration{Duration: syncPeriod},
 				DikiRunner: configv1alpha1.DikiRunnerConfig{
 					PodCompletionTimeout: &metav1.Duration{Duration: time.Second * 5},
 				},
@@ -144,7 +159,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
 			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
@@ -207,7 +222,7 @@ var _ = Describe("Controller", func() {
 			It("should create a Job with the correct spec", func() {
 				res, err := cr.Reconcile(ctx, request)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
 				Expect(jobList.Items).To(HaveLen(1))
@@ -235,7 +250,8 @@ var _ = Describe("Controller", func() {
 							"RestartPolicy":         Equal(corev1.RestartPolicyNever),
 							"Containers": ConsistOf(
 								MatchFields(IgnoreExtras, Fields{
-									"Name": Equal("diki-scan"),
+									"Name":                     Equal("diki-scan"),
+									"TerminationMessagePolicy": Equal(corev1.TerminationMessageFallbackToLogsOnError),
 									"Args": Equal([]string{
 										"run",
 										"--config=/config/config.yaml",
@@ -263,7 +279,8 @@ var _ = Describe("Controller", func() {
 									})),
 								}),
 								MatchFields(IgnoreExtras, Fields{
-									"Name": Equal("report-exporter"),
+									"Name":                     Equal("report-exporter"),
+									"TerminationMessagePolicy": Equal(corev1.TerminationMessageFallbackToLogsOnError),
 									"Args": Equal([]string{
 										"--config=/config/exporter-config.yaml",
 									}),
@@ -373,7 +390,7 @@ var _ = Describe("Controller", func() {
 
 				res, err := cr.Reconcile(ctx, request)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
 				Expect(jobList.Items).To(HaveLen(1))
@@ -428,7 +445,7 @@ var _ = Describe("Controller", func() {
 
 				res, err := cr.Reconcile(ctx, request)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
 				Expect(jobList.Items).To(HaveLen(1))
@@ -483,6 +500,457 @@ var _ = Describe("Controller", func() {
 					"Value": Equal("/var/run/secrets/foo/kubeconfig"),
 				})))
 			})
+
+			It("should request a token and store it in a Secret owned by the Job when tokenRequest is set", func() {
+				serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "diki", Namespace: "kube-system"}}
+				Expect(fakeClient.Create(ctx, serviceAccount)).To(Succeed())
+				cr.Config.DikiRunner.TargetKubeconfig = &configv1alpha1.KubeconfigConfig{
+					SecretRef: configv1alpha1.SecretRef{
+						Name: "target-kubeconfig",
+					},
+					TokenRequest: &configv1alpha1.TokenRequestConfig{
+						ServiceAccount:    configv1alpha1.ServiceAccountRef{Name: "diki", Namespace: "kube-system"},
+						ExpirationSeconds: ptr.To[int64](3600),
+					},
+					MountPath: "/var/run/secrets/foo",
+				}
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				job := jobList.Items[0]
+
+				secret := &corev1.Secret{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: compliancescan.TokenSecretNamePrefix + string(complianceScan.UID), Namespace: job.Namespace}, secret)).To(Succeed())
+				Expect(secret.Data).To(Equal(map[string][]byte{"token": []byte("fake-token")}))
+				Expect(secret.Labels).To(HaveKeyWithValue("compliancescan.diki.gardener.cloud/uid", string(complianceScan.UID)))
+				Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind":       Equal("Job"),
+					"Name":       Equal(job.Name),
+					"Controller": PointTo(BeTrue()),
+				})))
+
+				Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Name": Equal("kubeconfig"),
+					"VolumeSource": MatchFields(IgnoreExtras, Fields{
+						"Projected": PointTo(MatchFields(IgnoreExtras, Fields{
+							"Sources": ContainElement(MatchFields(IgnoreExtras, Fields{
+								"Secret": PointTo(MatchFields(IgnoreExtras, Fields{
+									"LocalObjectReference": MatchFields(IgnoreExtras, Fields{
+										"Name": Equal(secret.Name),
+									}),
+									"Items": ConsistOf(MatchFields(IgnoreExtras, Fields{
+										"Key":  Equal("token"),
+										"Path": Equal("token"),
+									})),
+								})),
+							})),
+						})),
+					}),
+				})))
+			})
+
+			It("should set the ComplianceScan's phase to Failed when the token cannot be requested", func() {
+				cr.Config.DikiRunner.TargetKubeconfig = &configv1alpha1.KubeconfigConfig{
+					SecretRef: configv1alpha1.SecretRef{
+						Name: "target-kubeconfig",
+					},
+					TokenRequest: &configv1alpha1.TokenRequestConfig{
+						ServiceAccount: configv1alpha1.ServiceAccountRef{Name: "diki", Namespace: "kube-system"},
+						ManagementKubeconfigSecretRef: &configv1alpha1.SecretRef{
+							Name: "management-kubeconfig",
+						},
+					},
+				}
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+				Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+				Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+					"Message": ContainSubstring("failed to get management kubeconfig secret"),
+				})))
+			})
+
+			It("should create a NetworkPolicy owned by the Job allowing egress to DNS and the API server endpoints", func() {
+				cr.Config.DikiRunner.NetworkPolicy = &configv1alpha1.DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}
+				Expect(fakeClient.Create(ctx, &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "kubernetes",
+						Namespace: metav1.NamespaceDefault,
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "kubernetes"},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{
+						{Addresses: []string{"10.0.0.1"}},
+						{Addresses: []string{"10.0.0.2"}},
+					},
+					Ports: []discoveryv1.EndpointPort{{Name: ptr.To("https"), Port: ptr.To[int32](6443)}},
+				})).To(Succeed())
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				job := jobList.Items[0]
+
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: compliancescan.NetworkPolicyNamePrefix + string(complianceScan.UID), Namespace: job.Namespace}, networkPolicy)).To(Succeed())
+				Expect(networkPolicy.Labels).To(HaveKeyWithValue("compliancescan.diki.gardener.cloud/uid", string(complianceScan.UID)))
+				Expect(networkPolicy.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind":       Equal("Job"),
+					"Name":       Equal(job.Name),
+					"Controller": PointTo(BeTrue()),
+				})))
+				Expect(networkPolicy.Spec).To(Equal(networkingv1.NetworkPolicySpec{
+					PodSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)},
+					},
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+					Egress: []networkingv1.NetworkPolicyEgressRule{
+						{
+							To: []networkingv1.NetworkPolicyPeer{{
+								NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "kube-system"}},
+								PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "kube-dns"}},
+							}},
+							Ports: []networkingv1.NetworkPolicyPort{
+								{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))},
+								{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(53))},
+							},
+						},
+						{
+							To: []networkingv1.NetworkPolicyPeer{
+								{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}},
+								{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.2/32"}},
+							},
+							Ports: []networkingv1.NetworkPolicyPort{
+								{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(6443))},
+							},
+						},
+					},
+				}))
+			})
+
+			It("should create a NetworkPolicy allowing egress to the configured API server CIDRs", func() {
+				cr.Config.DikiRunner.NetworkPolicy = &configv1alpha1.DikiRunnerNetworkPolicy{
+					Enabled:        ptr.To(true),
+					APIServerCIDRs: []string{"192.168.0.0/24"},
+				}
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: compliancescan.NetworkPolicyNamePrefix + string(complianceScan.UID)}, networkPolicy)).To(Succeed())
+				Expect(networkPolicy.Spec.Egress).To(ContainElement(networkingv1.NetworkPolicyEgressRule{
+					To: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/24"}},
+					},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(443))},
+					},
+				}))
+			})
+
+			It("should not create a NetworkPolicy when it is disabled", func() {
+				cr.Config.DikiRunner.NetworkPolicy = &configv1alpha1.DikiRunnerNetworkPolicy{Enabled: ptr.To(false)}
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				networkPolicyList := &networkingv1.NetworkPolicyList{}
+				Expect(fakeClient.List(ctx, networkPolicyList)).To(Succeed())
+				Expect(networkPolicyList.Items).To(BeEmpty())
+			})
+
+			It("should not use the API server endpoints of the default/kubernetes service with a target kubeconfig", func() {
+				cr.Config.DikiRunner.NetworkPolicy = &configv1alpha1.DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}
+				cr.Config.DikiRunner.TargetKubeconfig = &configv1alpha1.KubeconfigConfig{
+					SecretRef: configv1alpha1.SecretRef{Name: "target-kubeconfig"},
+					MountPath: "/var/run/secrets/target",
+				}
+				Expect(fakeClient.Create(ctx, &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "kubernetes",
+						Namespace: metav1.NamespaceDefault,
+						Labels:    map[string]string{discoveryv1.LabelServiceName: "kubernetes"},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}}},
+				})).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+				Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+				Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+					"Message": ContainSubstring("API server CIDRs must be configured for DikiRunners with a target kubeconfig"),
+				})))
+			})
+
+			It("should set the ComplianceScan's phase to Failed when the API server endpoints cannot be determined", func() {
+				cr.Config.DikiRunner.NetworkPolicy = &configv1alpha1.DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+				Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+				Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+					"Message": ContainSubstring("no endpoints found for the default/kubernetes service"),
+				})))
+			})
+
+			It("should create a Job with diki-scan as init container when the sequential layout is configured", func() {
+				cr.Config.DikiRunner.JobLayout = configv1alpha1.JobLayoutSequential
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+
+				podSpec := jobList.Items[0].Spec.Template.Spec
+				Expect(podSpec.InitContainers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Name": Equal("diki-scan"),
+				})))
+				Expect(podSpec.Containers).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Name": Equal("report-exporter"),
+				})))
+
+				configMap := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: compliancescan.ConfigMapNamePrefix + string(complianceScan.UID)}, configMap)).To(Succeed())
+				Expect(configMap.Data["exporter-config.yaml"]).NotTo(ContainSubstring("waitForReport"))
+			})
+
+			It("should create a Job with the PriorityClass the priority is mapped to", func() {
+				cr.Config.DikiRunner.PodTemplate = &configv1alpha1.DikiRunnerPodTemplate{PriorityClassName: "diki-default"}
+				cr.Config.DikiRunner.PriorityClasses = []configv1alpha1.PriorityClassMapping{
+					{MinPriority: 100, PriorityClassName: "diki-high"},
+				}
+				complianceScan.Spec.Priority = ptr.To(int32(100))
+				Expect(fakeClient.Update(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].Spec.Template.Spec.PriorityClassName).To(Equal("diki-high"))
+			})
+
+			It("should keep the PriorityClass of the pod template when the priority is not mapped", func() {
+				cr.Config.DikiRunner.PodTemplate = &configv1alpha1.DikiRunnerPodTemplate{PriorityClassName: "diki-default"}
+				cr.Config.DikiRunner.PriorityClasses = []configv1alpha1.PriorityClassMapping{
+					{MinPriority: 100, PriorityClassName: "diki-high"},
+				}
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].Spec.Template.Spec.PriorityClassName).To(Equal("diki-default"))
+			})
+
+			It("should use the configuration of the ConfigStore when it is set", func() {
+				reloadedConfig := cr.Config.DeepCopy()
+				reloadedConfig.DikiRunner.Namespace = "reloaded"
+				cr.ConfigStore = config.NewStore(reloadedConfig)
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Namespace": Equal("reloaded")}),
+				})))
+			})
+
+			It("should create a Job with the overridden images", func() {
+				Expect(fakeClient.Delete(ctx, complianceScan)).To(Succeed())
+				complianceScan.ResourceVersion = ""
+				complianceScan.Spec.Image = &dikiv1alpha1.ImageOverrides{
+					Diki:           "registry.example.com/diki:v1.0.0",
+					ReportExporter: "registry.example.com/report-exporter:v1.0.0",
+				}
+				Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].Spec.Template.Spec.Containers).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("diki-scan"),
+						"Image": Equal("registry.example.com/diki:v1.0.0"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("report-exporter"),
+						"Image": Equal("registry.example.com/report-exporter:v1.0.0"),
+					}),
+				))
+			})
+
+			It("should create the Job and ConfigMap in the namespace of the referenced runner profile", func() {
+				cr.Config.DikiRunnerProfiles = map[string]configv1alpha1.DikiRunnerConfig{
+					"foo": {
+						Namespace:            "foo",
+						Labels:               map[string]string{"profile": "foo"},
+						PodCompletionTimeout: &metav1.Duration{Duration: time.Minute},
+					},
+				}
+				complianceScan.Spec.RunnerProfile = "foo"
+				Expect(fakeClient.Update(ctx, complianceScan)).To(Succeed())
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+
+				job := jobList.Items[0]
+				Expect(job.Namespace).To(Equal("foo"))
+				Expect(job.Labels).To(HaveKeyWithValue("profile", "foo"))
+				Expect(job.Spec.Template.Spec.ActiveDeadlineSeconds).To(PointTo(Equal(int64(60))))
+
+				configMap := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: compliancescan.ConfigMapNamePrefix + string(complianceScan.UID), Namespace: "foo"}, configMap)).To(Succeed())
+			})
+
+			It("should set the ComplianceScan's phase to Failed when the runner profile is not configured", func() {
+				complianceScan.Spec.RunnerProfile = "foo"
+				Expect(fakeClient.Update(ctx, complianceScan)).To(Succeed())
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{}))
+
+				Expect(fakeClient.List(ctx, jobList)).To(Succeed())
+				Expect(jobList.Items).To(BeEmpty())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+				Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+				Expect(complianceScan.Status.Conditions).To(ContainElement(
+					MatchFields(IgnoreExtras, Fields{
+						"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+						"Message": Equal(`ComplianceScan failed with error: runner profile "foo" is not configured`),
+					}),
+				))
+			})
+
+			It("should create a Job with the configured pod template merged into it", func() {
+				cr.Config.DikiRunner.PodTemplate = &configv1alpha1.DikiRunnerPodTemplate{
+					Labels: map[string]string{
+						"foo":                                    "bar",
+						"compliancescan.diki.gardener.cloud/uid": "foo",
+					},
+					Annotations: map[string]string{"foo": "bar"},
+					Resources: &configv1alpha1.DikiRunnerResources{
+						DikiScan: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+						},
+						ReportExporter: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+						},
+					},
+					NodeSelector: map[string]string{"worker.gardener.cloud/pool": "diki"},
+					Affinity: &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{},
+						},
+					},
+					Tolerations: []corev1.Toleration{
+						{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "diki", Effect: corev1.TaintEffectNoSchedule},
+					},
+					PriorityClassName: "diki-runner",
+					ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "pull-secret"}},
+					RuntimeClassName:  ptr.To("gvisor"),
+				}
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+
+				job := jobList.Items[0]
+				Expect(job.Labels).NotTo(HaveKey("foo"))
+				Expect(job.Spec.Template.Labels).To(Equal(map[string]string{
+					"app.kubernetes.io/name":                  "diki",
+					"app.kubernetes.io/managed-by":            "diki-operator",
+					"compliancescan.diki.gardener.cloud/uid":  string(complianceScan.UID),
+					"compliancescan.diki.gardener.cloud/name": complianceScan.Name,
+					"foo": "bar",
+				}))
+				Expect(job.Spec.Template.Annotations).To(Equal(map[string]string{"foo": "bar"}))
+				Expect(job.Spec.Template.Spec).To(MatchFields(IgnoreExtras, Fields{
+					"Containers": ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"Name": Equal("diki-scan"),
+							"Resources": Equal(corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+							}),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"Name": Equal("report-exporter"),
+							"Resources": Equal(corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+							}),
+						}),
+					),
+					"NodeSelector": Equal(map[string]string{"worker.gardener.cloud/pool": "diki"}),
+					"Affinity":     PointTo(MatchFields(IgnoreExtras, Fields{"NodeAffinity": Not(BeNil())})),
+					"Tolerations": ConsistOf(corev1.Toleration{
+						Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "diki", Effect: corev1.TaintEffectNoSchedule,
+					}),
+					"PriorityClassName": Equal("diki-runner"),
+					"ImagePullSecrets":  ConsistOf(corev1.LocalObjectReference{Name: "pull-secret"}),
+					"RuntimeClassName":  PointTo(Equal("gvisor")),
+				}))
+			})
+
+			It("should only override the fields which are set in the configured pod template", func() {
+				cr.Config.DikiRunner.PodTemplate = &configv1alpha1.DikiRunnerPodTemplate{
+					Annotations: map[string]string{"foo": "bar"},
+				}
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+				Expect(fakeClient.List(ctx, jobList, client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID)})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+
+				job := jobList.Items[0]
+				Expect(job.Spec.Template.Annotations).To(Equal(map[string]string{"foo": "bar"}))
+				Expect(job.Spec.Template.Labels).To(HaveKeyWithValue("compliancescan.diki.gardener.cloud/uid", string(complianceScan.UID)))
+				Expect(job.Spec.Template.Spec.Tolerations).To(ConsistOf(
+					corev1.Toleration{Effect: corev1.TaintEffectNoSchedule, Operator: corev1.TolerationOpExists},
+					corev1.Toleration{Effect: corev1.TaintEffectNoExecute, Operator: corev1.TolerationOpExists},
+				))
+				Expect(job.Spec.Template.Spec.NodeSelector).To(BeNil())
+				Expect(job.Spec.Template.Spec.Affinity).To(BeNil())
+				Expect(job.Spec.Template.Spec.PriorityClassName).To(BeEmpty())
+				Expect(job.Spec.Template.Spec.ImagePullSecrets).To(BeNil())
+				Expect(job.Spec.Template.Spec.RuntimeClassName).To(BeNil())
+			})
 		})
 	})
 
@@ -561,7 +1029,7 @@ var _ = Describe("Controller", func() {
 			Entry("Job is still running",
 				nil,
 				nil,
-				reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval},
+				reconcile.Result{RequeueAfter: syncPeriod},
 				dikiv1alpha1.ComplianceScanRunning,
 				nil,
 			),
@@ -658,36 +1126,197 @@ var _ = Describe("Controller", func() {
 			))
 		})
 
-		It("should fail when the Job is not found", func() {
-			fakeClient = fakeClientBuilder.WithObjects(complianceScan).Build()
+		It("should add the container failures to the Failed condition and emit an Event when the Job fails", func() {
+			dikiRunJob.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+			}
+			dikiRunPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "diki-run-pod",
+					Labels: map[string]string{
+						"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID),
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name: "diki-scan",
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 1,
+								Reason:   "Error",
+								Message:  "line 1\nline 2\nline 3\nline 4\nline 5\nline 6\nline 7\nline 8\nline 9\nline 10\nline 11\n",
+							}},
+						},
+						{
+							Name: "report-exporter",
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 1,
+								Reason:   "Error",
+								Message:  `{"error":"error waiting for report file: timed out"}`,
+							}},
+						},
+					},
+				},
+			}
+
+			fakeClient = fakeClientBuilder.WithObjects(complianceScan, dikiRunJob, dikiRunPod).Build()
 			cr.Client = fakeClient
 			cr.SourceClient = fakeClient
 
-			res, err := cr.Reconcile(ctx, request)
+			_, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{}))
+
+			expectedMessage := "job failed: BackoffLimitExceeded; " +
+				"container diki-scan exited with code 1 (Error): line 2\nline 3\nline 4\nline 5\nline 6\nline 7\nline 8\nline 9\nline 10\nline 11; " +
+				"container report-exporter exited with code 1 (Error): error waiting for report file: timed out"
 
 			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
 			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
-			Expect(complianceScan.Status.Conditions).To(ContainElement(
-				MatchFields(IgnoreExtras, Fields{
-					"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
-					"Status":  Equal(dikiv1alpha1.ConditionTrue),
-					"Message": ContainSubstring("failed to get diki runner job"),
-				}),
-			))
+			Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+				"Reason":  Equal(compliancescan.ConditionReasonScannerFailed),
+				"Message": Equal("ComplianceScan failed with error: " + expectedMessage),
+			})))
+			Expect(fakeRecorder.Events).To(Receive(Equal("Warning ScannerFailed ComplianceScan failed with error: " + expectedMessage)))
 		})
 
-		It("should return error when patchCompleted fails", func() {
-			dikiRunJob.Status.Conditions = []batchv1.JobCondition{
-				{
-					Type:   batchv1.JobComplete,
-					Status: corev1.ConditionTrue,
-				},
-			}
-			interceptingClient := fakeClientBuilder.
-				WithObjects(complianceScan, dikiRunJob).
-				WithInterceptorFuncs(interceptor.Funcs{
+		DescribeTable("should set the reason of the Failed condition depending on the failed container",
+			func(initContainerStatuses, containerStatuses []corev1.ContainerStatus, expectedReason string) {
+				dikiRunJob.Status.Conditions = []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+				}
+				dikiRunPod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "diki-run-pod",
+						Labels: map[string]string{
+							"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID),
+						},
+					},
+					Status: corev1.PodStatus{
+						InitContainerStatuses: initContainerStatuses,
+						ContainerStatuses:     containerStatuses,
+					},
+				}
+
+				fakeClient = fakeClientBuilder.WithObjects(complianceScan, dikiRunJob, dikiRunPod).Build()
+				cr.Client = fakeClient
+				cr.SourceClient = fakeClient
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+				Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+				Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(dikiv1alpha1.ConditionTypeFailed),
+					"Reason": Equal(expectedReason),
+				})))
+			},
+			Entry("diki-scan init container fails",
+				[]corev1.ContainerStatus{
+					{Name: "diki-scan", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+				},
+				[]corev1.ContainerStatus{
+					{Name: "report-exporter", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+				},
+				compliancescan.ConditionReasonScannerFailed,
+			),
+			Entry("report-exporter fails after diki-scan init container completed",
+				[]corev1.ContainerStatus{
+					{Name: "diki-scan", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+				},
+				[]corev1.ContainerStatus{
+					{Name: "report-exporter", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+				},
+				compliancescan.ConditionReasonExporterFailed,
+			),
+			Entry("report-exporter fails while diki-scan container is running",
+				nil,
+				[]corev1.ContainerStatus{
+					{Name: "diki-scan", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+					{Name: "report-exporter", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+				},
+				compliancescan.ConditionReasonExporterFailed,
+			),
+			Entry("no container failed",
+				nil,
+				nil,
+				compliancescan.ConditionReasonFailed,
+			),
+		)
+
+		It("should record the images of the diki runner pods when the Job finishes", func() {
+			dikiRunJob.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			}
+			dikiRunPod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "diki-run-pod",
+					Labels: map[string]string{
+						"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID),
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:    "report-exporter",
+							Image:   "registry.example.com/report-exporter:v1.0.0",
+							ImageID: "registry.example.com/report-exporter@sha256:bar",
+						},
+						{
+							Name:    "diki-scan",
+							Image:   "registry.example.com/diki:v1.0.0",
+							ImageID: "docker-pullable://registry.example.com/diki@sha256:foo",
+						},
+					},
+				},
+			}
+
+			fakeClient = fakeClientBuilder.WithObjects(complianceScan, dikiRunJob, dikiRunPod).Build()
+			cr.Client = fakeClient
+			cr.SourceClient = fakeClient
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanCompleted))
+			Expect(complianceScan.Status.Images).To(Equal([]dikiv1alpha1.ImageStatus{
+				{Container: "diki-scan", Image: "registry.example.com/diki:v1.0.0", Digest: "sha256:foo"},
+				{Container: "report-exporter", Image: "registry.example.com/report-exporter:v1.0.0", Digest: "sha256:bar"},
+			}))
+		})
+
+		It("should fail when the Job is not found", func() {
+			fakeClient = fakeClientBuilder.WithObjects(complianceScan).Build()
+			cr.Client = fakeClient
+			cr.SourceClient = fakeClient
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+			Expect(complianceScan.Status.Conditions).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+					"Status":  Equal(dikiv1alpha1.ConditionTrue),
+					"Message": ContainSubstring("failed to get diki runner job"),
+				}),
+			))
+		})
+
+		It("should return error when patchCompleted fails", func() {
+			dikiRunJob.Status.Conditions = []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				},
+			}
+			interceptingClient := fakeClientBuilder.
+				WithObjects(complianceScan, dikiRunJob).
+				WithInterceptorFuncs(interceptor.Funcs{
 					SubResourcePatch: func(ctx context.Context, client client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
 						cs := obj.(*dikiv1alpha1.ComplianceScan)
 						if cs.Status.Phase == dikiv1alpha1.ComplianceScanCompleted {
@@ -850,7 +1479,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
 			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
@@ -878,7 +1507,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.List(ctx, configMapList,
 				client.MatchingLabels{"compliancescan.diki.gardener.cloud/name": "compliancescan"},
@@ -902,7 +1531,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.List(ctx, configMapList,
 				client.MatchingLabels{"compliancescan.diki.gardener.cloud/name": "compliancescan"},
@@ -930,7 +1559,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
 			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
@@ -996,7 +1625,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
 			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
@@ -1016,6 +1645,64 @@ var _ = Describe("Controller", func() {
 			Expect(configMap.Data).To(HaveKey("config.yaml"))
 			Expect(configMap.Data["config.yaml"]).To(Equal(configFor(disaConfig, secK8sConfig)))
 		})
+
+		It("should build the diki config from the options recorded in the snapshot", func() {
+			complianceScan.Spec.Rulesets = []dikiv1alpha1.RulesetConfig{
+				{
+					ID:      "disa-kubernetes-stig",
+					Version: "v1",
+					Options: &dikiv1alpha1.RulesetOptions{
+						Ruleset: &dikiv1alpha1.Options{
+							ConfigMapRef: &dikiv1alpha1.OptionsConfigMapRef{
+								Name:      "options-configmap",
+								Namespace: "kube-system",
+							},
+						},
+						Rules: &dikiv1alpha1.Options{
+							ConfigMapRef: &dikiv1alpha1.OptionsConfigMapRef{
+								Name:      "options-configmap",
+								Namespace: "kube-system",
+							},
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			// Change the options after the snapshot has been recorded.
+			changed := false
+			cr.Client = interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if err := c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...); err != nil {
+						return err
+					}
+					if cs, ok := obj.(*dikiv1alpha1.ComplianceScan); ok && cs.Status.Snapshot != nil && !changed {
+						changed = true
+						changedConfigMap := &corev1.ConfigMap{}
+						Expect(c.Get(ctx, client.ObjectKeyFromObject(optionsConfigMap), changedConfigMap)).To(Succeed())
+						changedConfigMap.Data["disa-kubernetes-stig"] = setRulesetOptions
+						changedConfigMap.Data["disa-kubernetes-stig-rules"] = setRuleOptions
+						Expect(c.Update(ctx, changedConfigMap)).To(Succeed())
+					}
+					return nil
+				},
+			})
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+			Expect(changed).To(BeTrue())
+
+			Expect(fakeClient.List(ctx, configMapList,
+				client.MatchingLabels{"compliancescan.diki.gardener.cloud/name": "compliancescan"},
+				client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": "1"},
+			)).To(Succeed())
+			Expect(len(configMapList.Items)).To(Equal(1))
+
+			configMap := configMapList.Items[0]
+			Expect(configMap.Data).To(HaveKey("config.yaml"))
+			Expect(configMap.Data["config.yaml"]).To(Equal(configFor(disaConfigWith("v1", defaultRulesetOptions, defaultRuleOptions))))
+		})
 	})
 
 	Describe("exporter config in ConfigMap", func() {
@@ -1030,7 +1717,7 @@ var _ = Describe("Controller", func() {
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.List(ctx, configMapList,
 				client.MatchingLabels{"compliancescan.diki.gardener.cloud/name": "compliancescan"},
@@ -1071,7 +1758,7 @@ waitForReport: true
 
 			res, err := cr.Reconcile(ctx, request)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(res).To(Equal(reconcile.Result{RequeueAfter: compliancescan.ReconciliationRequeueInterval}))
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
 
 			Expect(fakeClient.List(ctx, configMapList,
 				client.MatchingLabels{"compliancescan.diki.gardener.cloud/name": "compliancescan"},
@@ -1095,4 +1782,828 @@ waitForReport: true
 		})
 	})
 
+	Describe("snapshot", func() {
+		var reportOutput *dikiv1alpha1.ReportOutput
+
+		BeforeEach(func() {
+			reportOutput = &dikiv1alpha1.ReportOutput{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-output"},
+				Spec: dikiv1alpha1.ReportOutputSpec{
+					Output: dikiv1alpha1.Output{
+						ConfigMap: &dikiv1alpha1.OutputConfigMap{
+							Namespace:  "kube-system",
+							NamePrefix: "scan-report-",
+						},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, reportOutput)).To(Succeed())
+
+			Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "options", Namespace: "kube-system"},
+				Data: map[string]string{
+					"FAKE":       "foo: bar\n",
+					"FAKE-rules": "[]\n",
+				},
+			})).To(Succeed())
+
+			complianceScan.Spec.Rulesets[0].Options = &dikiv1alpha1.RulesetOptions{
+				Ruleset: &dikiv1alpha1.Options{
+					ConfigMapRef: &dikiv1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system", Key: ptr.To("FAKE")},
+				},
+				Rules: &dikiv1alpha1.Options{
+					ConfigMapRef: &dikiv1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system"},
+				},
+			}
+			complianceScan.Spec.Image = &dikiv1alpha1.ImageOverrides{
+				Diki:           "registry.example.com/diki:v1.0.0",
+				ReportExporter: "registry.example.com/report-exporter:v1.0.0",
+			}
+			complianceScan.Spec.Outputs = []dikiv1alpha1.ReportOutputRef{{Name: "my-output"}}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+		})
+
+		It("should record the images, ruleset options and ReportOutputs when the ComplianceScan is started", func() {
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+			Expect(complianceScan.Status.Snapshot).To(PointTo(Equal(dikiv1alpha1.ComplianceScanSnapshot{
+				Images: dikiv1alpha1.SnapshotImages{
+					Diki:           "registry.example.com/diki:v1.0.0",
+					ReportExporter: "registry.example.com/report-exporter:v1.0.0",
+				},
+				Rulesets: []dikiv1alpha1.RulesetSnapshot{
+					{
+						ID:      "FAKE",
+						Version: "FAKE",
+						RulesetOptions: &dikiv1alpha1.OptionsSnapshot{
+							ConfigMapRef:    dikiv1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system", Key: ptr.To("FAKE")},
+							ResourceVersion: "1",
+							Hash:            "1dabc4e3cbbd6a0818bd460f3a6c9855bfe95d506c74726bc0f2edb0aecb1f4e",
+							Data:            "foo: bar\n",
+						},
+						RuleOptions: &dikiv1alpha1.OptionsSnapshot{
+							ConfigMapRef:    dikiv1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system", Key: ptr.To("FAKE-rules")},
+							ResourceVersion: "1",
+							Hash:            "37517e5f3dc66819f61f5a7bb8ace1921282415f10551d2defa5c3eb0985b570",
+							Data:            "[]\n",
+						},
+					},
+				},
+				Outputs: []dikiv1alpha1.OutputSnapshot{
+					{Name: "my-output", Output: reportOutput.Spec.Output},
+				},
+			})))
+		})
+
+		It("should set the ComplianceScan's phase to Failed when a referenced options ConfigMap does not exist", func() {
+			complianceScan.Spec.Rulesets[0].Options.Rules.ConfigMapRef.Name = "missing"
+			Expect(fakeClient.Update(ctx, complianceScan)).To(Succeed())
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+			Expect(complianceScan.Status.Snapshot).To(BeNil())
+		})
+	})
+
+	Describe("ComplianceScan restricted to a namespace", func() {
+		var name string
+
+		BeforeEach(func() {
+			name = compliancescan.NamespaceScanNamePrefix + string(complianceScan.UID)
+			cr.Config.DikiRunner.Namespace = "kube-system"
+			complianceScan.Spec.TargetNamespace = "tenant"
+			namespaceScopedRulesets := compliancescan.NamespaceScopedRulesets
+			compliancescan.NamespaceScopedRulesets = sets.New("FAKE")
+			DeferCleanup(func() { compliancescan.NamespaceScopedRulesets = namespaceScopedRulesets })
+		})
+
+		It("should run the Job with a ServiceAccount which is only granted access to the target namespace", func() {
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+
+			job := &batchv1.Job{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: compliancescan.JobNamePrefix + string(complianceScan.UID), Namespace: "kube-system"}, job)).To(Succeed())
+			Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal(name))
+
+			serviceAccount := &corev1.ServiceAccount{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "kube-system"}, serviceAccount)).To(Succeed())
+			Expect(serviceAccount.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("Job"),
+				"Name": Equal(job.Name),
+			})))
+
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "tenant"}, roleBinding)).To(Succeed())
+			Expect(roleBinding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "namespace-scanner.diki.gardener.cloud"}))
+			Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: name, Namespace: "kube-system"}))
+			Expect(roleBinding.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("ComplianceScan"),
+				"Name": Equal(complianceScan.Name),
+				"UID":  Equal(complianceScan.UID),
+			})))
+
+			clusterRoleList := &rbacv1.ClusterRoleList{}
+			Expect(fakeClient.List(ctx, clusterRoleList)).To(Succeed())
+			Expect(clusterRoleList.Items).To(BeEmpty())
+			clusterRoleBindingList := &rbacv1.ClusterRoleBindingList{}
+			Expect(fakeClient.List(ctx, clusterRoleBindingList)).To(Succeed())
+			Expect(clusterRoleBindingList.Items).To(BeEmpty())
+		})
+
+		DescribeTable("should set the ComplianceScan's phase to Failed when it cannot be restricted to the namespace",
+			func(mutate func(), message string) {
+				mutate()
+				Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+				Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+				Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+					"Message": Equal("ComplianceScan failed with error: " + message),
+				})))
+				err = fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "tenant"}, &rbacv1.RoleBinding{})
+				Expect(err).To(HaveOccurred())
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+			},
+			Entry("options outside of the target namespace", func() {
+				complianceScan.Spec.Rulesets[0].Options = &dikiv1alpha1.RulesetOptions{
+					Rules: &dikiv1alpha1.Options{
+						ConfigMapRef: &dikiv1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system"},
+					},
+				}
+			}, "options of ruleset FAKE reference configMap kube-system/options outside of the target namespace tenant"),
+			Entry("outputs outside of the target namespace", func() {
+				Expect(fakeClient.Create(ctx, &dikiv1alpha1.ReportOutput{
+					ObjectMeta: metav1.ObjectMeta{Name: "output"},
+					Spec: dikiv1alpha1.ReportOutputSpec{
+						Output: dikiv1alpha1.Output{ConfigMap: &dikiv1alpha1.OutputConfigMap{Namespace: "kube-system"}},
+					},
+				})).To(Succeed())
+				complianceScan.Spec.Outputs = []dikiv1alpha1.ReportOutputRef{{Name: "output"}}
+			}, `ReportOutput "output" exports to namespace kube-system outside of the target namespace tenant`),
+			Entry("ruleset which cannot be restricted to a namespace", func() {
+				complianceScan.Spec.Rulesets = append(complianceScan.Spec.Rulesets, dikiv1alpha1.RulesetConfig{ID: "disa-kubernetes-stig", Version: "v2r5"})
+			}, "ruleset disa-kubernetes-stig cannot be restricted to a namespace"),
+			Entry("parallelism greater than 1", func() {
+				complianceScan.Spec.Rulesets = append(complianceScan.Spec.Rulesets, dikiv1alpha1.RulesetConfig{ID: "OTHER", Version: "FAKE"})
+				complianceScan.Spec.Parallelism = ptr.To[int32](2)
+			}, "compliance scans restricted to a namespace cannot be run with a parallelism greater than 1"),
+			Entry("DikiRunner with a target kubeconfig", func() {
+				cr.Config.DikiRunner.TargetKubeconfig = &configv1alpha1.KubeconfigConfig{
+					SecretRef: configv1alpha1.SecretRef{Name: "target-kubeconfig"},
+					MountPath: "/var/run/secrets/target",
+				}
+			}, "compliance scans restricted to a namespace are not supported by DikiRunners with a target kubeconfig"),
+		)
+
+		It("should not generate a diki config for rulesets which cannot be restricted to a namespace", func() {
+			complianceScan.Spec.Rulesets = []dikiv1alpha1.RulesetConfig{{ID: "security-hardened-k8s", Version: "v0.1.0"}}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+
+			configMapList := &corev1.ConfigMapList{}
+			Expect(fakeClient.List(ctx, configMapList, client.InNamespace("kube-system"))).To(Succeed())
+			Expect(configMapList.Items).To(BeEmpty())
+		})
+
+		It("should delete the ServiceAccount when the Job has finished", func() {
+			complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanRunning
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+			Expect(fakeClient.Status().Update(ctx, complianceScan)).To(Succeed())
+			Expect(fakeClient.Create(ctx, &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: compliancescan.JobNamePrefix + string(complianceScan.UID), Namespace: "kube-system"},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+				},
+			})).To(Succeed())
+
+			serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system"}}
+			Expect(fakeClient.Create(ctx, serviceAccount)).To(Succeed())
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanCompleted))
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(serviceAccount), serviceAccount)
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+		})
+	})
+
+	Describe("admission", func() {
+		var (
+			jobList *batchv1.JobList
+			now     time.Time
+		)
+
+		newComplianceScan := func(name string, created time.Time, phase dikiv1alpha1.ComplianceScanPhase, runnerProfile string) *dikiv1alpha1.ComplianceScan {
+			return &dikiv1alpha1.ComplianceScan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					UID:               types.UID(name),
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec: dikiv1alpha1.ComplianceScanSpec{
+					RunnerProfile: runnerProfile,
+					Rulesets:      []dikiv1alpha1.RulesetConfig{{ID: "FAKE", Version: "FAKE"}},
+				},
+				Status: dikiv1alpha1.ComplianceScanStatus{Phase: phase},
+			}
+		}
+
+		BeforeEach(func() {
+			jobList = &batchv1.JobList{}
+			now = time.Now().Truncate(time.Second)
+			complianceScan.CreationTimestamp = metav1.NewTime(now)
+		})
+
+		setObjects := func(objects ...client.Object) {
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&dikiv1alpha1.ComplianceScan{}).
+				WithObjects(objects...).
+				Build()
+			cr.Client = fakeClient
+			cr.SourceClient = fakeClient
+			cr.APIReader = fakeClient
+		}
+
+		It("should queue the ComplianceScan when the global limit is reached", func() {
+			cr.Config.MaxConcurrentJobs = ptr.To(int32(1))
+			setObjects(complianceScan, newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, ""))
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanQueued))
+			Expect(complianceScan.Status.QueuePosition).To(PointTo(Equal(int32(1))))
+			Expect(complianceScan.Status.Conditions).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(dikiv1alpha1.ConditionTypeCompleted),
+					"Status":  Equal(dikiv1alpha1.ConditionFalse),
+					"Reason":  Equal(compliancescan.ConditionReasonQueued),
+					"Message": Equal("ComplianceScan is queued at position 1"),
+				}),
+			))
+
+			Expect(fakeClient.List(ctx, jobList)).To(Succeed())
+			Expect(jobList.Items).To(BeEmpty())
+		})
+
+		It("should not let the ComplianceScan overtake earlier queued ComplianceScans", func() {
+			cr.Config.MaxConcurrentJobs = ptr.To(int32(2))
+			setObjects(complianceScan,
+				newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, ""),
+				newComplianceScan("queued", now.Add(-time.Minute), dikiv1alpha1.ComplianceScanQueued, ""),
+				newComplianceScan("later", now.Add(time.Minute), "", ""),
+			)
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanQueued))
+			Expect(complianceScan.Status.QueuePosition).To(PointTo(Equal(int32(1))))
+		})
+
+		It("should admit ComplianceScans with a higher priority before earlier queued ones", func() {
+			cr.Config.MaxConcurrentJobs = ptr.To(int32(2))
+			complianceScan.Spec.Priority = ptr.To(int32(100))
+			setObjects(complianceScan,
+				newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, ""),
+				newComplianceScan("queued", now.Add(-time.Minute), dikiv1alpha1.ComplianceScanQueued, ""),
+			)
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+		})
+
+		It("should queue ComplianceScans with a lower priority behind later ones", func() {
+			cr.Config.MaxConcurrentJobs = ptr.To(int32(1))
+			complianceScan.Spec.Priority = ptr.To(int32(-1))
+			setObjects(complianceScan,
+				newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, ""),
+				newComplianceScan("later", now.Add(time.Minute), "", ""),
+			)
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanQueued))
+			Expect(complianceScan.Status.QueuePosition).To(PointTo(Equal(int32(2))))
+		})
+
+		It("should count the shards of running ComplianceScans", func() {
+			cr.Config.MaxConcurrentJobs = ptr.To(int32(2))
+			running := newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, "")
+			running.Spec.Parallelism = ptr.To(int32(2))
+			running.Spec.Rulesets = append(running.Spec.Rulesets, dikiv1alpha1.RulesetConfig{ID: "FAKE2", Version: "FAKE"})
+			setObjects(complianceScan, running)
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanQueued))
+		})
+
+		It("should queue the ComplianceScan when the limit of its runner profile is reached", func() {
+			cr.Config.DikiRunnerProfiles = map[string]configv1alpha1.DikiRunnerConfig{
+				"large": {PodCompletionTimeout: &metav1.Duration{Duration: time.Minute}, MaxConcurrentJobs: ptr.To(int32(1))},
+			}
+			complianceScan.Spec.RunnerProfile = "large"
+			setObjects(complianceScan, newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, "large"))
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanQueued))
+			Expect(complianceScan.Status.QueuePosition).To(PointTo(Equal(int32(1))))
+		})
+
+		It("should admit the ComplianceScan when only the limit of another runner profile is reached", func() {
+			cr.Config.DikiRunnerProfiles = map[string]configv1alpha1.DikiRunnerConfig{
+				"large": {PodCompletionTimeout: &metav1.Duration{Duration: time.Minute}, MaxConcurrentJobs: ptr.To(int32(1))},
+			}
+			setObjects(complianceScan,
+				newComplianceScan("running", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanRunning, "large"),
+				newComplianceScan("queued", now.Add(-time.Minute), dikiv1alpha1.ComplianceScanQueued, "large"),
+			)
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+
+			Expect(fakeClient.List(ctx, jobList)).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+		})
+
+		It("should admit a queued ComplianceScan once a Job slot is free", func() {
+			cr.Config.MaxConcurrentJobs = ptr.To(int32(1))
+			complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanQueued
+			complianceScan.Status.QueuePosition = ptr.To(int32(1))
+			setObjects(complianceScan, newComplianceScan("completed", now.Add(-time.Hour), dikiv1alpha1.ComplianceScanCompleted, ""))
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+			Expect(complianceScan.Status.QueuePosition).To(BeNil())
+
+			Expect(fakeClient.List(ctx, jobList)).To(Succeed())
+			Expect(jobList.Items).To(HaveLen(1))
+		})
+	})
+
+	Describe("sharded ComplianceScan", func() {
+		var (
+			shardJobs       []*batchv1.Job
+			partialReports  []*corev1.ConfigMap
+			mergeJobKey     client.ObjectKey
+			mergeConfigKey  client.ObjectKey
+			configMapList   *corev1.ConfigMapList
+			runningScanCond dikiv1alpha1.Condition
+		)
+
+		BeforeEach(func() {
+			complianceScan.Spec.Parallelism = ptr.To[int32](2)
+			complianceScan.Spec.Rulesets = []dikiv1alpha1.RulesetConfig{
+				{ID: "disa-kubernetes-stig", Version: "v2r4"},
+				{ID: "security-hardened-k8s", Version: "v0.1.0"},
+			}
+
+			shardJobs = nil
+			partialReports = nil
+			for _, shard := range []string{"0", "1"} {
+				shardJobs = append(shardJobs, &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: compliancescan.JobNamePrefix + string(complianceScan.UID) + "-" + shard,
+					},
+				})
+				partialReports = append(partialReports, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: compliancescan.PartialReportConfigMapNamePrefix + string(complianceScan.UID) + "-" + shard,
+					},
+				})
+			}
+
+			mergeJobKey = client.ObjectKey{Name: compliancescan.JobNamePrefix + string(complianceScan.UID) + "-merge"}
+			mergeConfigKey = client.ObjectKey{Name: compliancescan.ConfigMapNamePrefix + string(complianceScan.UID) + "-merge"}
+			configMapList = &corev1.ConfigMapList{}
+			runningScanCond = dikiv1alpha1.Condition{
+				Type:               dikiv1alpha1.ConditionTypeCompleted,
+				Status:             dikiv1alpha1.ConditionFalse,
+				Reason:             compliancescan.ConditionReasonRunning,
+				Message:            "ComplianceScan is running",
+				LastTransitionTime: metav1.Now(),
+				LastUpdateTime:     metav1.Now(),
+			}
+		})
+
+		It("should create a Job and ConfigMap for every shard with a subset of the rulesets", func() {
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			for _, shardJob := range shardJobs {
+				job := &batchv1.Job{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shardJob), job)).To(Succeed())
+				Expect(job.Spec.Suspend).To(PointTo(BeFalse()))
+				Expect(job.Spec.Template.Spec.Containers).To(HaveLen(2))
+			}
+
+			Expect(fakeClient.List(ctx, configMapList,
+				client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": "1"},
+			)).To(Succeed())
+			Expect(configMapList.Items).To(HaveLen(2))
+
+			for i, configMap := range configMapList.Items {
+				Expect(configMap.Name).To(Equal(compliancescan.ConfigMapNamePrefix + "1-" + []string{"0", "1"}[i]))
+				Expect(configMap.Data["config.yaml"]).To(ContainSubstring(complianceScan.Spec.Rulesets[i].ID))
+				Expect(configMap.Data["config.yaml"]).NotTo(ContainSubstring(complianceScan.Spec.Rulesets[1-i].ID))
+				Expect(configMap.Data["exporter-config.yaml"]).To(Equal(`apiVersion: exporter.diki.gardener.cloud/v1alpha1
+complianceScanName: compliancescan
+kind: ReportExporterConfiguration
+outputs: null
+partialReport:
+  name: ` + partialReports[i].Name + `
+  namespace: ""
+reportPath: /report/report.json
+waitForReport: true
+`))
+			}
+		})
+
+		It("should split rulesets into ranges of rules when there are more shards than rulesets", func() {
+			complianceScan.Spec.Parallelism = ptr.To[int32](10)
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			Expect(fakeClient.List(ctx, configMapList,
+				client.MatchingLabels{"compliancescan.diki.gardener.cloud/uid": "1"},
+			)).To(Succeed())
+			// The rules of the unknown DISA version cannot be split, hence it is scanned by a single shard.
+			Expect(configMapList.Items).To(HaveLen(10))
+
+			configs := map[string]string{}
+			for _, configMap := range configMapList.Items {
+				configs[configMap.Name] = configMap.Data["config.yaml"]
+			}
+
+			Expect(configs[compliancescan.ConfigMapNamePrefix+"1-0"]).To(And(
+				ContainSubstring("id: disa-kubernetes-stig"),
+				Not(ContainSubstring("ruleOptions")),
+			))
+			Expect(configs[compliancescan.ConfigMapNamePrefix+"1-1"]).To(And(
+				ContainSubstring("id: security-hardened-k8s"),
+				Not(ContainSubstring(`ruleID: "2000"`)),
+				ContainSubstring(`ruleID: "2001"`),
+				ContainSubstring(`ruleID: "2008"`),
+				ContainSubstring("justification: The rule is evaluated by another shard"),
+			))
+			Expect(configs[compliancescan.ConfigMapNamePrefix+"1-9"]).To(And(
+				ContainSubstring(`ruleID: "2000"`),
+				ContainSubstring(`ruleID: "2007"`),
+				Not(ContainSubstring(`ruleID: "2008"`)),
+			))
+		})
+
+		It("should wait while the shards are running", func() {
+			complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanRunning
+			complianceScan.Status.Conditions = []dikiv1alpha1.Condition{runningScanCond}
+			shardJobs[0].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+			Expect(fakeClient.Create(ctx, shardJobs[0])).To(Succeed())
+			Expect(fakeClient.Create(ctx, shardJobs[1])).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			err = fakeClient.Get(ctx, mergeJobKey, &batchv1.Job{})
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+		})
+
+		It("should create the merge Job when all shards have completed", func() {
+			complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanRunning
+			complianceScan.Status.Conditions = []dikiv1alpha1.Condition{runningScanCond}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+			for _, shardJob := range shardJobs {
+				shardJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+				Expect(fakeClient.Create(ctx, shardJob)).To(Succeed())
+			}
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{RequeueAfter: syncPeriod}))
+
+			mergeJob := &batchv1.Job{}
+			Expect(fakeClient.Get(ctx, mergeJobKey, mergeJob)).To(Succeed())
+			Expect(mergeJob.Spec.Suspend).To(PointTo(BeFalse()))
+			Expect(mergeJob.Spec.Template.Spec.Containers).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal(compliancescan.ReportExporterContainerName)}),
+			))
+
+			mergeConfigMap := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, mergeConfigKey, mergeConfigMap)).To(Succeed())
+			Expect(mergeConfigMap.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("Job"),
+				"Name": Equal(mergeJob.Name),
+			})))
+			Expect(mergeConfigMap.Data).NotTo(HaveKey("config.yaml"))
+			Expect(mergeConfigMap.Data["exporter-config.yaml"]).To(Equal(`apiVersion: exporter.diki.gardener.cloud/v1alpha1
+complianceScanName: compliancescan
+kind: ReportExporterConfiguration
+outputs: null
+partialReports:
+  - name: diki-partial-report-1-0
+    namespace: ""
+  - name: diki-partial-report-1-1
+    namespace: ""
+`))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanRunning))
+		})
+
+		It("should set phase to Failed and delete the partial reports when a shard fails", func() {
+			complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanRunning
+			complianceScan.Status.Conditions = []dikiv1alpha1.Condition{runningScanCond}
+			shardJobs[0].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			shardJobs[1].Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+			Expect(fakeClient.Create(ctx, shardJobs[0])).To(Succeed())
+			Expect(fakeClient.Create(ctx, shardJobs[1])).To(Succeed())
+			Expect(fakeClient.Create(ctx, partialReports[0])).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+			Expect(complianceScan.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal(dikiv1alpha1.ConditionTypeFailed),
+				"Message": Equal("ComplianceScan failed with error: job diki-run-1-1 failed: BackoffLimitExceeded"),
+			})))
+
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(partialReports[0]), &corev1.ConfigMap{})
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+
+			err = fakeClient.Get(ctx, mergeJobKey, &batchv1.Job{})
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+		})
+
+		It("should set phase to Completed and delete the partial reports when the merge Job succeeds", func() {
+			complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanRunning
+			complianceScan.Status.Conditions = []dikiv1alpha1.Condition{runningScanCond}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+			for _, partialReport := range partialReports {
+				Expect(fakeClient.Create(ctx, partialReport)).To(Succeed())
+			}
+			Expect(fakeClient.Create(ctx, &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: mergeJobKey.Name},
+				Status: batchv1.JobStatus{
+					Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+				},
+			})).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanCompleted))
+
+			for _, partialReport := range partialReports {
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(partialReport), &corev1.ConfigMap{})
+				Expect(err).To(HaveOccurred())
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+			}
+		})
+	})
+
+	Describe("finalizer", func() {
+		BeforeEach(func() {
+			cr.Config.DikiRunner.Namespace = "kube-system"
+		})
+
+		It("should add the finalizer to the ComplianceScan", func() {
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: complianceScan.Name}, complianceScan)).To(Succeed())
+			Expect(complianceScan.Finalizers).To(ConsistOf(compliancescan.FinalizerName))
+		})
+
+		Describe("deletion", func() {
+			var (
+				scanLabels   map[string]string
+				job          *batchv1.Job
+				tokenSecret  *corev1.Secret
+				deleteOutput *dikiv1alpha1.ReportOutput
+				retainOutput *dikiv1alpha1.ReportOutput
+			)
+
+			newReportConfigMap := func(name, outputName string) *corev1.ConfigMap {
+				return &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: "reports",
+						Labels: map[string]string{
+							"app.kubernetes.io/managed-by":           "diki-operator",
+							"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID),
+							"reportoutput.diki.gardener.cloud/name":  outputName,
+						},
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				scanLabels = map[string]string{
+					"compliancescan.diki.gardener.cloud/uid": string(complianceScan.UID),
+				}
+				job = &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "diki-run-1",
+						Namespace: "kube-system",
+						Labels:    scanLabels,
+					},
+				}
+				tokenSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "diki-token-1",
+						Namespace: "kube-system",
+						Labels:    scanLabels,
+					},
+				}
+
+				deleteOutput = &dikiv1alpha1.ReportOutput{
+					ObjectMeta: metav1.ObjectMeta{Name: "delete-output"},
+					Spec: dikiv1alpha1.ReportOutputSpec{
+						Output: dikiv1alpha1.Output{
+							ConfigMap: &dikiv1alpha1.OutputConfigMap{Namespace: "reports"},
+						},
+					},
+				}
+				retainOutput = deleteOutput.DeepCopy()
+				retainOutput.Name = "retain-output"
+
+				complianceScan.Finalizers = []string{compliancescan.FinalizerName}
+				complianceScan.Spec.Outputs = []dikiv1alpha1.ReportOutputRef{
+					{Name: deleteOutput.Name, DeletionPolicy: dikiv1alpha1.DeletionPolicyDelete},
+					{Name: retainOutput.Name},
+				}
+				complianceScan.Status.Phase = dikiv1alpha1.ComplianceScanCompleted
+
+				Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+				Expect(fakeClient.Create(ctx, deleteOutput)).To(Succeed())
+				Expect(fakeClient.Create(ctx, retainOutput)).To(Succeed())
+				Expect(fakeClient.Create(ctx, job)).To(Succeed())
+				Expect(fakeClient.Create(ctx, tokenSecret)).To(Succeed())
+			})
+
+			It("should delete the resources of the ComplianceScan and remove the finalizer", func() {
+				deletedReport := newReportConfigMap("report-1", deleteOutput.Name)
+				retainedReport := newReportConfigMap("report-2", retainOutput.Name)
+				Expect(fakeClient.Create(ctx, deletedReport)).To(Succeed())
+				Expect(fakeClient.Create(ctx, retainedReport)).To(Succeed())
+
+				Expect(fakeClient.Delete(ctx, complianceScan)).To(Succeed())
+
+				res, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{}))
+
+				for _, obj := range []client.Object{job, tokenSecret, deletedReport, complianceScan} {
+					err = fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+					Expect(err).To(HaveOccurred())
+					Expect(client.IgnoreNotFound(err)).To(Succeed())
+				}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(retainedReport), retainedReport)).To(Succeed())
+			})
+
+			It("should remove the finalizer when a ReportOutput with deletion policy Delete does not exist", func() {
+				Expect(fakeClient.Delete(ctx, deleteOutput)).To(Succeed())
+				Expect(fakeClient.Delete(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)
+				Expect(err).To(HaveOccurred())
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+			})
+
+			It("should delete the artifacts of the ReportOutputs recorded in the snapshot", func() {
+				deletedReport := newReportConfigMap("report-1", deleteOutput.Name)
+				Expect(fakeClient.Create(ctx, deletedReport)).To(Succeed())
+
+				complianceScan.Status.Snapshot = &dikiv1alpha1.ComplianceScanSnapshot{
+					Outputs: []dikiv1alpha1.OutputSnapshot{
+						{Name: deleteOutput.Name, Output: deleteOutput.Spec.Output},
+						{Name: retainOutput.Name, Output: retainOutput.Spec.Output},
+					},
+				}
+				Expect(fakeClient.Status().Update(ctx, complianceScan)).To(Succeed())
+
+				deleteOutput.Spec.Output.ConfigMap.Namespace = "other"
+				Expect(fakeClient.Update(ctx, deleteOutput)).To(Succeed())
+				Expect(fakeClient.Delete(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, obj := range []client.Object{deletedReport, complianceScan} {
+					err = fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+					Expect(err).To(HaveOccurred())
+					Expect(client.IgnoreNotFound(err)).To(Succeed())
+				}
+			})
+
+			It("should delete the ServiceAccount of a ComplianceScan restricted to a namespace", func() {
+				serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+					Name:      compliancescan.NamespaceScanNamePrefix + string(complianceScan.UID),
+					Namespace: cr.Config.DikiRunner.Namespace,
+				}}
+				Expect(fakeClient.Create(ctx, serviceAccount)).To(Succeed())
+
+				complianceScan.Spec.TargetNamespace = "tenant"
+				Expect(fakeClient.Update(ctx, complianceScan)).To(Succeed())
+				Expect(fakeClient.Delete(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, obj := range []client.Object{serviceAccount, complianceScan} {
+					err = fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+					Expect(err).To(HaveOccurred())
+					Expect(client.IgnoreNotFound(err)).To(Succeed())
+				}
+			})
+
+			It("should keep the finalizer when the resources cannot be deleted", func() {
+				cr.SourceClient = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Delete: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.DeleteOption) error {
+							return errors.New("fake error")
+						},
+					}).
+					WithObjects(job.DeepCopy()).
+					Build()
+				Expect(fakeClient.Delete(ctx, complianceScan)).To(Succeed())
+
+				_, err := cr.Reconcile(ctx, request)
+				Expect(err).To(MatchError(ContainSubstring("fake error")))
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(complianceScan), complianceScan)).To(Succeed())
+				Expect(complianceScan.Finalizers).To(ConsistOf(compliancescan.FinalizerName))
+			})
+		})
+	})
 })
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/securityhardenedk8s"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// NamespaceScopedRulesets contains the IDs of the rulesets which can be run by compliance scans restricted to a
+// namespace. The diki config has no option to restrict a ruleset to a namespace and the rules of the managedk8s
+// rulesets list the resources of all namespaces, hence none of them can be run restricted to a namespace.
+var NamespaceScopedRulesets = sets.New[string]()
+
+var disaK8sSTIGRuleIDs = []string{
+	"242376", "242377", "242378", "242379", "242380", "242381", "242382", "242383", "242384", "242385",
+	"242387", "242389", "242390", "242391", "242392", "242393", "242394", "242395", "242396", "242397",
+	"242398", "242399", "242400", "242402", "242403", "242404", "242405", "242406", "242407", "242408",
+	"242409", "242410", "242411", "242412", "242413", "242414", "242415", "242417", "242418", "242419",
+	"242420", "242421", "242422", "242423", "242424", "242425", "242426", "242427", "242428", "242429",
+	"242430", "242431", "242432", "242433", "242434", "242436", "242437", "242438", "242442", "242443",
+	"242444", "242445", "242446", "242447", "242448", "242449", "242450", "242451", "242452", "242453",
+	"242454", "242455", "242456", "242457", "242459", "242460", "242461", "242462", "242463", "242464",
+	"242465", "242466", "242467", "245541", "245542", "245543", "245544", "254800", "254801", "274882",
+	"274883", "274884",
+}
+
+// rulesetRuleIDs contains the sorted IDs of the rules of the supported ruleset versions by ruleset ID and version.
+// They are used to split a ruleset into ranges of rules which are scanned by different shards. Ruleset versions
+// which are not listed are always scanned by a single shard.
+var rulesetRuleIDs = map[string]map[string][]string{
+	disak8sstig.RulesetID: {
+		"v2r5": disaK8sSTIGRuleIDs,
+		"v2r6": disaK8sSTIGRuleIDs,
+	},
+	securityhardenedk8s.RulesetID: {
+		"v0.1.0": {"2000", "2001", "2002", "2003", "2004", "2005", "2006", "2007", "2008"},
+	},
+}
+
+// getRuleIDs returns the IDs of the rules of the given ruleset version or nil if they are not known.
+func getRuleIDs(rulesetID, version string) []string {
+	return rulesetRuleIDs[rulesetID][version]
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+	reportexporterv1alpha1 "github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
+)
+
+// shardRuleset is a ruleset which is scanned by a shard. If skippedRuleIDs is set, the ruleset is split across
+// several shards and the shard skips the given rules because they are scanned by other shards.
+type shardRuleset struct {
+	v1alpha1.RulesetConfig
+	skippedRuleIDs []string
+}
+
+// getNumShards returns the number of diki-run Jobs which scan the rulesets of the compliance scan.
+// There are never more shards than rulesets, unless the rules of the rulesets are known and can be split.
+func getNumShards(complianceScan *v1alpha1.ComplianceScan) int {
+	if complianceScan.Spec.Parallelism == nil {
+		return 1
+	}
+
+	maxShards := 0
+	for _, ruleset := range complianceScan.Spec.Rulesets {
+		maxShards += max(1, len(getRuleIDs(ruleset.ID, ruleset.Version)))
+	}
+
+	return max(1, min(int(*complianceScan.Spec.Parallelism), maxShards))
+}
+
+// getRulesetShards distributes the rulesets of the compliance scan across its shards. If there are at most as many
+// shards as rulesets, the rulesets are distributed round-robin. Otherwise, the remaining shards are assigned to the
+// rulesets with the most rules per shard and each ruleset is split into consecutive ranges of its rule IDs.
+func getRulesetShards(complianceScan *v1alpha1.ComplianceScan) [][]shardRuleset {
+	var (
+		rulesets = complianceScan.Spec.Rulesets
+		shards   = make([][]shardRuleset, getNumShards(complianceScan))
+	)
+
+	if len(shards) <= len(rulesets) {
+		for i, ruleset := range rulesets {
+			shards[i%len(shards)] = append(shards[i%len(shards)], shardRuleset{RulesetConfig: ruleset})
+		}
+		return shards
+	}
+
+	ruleIDs := make([][]string, len(rulesets))
+	parts := make([]int, len(rulesets))
+	for i, ruleset := range rulesets {
+		ruleIDs[i] = getRuleIDs(ruleset.ID, ruleset.Version)
+		parts[i] = 1
+	}
+
+	for range len(shards) - len(rulesets) {
+		next := -1
+		for i := range rulesets {
+			if parts[i] >= len(ruleIDs[i]) {
+				continue
+			}
+			// Compare len(ruleIDs[i])/parts[i] > len(ruleIDs[next])/parts[next] without rounding.
+			if next < 0 || len(ruleIDs[i])*parts[next] > len(ruleIDs[next])*parts[i] {
+				next = i
+			}
+		}
+		parts[next]++
+	}
+
+	shard := 0
+	for i, ruleset := range rulesets {
+		if parts[i] == 1 {
+			shards[shard] = []shardRuleset{{RulesetConfig: ruleset}}
+			shard++
+			continue
+		}
+
+		for _, ruleRange := range splitRuleIDs(ruleIDs[i], parts[i]) {
+			skippedRuleIDs := make([]string, 0, len(ruleIDs[i])-len(ruleRange))
+			for _, ruleID := range ruleIDs[i] {
+				if !slices.Contains(ruleRange, ruleID) {
+					skippedRuleIDs = append(skippedRuleIDs, ruleID)
+				}
+			}
+
+			shards[shard] = []shardRuleset{{RulesetConfig: ruleset, skippedRuleIDs: skippedRuleIDs}}
+			shard++
+		}
+	}
+
+	return shards
+}
+
+// splitRuleIDs splits the given rule IDs into the given number of consecutive ranges whose sizes differ by at most one.
+func splitRuleIDs(ruleIDs []string, parts int) [][]string {
+	ranges := make([][]string, 0, parts)
+	start := 0
+	for i := range parts {
+		end := start + len(ruleIDs)/parts
+		if i < len(ruleIDs)%parts {
+			end++
+		}
+		ranges = append(ranges, ruleIDs[start:end])
+		start = end
+	}
+
+	return ranges
+}
+
+func shardResourceName(prefix string, complianceScanUID types.UID, suffix string) string {
+	return fmt.Sprintf("%s%s-%s", prefix, complianceScanUID, suffix)
+}
+
+func partialReportConfigMapName(complianceScanUID types.UID, shard int) string {
+	return shardResourceName(PartialReportConfigMapNamePrefix, complianceScanUID, strconv.Itoa(shard))
+}
+
+// deployShards deploys a diki-run Job for every shard of the compliance scan. Instead of exporting
+// the report to the outputs, each Job stores its partial report in a ConfigMap.
+func (r *Reconciler) deployShards(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, exporterConfig *reportexporterv1alpha1.ReportExporterConfiguration, log logr.Logger) error {
+	for i, rulesets := range getRulesetShards(complianceScan) {
+		shardExporterConfig := exporterConfig.DeepCopy()
+		shardExporterConfig.Outputs = nil
+		shardExporterConfig.PartialReport = &reportexporterv1alpha1.ConfigMapReference{
+			Name:      partialReportConfigMapName(complianceScan.UID, i),
+			Namespace: dikiRunner.Namespace,
+		}
+
+		if err := r.deployDikiRun(ctx, complianceScan, rulesets, dikiRunner,
+			shardResourceName(JobNamePrefix, complianceScan.UID, strconv.Itoa(i)),
+			shardResourceName(ConfigMapNamePrefix, complianceScan.UID, strconv.Itoa(i)),
+			shardExporterConfig, i == 0, log,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileShards checks the diki-run Jobs of a sharded compliance scan and deploys the merge Job once all of them
+// have completed. It returns the merge Job if it is already deployed, otherwise nil.
+func (r *Reconciler) reconcileShards(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, log logr.Logger) (*batchv1.Job, error) {
+	mergeJobName := shardResourceName(JobNamePrefix, complianceScan.UID, MergeJobNameSuffix)
+
+	mergeJob := &batchv1.Job{}
+	if err := r.SourceClient.Get(ctx, client.ObjectKey{Name: mergeJobName, Namespace: dikiRunner.Namespace}, mergeJob); err == nil {
+		return mergeJob, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get merge job: %w", err)
+	}
+
+	numShards := getNumShards(complianceScan)
+	completed := 0
+	for i := range numShards {
+		job, err := r.findDikiRunJob(ctx, shardResourceName(JobNamePrefix, complianceScan.UID, strconv.Itoa(i)), dikiRunner)
+		if err != nil {
+			return nil, err
+		}
+
+		if job.Spec.Suspend != nil && *job.Spec.Suspend {
+			return nil, fmt.Errorf("job %s is unexpectedly suspended", job.Name)
+		}
+
+		if condition := getJobCondition(job, batchv1.JobFailed); condition != nil {
+			return nil, r.newJobFailedError(ctx, complianceScan, job, fmt.Errorf("job %s failed: %s", job.Name, condition.Message), log)
+		}
+
+		if getJobCondition(job, batchv1.JobComplete) != nil {
+			completed++
+		}
+	}
+
+	if completed < numShards {
+		log.Info("Waiting for shards to complete", "completed", completed, "shards", numShards)
+		return nil, nil
+	}
+
+	exporterConfig, err := buildExporterConfig(complianceScan, dikiRunner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build exporter config: %w", err)
+	}
+	exporterConfig.ReportPath = ""
+	exporterConfig.WaitForReport = false
+	for i := range numShards {
+		exporterConfig.PartialReports = append(exporterConfig.PartialReports, reportexporterv1alpha1.ConfigMapReference{
+			Name:      partialReportConfigMapName(complianceScan.UID, i),
+			Namespace: dikiRunner.Namespace,
+		})
+	}
+
+	mergeJob, err = r.deployMergeJob(ctx, complianceScan, dikiRunner, mergeJobName, shardResourceName(ConfigMapNamePrefix, complianceScan.UID, MergeJobNameSuffix))
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Created merge Job successfully", "job", mergeJob.Name, "namespace", mergeJob.Namespace)
+
+	configMap, err := r.deployMergeConfigMap(ctx, shardResourceName(ConfigMapNamePrefix, complianceScan.UID, MergeJobNameSuffix), complianceScan, dikiRunner, mergeJob, exporterConfig)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Created ConfigMap successfully", "configMap", configMap.Name, "namespace", configMap.Namespace)
+
+	if err := r.startDikiRunJob(ctx, mergeJob); err != nil {
+		return nil, fmt.Errorf("failed to start merge job: %w", err)
+	}
+	log.Info("Started merge Job successfully", "job", mergeJob.Name, "namespace", mergeJob.Namespace)
+
+	return nil, nil
+}
+
+// cleanupPartialReports deletes the ConfigMaps containing the partial reports of a sharded compliance scan once they are no longer needed.
+// Failures are only logged because the partial reports are owned by the ComplianceScan and are garbage collected together with it.
+func (r *Reconciler) cleanupPartialReports(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, namespace string, log logr.Logger) {
+	for i := range getNumShards(complianceScan) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      partialReportConfigMapName(complianceScan.UID, i),
+				Namespace: namespace,
+			},
+		}
+
+		if err := r.Client.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to delete partial report ConfigMap", "configMap", configMap.Name, "namespace", configMap.Namespace)
+		}
+	}
+}
+
+func getJobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return &condition
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// buildSnapshot resolves the images, the ruleset options and the ReportOutputs of the compliance scan,
+// so that the configuration a compliance scan was run with is known after it has been changed.
+func (r *Reconciler) buildSnapshot(ctx context.Context, complianceScan *v1alpha1.ComplianceScan) (*v1alpha1.ComplianceScanSnapshot, error) {
+	dikiImage, reportExporterImage, err := resolveImages(complianceScan)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &v1alpha1.ComplianceScanSnapshot{
+		Images: v1alpha1.SnapshotImages{
+			Diki:           dikiImage,
+			ReportExporter: reportExporterImage,
+		},
+	}
+
+	for _, ruleset := range complianceScan.Spec.Rulesets {
+		rulesetSnapshot := v1alpha1.RulesetSnapshot{
+			ID:      ruleset.ID,
+			Version: ruleset.Version,
+		}
+
+		if options := ruleset.Options; options != nil {
+			if options.Ruleset != nil && options.Ruleset.ConfigMapRef != nil {
+				if rulesetSnapshot.RulesetOptions, err = r.snapshotOptions(ctx, *options.Ruleset.ConfigMapRef, ruleset.ID); err != nil {
+					return nil, fmt.Errorf("failed to snapshot ruleset options of ruleset %s: %w", ruleset.ID, err)
+				}
+			}
+			if options.Rules != nil && options.Rules.ConfigMapRef != nil {
+				if rulesetSnapshot.RuleOptions, err = r.snapshotOptions(ctx, *options.Rules.ConfigMapRef, ruleset.ID+RuleOptionsSuffix); err != nil {
+					return nil, fmt.Errorf("failed to snapshot rule options of ruleset %s: %w", ruleset.ID, err)
+				}
+			}
+		}
+
+		snapshot.Rulesets = append(snapshot.Rulesets, rulesetSnapshot)
+	}
+
+	for _, outputRef := range complianceScan.Spec.Outputs {
+		reportOutput := &v1alpha1.ReportOutput{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: outputRef.Name}, reportOutput); err != nil {
+			return nil, fmt.Errorf("failed to get ReportOutput %q: %w", outputRef.Name, err)
+		}
+
+		snapshot.Outputs = append(snapshot.Outputs, v1alpha1.OutputSnapshot{
+			Name:   reportOutput.Name,
+			Output: reportOutput.Spec.Output,
+		})
+	}
+
+	return snapshot, nil
+}
+
+// snapshotOptions records the referenced options together with the resource version of the referenced ConfigMap
+// and their hash.
+func (r *Reconciler) snapshotOptions(ctx context.Context, configMapRef v1alpha1.OptionsConfigMapRef, defaultKey string) (*v1alpha1.OptionsSnapshot, error) {
+	if configMapRef.Key == nil {
+		configMapRef.Key = ptr.To(defaultKey)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: configMapRef.Name, Namespace: configMapRef.Namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get configMap %s/%s: %w", configMapRef.Namespace, configMapRef.Name, err)
+	}
+
+	options, ok := configMap.Data[*configMapRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("key '%s' does not exist in configMap %s", *configMapRef.Key, client.ObjectKeyFromObject(configMap))
+	}
+
+	return &v1alpha1.OptionsSnapshot{
+		ConfigMapRef:    configMapRef,
+		ResourceVersion: configMap.ResourceVersion,
+		Hash:            utils.ComputeSHA256Hex([]byte(options)),
+		Data:            options,
+	}, nil
+}
+
+// patchSnapshot records the given snapshot in the status of the compliance scan.
+func (r *Reconciler) patchSnapshot(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, snapshot *v1alpha1.ComplianceScanSnapshot, log logr.Logger) error {
+	patch := client.MergeFrom(complianceScan.DeepCopy())
+	complianceScan.Status.Snapshot = snapshot
+
+	if err := r.Client.Status().Patch(ctx, complianceScan, patch); err != nil {
+		return fmt.Errorf("failed to update ComplianceScan snapshot: %w", err)
+	}
+
+	log.Info("Recorded ComplianceScan snapshot")
+
+	return nil
+}
+
+// getRulesetSnapshot returns the snapshot of the ruleset with the given ID or nil if the compliance scan has no
+// snapshot of it.
+func getRulesetSnapshot(complianceScan *v1alpha1.ComplianceScan, rulesetID string) *v1alpha1.RulesetSnapshot {
+	if complianceScan.Status.Snapshot == nil {
+		return nil
+	}
+
+	for _, rulesetSnapshot := range complianceScan.Status.Snapshot.Rulesets {
+		if rulesetSnapshot.ID == rulesetID {
+			return &rulesetSnapshot
+		}
+	}
+
+	return nil
+}
+
+// getSnapshotReportOutput returns the ReportOutput with the given name as it was recorded in the snapshot of the
+// compliance scan. The live ReportOutput is returned for compliance scans without a snapshot.
+func (r *Reconciler) getSnapshotReportOutput(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, name string) (*v1alpha1.ReportOutput, error) {
+	reportOutput := &v1alpha1.ReportOutput{}
+
+	if snapshot := complianceScan.Status.Snapshot; snapshot != nil {
+		for _, output := range snapshot.Outputs {
+			if output.Name == name {
+				reportOutput.Name = output.Name
+				reportOutput.Spec.Output = output.Output
+				return reportOutput, nil
+			}
+		}
+	}
+
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, reportOutput); err != nil {
+		return nil, err
+	}
+
+	return reportOutput, nil
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// deployTokenSecret requests a short-lived token for the configured ServiceAccount via the TokenRequest API
+// and stores it in a Secret which is owned by the diki-run Job.
+func (r *Reconciler) deployTokenSecret(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig, job *batchv1.Job) (*corev1.Secret, error) {
+	tokenRequestConfig := dikiRunner.TargetKubeconfig.TokenRequest
+
+	tokenClient := r.Client
+	if tokenRequestConfig.ManagementKubeconfigSecretRef != nil {
+		managementClient, err := r.newManagementClient(ctx, dikiRunner.Namespace, tokenRequestConfig.ManagementKubeconfigSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		tokenClient = managementClient
+	}
+
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tokenRequestConfig.ServiceAccount.Name,
+			Namespace: tokenRequestConfig.ServiceAccount.Namespace,
+		},
+	}
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         tokenRequestConfig.Audiences,
+			ExpirationSeconds: tokenRequestConfig.ExpirationSeconds,
+		},
+	}
+
+	if err := tokenClient.SubResource("token").Create(ctx, serviceAccount, tokenRequest); err != nil {
+		return nil, fmt.Errorf("failed to request token for service account %s: %w", client.ObjectKeyFromObject(serviceAccount), err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            TokenSecretNamePrefix + string(complianceScan.UID),
+			Namespace:       dikiRunner.Namespace,
+			Labels:          r.getLabels(complianceScan, dikiRunner),
+			OwnerReferences: r.getOwnerReference(job),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			TokenSecretKey: []byte(tokenRequest.Status.Token),
+		},
+	}
+
+	if err := r.SourceClient.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create token secret: %w", err)
+	}
+
+	return secret, nil
+}
+
+// newManagementClient creates a client for the target cluster from the kubeconfig stored in the referenced Secret.
+func (r *Reconciler) newManagementClient(ctx context.Context, namespace string, secretRef *configv1alpha1.SecretRef) (client.Client, error) {
+	secret := &corev1.Secret{}
+	if err := r.SourceClient.Get(ctx, client.ObjectKey{Name: secretRef.Name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get management kubeconfig secret: %w", err)
+	}
+
+	kubeconfigKey := KubeconfigSecretKey
+	if secretRef.Key != nil {
+		kubeconfigKey = *secretRef.Key
+	}
+
+	kubeconfig, ok := secret.Data[kubeconfigKey]
+	if !ok {
+		return nil, fmt.Errorf("management kubeconfig secret %s does not contain key %q", client.ObjectKeyFromObject(secret), kubeconfigKey)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse management kubeconfig: %w", err)
+	}
+
+	managementClient, err := client.New(restConfig, client.Options{Scheme: r.Client.Scheme()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create management client: %w", err)
+	}
+
+	return managementClient, nil
+}
@@ -6,26 +6,33 @@ package reconciler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/diki-operator/internal/constants"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 	v1alpha1helper "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1/helper"
+	reportexporterv1alpha1 "github.com/gardener/diki-operator/pkg/apis/reportexporter/v1alpha1"
 )
 
 func (r *Reconciler) patchRunning(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, log logr.Logger) error {
 	patch := client.MergeFrom(complianceScan.DeepCopy())
 	complianceScan.Status.Phase = v1alpha1.ComplianceScanRunning
+	complianceScan.Status.QueuePosition = nil
 	complianceScan.Status.Conditions = v1alpha1helper.UpdateConditions(
 		complianceScan.Status.Conditions,
 		v1alpha1.ConditionTypeCompleted,
@@ -66,13 +73,17 @@ func (r *Reconciler) patchCompleted(ctx context.Context, complianceScan *v1alpha
 }
 
 func (r *Reconciler) patchFailed(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, log logr.Logger, err error) error {
+	return r.patchFailedWithReason(ctx, complianceScan, log, ConditionReasonFailed, err)
+}
+
+func (r *Reconciler) patchFailedWithReason(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, log logr.Logger, reason string, err error) error {
 	patch := client.MergeFrom(complianceScan.DeepCopy())
 	complianceScan.Status.Phase = v1alpha1.ComplianceScanFailed
 	complianceScan.Status.Conditions = v1alpha1helper.UpdateConditions(
 		complianceScan.Status.Conditions,
 		v1alpha1.ConditionTypeFailed,
 		v1alpha1.ConditionTrue,
-		ConditionReasonFailed,
+		reason,
 		fmt.Sprintf("ComplianceScan failed with error: %s", err.Error()),
 		time.Now(),
 	)
@@ -85,28 +96,29 @@ func (r *Reconciler) patchFailed(ctx context.Context, complianceScan *v1alpha1.C
 	}
 
 	log.Info("Updated ComplianceScan phase to Failed", "error", err.Error())
+	r.Recorder.Eventf(complianceScan, nil, corev1.EventTypeWarning, reason, EventActionScan, "ComplianceScan failed with error: %s", err.Error())
 
 	return nil
 }
 
-func (r *Reconciler) getLabels(complianceScan *v1alpha1.ComplianceScan) map[string]string {
+func (r *Reconciler) getLabels(complianceScan *v1alpha1.ComplianceScan, dikiRunner *configv1alpha1.DikiRunnerConfig) map[string]string {
 	labels := map[string]string{
 		constants.LabelAppName:      constants.LabelValueDiki,
 		constants.LabelAppManagedBy: constants.LabelValueDikiOperator,
 	}
 
-	maps.Copy(labels, r.Config.DikiRunner.Labels)
+	maps.Copy(labels, dikiRunner.Labels)
 	labels[constants.LabelComplianceScanName] = complianceScan.Name
 	labels[constants.LabelComplianceScanUID] = string(complianceScan.UID)
 
 	return labels
 }
 
-func (r *Reconciler) findDikiRunJob(ctx context.Context, complianceScanUID types.UID) (*batchv1.Job, error) {
+func (r *Reconciler) findDikiRunJob(ctx context.Context, jobName string, dikiRunner *configv1alpha1.DikiRunnerConfig) (*batchv1.Job, error) {
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      JobNamePrefix + string(complianceScanUID),
-			Namespace: r.Config.DikiRunner.Namespace,
+			Name:      jobName,
+			Namespace: dikiRunner.Namespace,
 		},
 	}
 
@@ -136,6 +148,177 @@ func (r *Reconciler) startDikiRunJob(ctx context.Context, job *batchv1.Job) erro
 	return r.SourceClient.Patch(ctx, job, jobPatch)
 }
 
+func (r *Reconciler) patchImages(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, job *batchv1.Job, log logr.Logger) error {
+	pods, err := r.listDikiRunPods(ctx, complianceScan.UID, job.Namespace)
+	if err != nil {
+		return err
+	}
+
+	images := getImageStatuses(pods)
+	if len(images) == 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(complianceScan.DeepCopy())
+	complianceScan.Status.Images = images
+
+	if err := r.Client.Status().Patch(ctx, complianceScan, patch); err != nil {
+		return fmt.Errorf("failed to update ComplianceScan images: %w", err)
+	}
+
+	log.Info("Updated ComplianceScan images")
+
+	return nil
+}
+
+func (r *Reconciler) listDikiRunPods(ctx context.Context, complianceScanUID types.UID, namespace string) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.SourceClient.List(ctx, podList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{constants.LabelComplianceScanUID: string(complianceScanUID)},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list diki runner pods: %w", err)
+	}
+
+	return podList.Items, nil
+}
+
+// getImageStatuses returns the images used by the containers of the given pods.
+// The digest is taken from the image ID reported by the container runtime.
+func getImageStatuses(pods []corev1.Pod) []v1alpha1.ImageStatus {
+	var images []v1alpha1.ImageStatus
+	for _, pod := range pods {
+		for _, containerStatus := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+			if containerStatus.ImageID == "" || slices.ContainsFunc(images, func(image v1alpha1.ImageStatus) bool {
+				return image.Container == containerStatus.Name
+			}) {
+				continue
+			}
+
+			digest := containerStatus.ImageID
+			if _, after, ok := strings.Cut(digest, "@"); ok {
+				digest = after
+			}
+
+			images = append(images, v1alpha1.ImageStatus{
+				Container: containerStatus.Name,
+				Image:     containerStatus.Image,
+				Digest:    digest,
+			})
+		}
+	}
+
+	slices.SortFunc(images, func(a, b v1alpha1.ImageStatus) int {
+		return strings.Compare(a.Container, b.Container)
+	})
+
+	return images
+}
+
+// getContainerFailures returns descriptions of the containers of the given pods which terminated with a non-zero exit code.
+// The description contains the last lines of the termination message, which is either the structured message written
+// by the report-exporter or the tail of the container logs.
+func getContainerFailures(pods []corev1.Pod) []string {
+	var failures []string
+	for _, pod := range pods {
+		for _, containerStatus := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+			terminated := containerStatus.State.Terminated
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+
+			failure := fmt.Sprintf("container %s exited with code %d", containerStatus.Name, terminated.ExitCode)
+			if terminated.Reason != "" {
+				failure = fmt.Sprintf("%s (%s)", failure, terminated.Reason)
+			}
+			if message := getTerminationMessage(terminated.Message); message != "" {
+				failure = fmt.Sprintf("%s: %s", failure, message)
+			}
+
+			failures = append(failures, failure)
+		}
+	}
+
+	return failures
+}
+
+// jobFailedError is returned when a diki-run Job has failed. It carries the condition reason
+// which tells which of the containers has failed.
+type jobFailedError struct {
+	reason string
+	err    error
+}
+
+func (e *jobFailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *jobFailedError) Unwrap() error {
+	return e.err
+}
+
+// newJobFailedError enriches the given error with the failures of the containers of the failed Job.
+func (r *Reconciler) newJobFailedError(ctx context.Context, complianceScan *v1alpha1.ComplianceScan, job *batchv1.Job, jobErr error, log logr.Logger) *jobFailedError {
+	pods, err := r.listDikiRunPods(ctx, complianceScan.UID, job.Namespace)
+	if err != nil {
+		log.Error(err, "Failed to list diki runner pods for failure diagnostics", "job", job.Name, "namespace", job.Namespace)
+		return &jobFailedError{reason: ConditionReasonFailed, err: jobErr}
+	}
+
+	if containerFailures := getContainerFailures(pods); len(containerFailures) > 0 {
+		jobErr = fmt.Errorf("%w; %s", jobErr, strings.Join(containerFailures, "; "))
+	}
+
+	return &jobFailedError{reason: getFailureReason(pods), err: jobErr}
+}
+
+// getFailureReasonFromError returns the condition reason carried by the given error.
+func getFailureReasonFromError(err error) string {
+	var jobErr *jobFailedError
+	if errors.As(err, &jobErr) {
+		return jobErr.reason
+	}
+
+	return ConditionReasonFailed
+}
+
+// getFailureReason returns the condition reason for a failed diki-run Job based on which of its containers failed.
+// A failure of the diki-scan container takes precedence as it usually causes the report-exporter to fail as well.
+func getFailureReason(pods []corev1.Pod) string {
+	reason := ConditionReasonFailed
+	for _, pod := range pods {
+		for _, containerStatus := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+			terminated := containerStatus.State.Terminated
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+
+			switch containerStatus.Name {
+			case DikiScanContainerName:
+				return ConditionReasonScannerFailed
+			case ReportExporterContainerName:
+				reason = ConditionReasonExporterFailed
+			}
+		}
+	}
+
+	return reason
+}
+
+func getTerminationMessage(message string) string {
+	terminationMessage := &reportexporterv1alpha1.TerminationMessage{}
+	if err := json.Unmarshal([]byte(message), terminationMessage); err == nil && terminationMessage.Error != "" {
+		return terminationMessage.Error
+	}
+
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	if len(lines) > maxTerminationMessageLines {
+		lines = lines[len(lines)-maxTerminationMessageLines:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func getFailedOutputs(complianceScan *v1alpha1.ComplianceScan) []string {
 	var failed []string
 	for _, output := range complianceScan.Status.Outputs {
@@ -5,24 +5,18 @@
 package reconciler
 
 import (
-	"time"
-
-	"github.com/gardener/gardener/pkg/controllerutils"
+	gardenercontrollerutils "github.com/gardener/gardener/pkg/controllerutils"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
-)
 
-const (
-	// ControllerName is the name of the garbagecollector controller.
-	ControllerName = "garbagecollector"
-	// ReconciliationTimeout is the timeout passed to the context of the Reconcile call.
-	ReconciliationTimeout = 5 * time.Minute
-	// MaxConcurrentReconciles is the maximum number of concurrent Reconcile calls.
-	MaxConcurrentReconciles = 1
+	"github.com/gardener/diki-operator/internal/controllerutils"
 )
 
-// SetupWithManager specifies how the controller is built to periodically clean up Jobs.
+// ControllerName is the name of the garbagecollector controller.
+const ControllerName = "garbagecollector"
+
+// SetupWithManager specifies how the controller is built to periodically clean up Jobs and report ConfigMaps.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Client == nil {
 		r.Client = mgr.GetClient()
@@ -30,13 +24,13 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.SourceClient == nil {
 		r.SourceClient = mgr.GetClient()
 	}
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
 
 	return builder.ControllerManagedBy(mgr).
 		Named(ControllerName).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: MaxConcurrentReconciles,
-			ReconciliationTimeout:   ReconciliationTimeout,
-		}).
-		WatchesRawSource(controllerutils.EnqueueOnce).
+		WithOptions(controllerutils.Options(r.Config.ControllerOptions)).
+		WatchesRawSource(gardenercontrollerutils.EnqueueOnce).
 		Complete(r)
 }
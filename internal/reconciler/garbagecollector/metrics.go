@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// reasonOrphaned is the reason for deleting resources linked to a ComplianceScan which no longer exists or is finished.
+	reasonOrphaned = "orphaned"
+	// reasonExpired is the reason for deleting finished ComplianceScans whose time to live has expired.
+	reasonExpired = "expired"
+	// reasonRetention is the reason for deleting report ConfigMaps exceeding the retention settings of their ReportOutput.
+	reasonRetention = "retention"
+)
+
+// DeletedResourcesTotal counts the resources deleted by the garbage collector by kind and reason.
+var DeletedResourcesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "diki_operator",
+		Subsystem: "garbage_collector",
+		Name:      "deleted_resources_total",
+		Help:      "Total number of resources deleted by the garbage collector, partitioned by kind and reason.",
+	},
+	[]string{"kind", "reason"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(DeletedResourcesTotal)
+}
+
+// deletionSummary counts the resources deleted during a single garbage collection by kind and reason.
+type deletionSummary map[string]int
+
+func (d deletionSummary) record(kind, reason string) {
+	d[kind+"/"+reason]++
+	DeletedResourcesTotal.WithLabelValues(kind, reason).Inc()
+}
+
+func (d deletionSummary) log(log logr.Logger) {
+	if len(d) == 0 {
+		return
+	}
+	log.Info("Garbage collection deleted resources", "deleted", map[string]int(d))
+}
@@ -7,35 +7,99 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/gardener/diki-operator/internal/constants"
+	compliancescan "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
 // Config holds configuration for the garbagecollector controller.
 type Config struct {
-	Namespace       string
+	// ControllerOptions are the tuning options of the controller.
+	ControllerOptions configv1alpha1.ControllerOptions
+	// Namespaces are the namespaces in which diki-run Jobs are created.
+	Namespaces []string
+	// RequeueInterval is the interval in which the garbage collection is performed.
 	RequeueInterval time.Duration
+	// ComplianceScanTTLSecondsAfterFinished is the default time to live of finished ComplianceScans
+	// which are not created by a ScheduledComplianceScan.
+	ComplianceScanTTLSecondsAfterFinished *int32
 }
 
-// Reconciler periodically cleans up diki-run Jobs that are no longer needed.
+// Reconciler periodically cleans up diki-run Jobs, their artifacts, expired ComplianceScans and report ConfigMaps that are no longer needed.
 type Reconciler struct {
 	Client       client.Client
 	SourceClient client.Client
+	Clock        clock.Clock
 	Config       Config
 }
 
-// Reconcile lists all diki-run Jobs and deletes those linked to a ComplianceScan
+// orphanedResource describes a kind of resource which is created for a ComplianceScan in the diki runner namespaces.
+type orphanedResource struct {
+	kind    string
+	newList func() client.ObjectList
+	// source is true if the resources reside in the cluster of the diki-run Jobs, otherwise in the cluster of the ComplianceScans.
+	source bool
+	// namePrefix restricts the resources to the ones with the given name prefix. It is required for ConfigMaps
+	// because the reports exported to the outputs carry the same labels and might reside in the same namespaces.
+	namePrefix string
+	deleteOpts []client.DeleteOption
+}
+
+var orphanedResources = []orphanedResource{
+	{
+		kind:       "Job",
+		newList:    func() client.ObjectList { return &batchv1.JobList{} },
+		source:     true,
+		deleteOpts: []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationBackground)},
+	},
+	{
+		kind:    "Secret",
+		newList: func() client.ObjectList { return &corev1.SecretList{} },
+		source:  true,
+	},
+	{
+		// The diki config ConfigMaps are owned by their Job, but they are left behind when the Job could not be created
+		// or when the owner reference is not honoured.
+		kind:       "ConfigMap",
+		newList:    func() client.ObjectList { return &corev1.ConfigMapList{} },
+		source:     true,
+		namePrefix: compliancescan.ConfigMapNamePrefix,
+	},
+	{
+		kind:    "NetworkPolicy",
+		newList: func() client.ObjectList { return &networkingv1.NetworkPolicyList{} },
+		source:  true,
+	},
+	{
+		// The partial reports of sharded ComplianceScans are stored in the cluster of the ComplianceScans.
+		kind:       "ConfigMap",
+		newList:    func() client.ObjectList { return &corev1.ConfigMapList{} },
+		namePrefix: compliancescan.PartialReportConfigMapNamePrefix,
+	},
+}
+
+// Reconcile lists all resources created for ComplianceScans in the diki runner namespaces, i.e. diki-run Jobs,
+// token Secrets, diki config ConfigMaps, NetworkPolicies and partial reports, and deletes those linked to a ComplianceScan
 // that no longer exists or is in a terminal state (Completed/Failed).
+// Afterwards, it deletes the finished ComplianceScans whose time to live has expired and prunes the report ConfigMaps
+// exceeding the retention settings of their ReportOutput. The deleted resources are summarized in the log and in metrics.
 func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
@@ -49,32 +113,82 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 		scanPhases[string(complianceScan.UID)] = complianceScan.Status.Phase
 	}
 
-	jobList := &batchv1.JobList{}
-	if err := r.SourceClient.List(ctx, jobList,
-		client.InNamespace(r.Config.Namespace),
+	deleted := deletionSummary{}
+	defer deleted.log(log)
+
+	for _, namespace := range r.Config.Namespaces {
+		for _, resource := range orphanedResources {
+			c := r.Client
+			if resource.source {
+				c = r.SourceClient
+			}
+
+			if err := r.deleteOrphanedResources(ctx, c, resource, namespace, scanPhases, deleted, log); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	if err := r.deleteExpiredComplianceScans(ctx, complianceScanList.Items, deleted, log); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.pruneReports(ctx, deleted, log); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.Config.RequeueInterval}, nil
+}
+
+// deleteOrphanedResources deletes the resources of the given kind in the namespace which are linked to a ComplianceScan
+// that no longer exists or is in a terminal state.
+func (r *Reconciler) deleteOrphanedResources(
+	ctx context.Context,
+	c client.Client,
+	resource orphanedResource,
+	namespace string,
+	scanPhases map[string]v1alpha1.ComplianceScanPhase,
+	deleted deletionSummary,
+	log logr.Logger,
+) error {
+	list := resource.newList()
+	if err := c.List(ctx, list,
+		client.InNamespace(namespace),
 		client.HasLabels{constants.LabelComplianceScanUID},
 	); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed to list diki-run Jobs: %w", err)
+		return fmt.Errorf("failed to list %ss in namespace %s: %w", resource.kind, namespace, err)
 	}
 
-	for i := range jobList.Items {
-		job := &jobList.Items[i]
-		complianceScanUID := job.Labels[constants.LabelComplianceScanUID]
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract %ss: %w", resource.kind, err)
+	}
 
-		if !shouldDeleteJob(scanPhases, complianceScanUID) {
+	for _, o := range objects {
+		obj, ok := o.(client.Object)
+		if !ok || !strings.HasPrefix(obj.GetName(), resource.namePrefix) {
 			continue
 		}
 
-		log.Info("Deleting Job", "job", client.ObjectKeyFromObject(job), "complianceScanUID", complianceScanUID)
-		if err := r.SourceClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
-			return reconcile.Result{}, fmt.Errorf("failed to delete Job %s: %w", client.ObjectKeyFromObject(job), err)
+		complianceScanUID := obj.GetLabels()[constants.LabelComplianceScanUID]
+		if !shouldDelete(scanPhases, complianceScanUID) {
+			continue
 		}
+
+		log.Info("Deleting "+resource.kind, "object", client.ObjectKeyFromObject(obj), "complianceScanUID", complianceScanUID)
+		if err := c.Delete(ctx, obj, resource.deleteOpts...); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to delete %s %s: %w", resource.kind, client.ObjectKeyFromObject(obj), err)
+		}
+		deleted.record(resource.kind, reasonOrphaned)
 	}
 
-	return reconcile.Result{RequeueAfter: r.Config.RequeueInterval}, nil
+	return nil
 }
 
-func shouldDeleteJob(scanPhases map[string]v1alpha1.ComplianceScanPhase, complianceScanUID string) bool {
+func shouldDelete(scanPhases map[string]v1alpha1.ComplianceScanPhase, complianceScanUID string) bool {
 	phase, exists := scanPhases[complianceScanUID]
 	return !exists || phase == v1alpha1.ComplianceScanCompleted || phase == v1alpha1.ComplianceScanFailed
 }
@@ -12,11 +12,15 @@ import (
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -35,6 +39,7 @@ var _ = Describe("Controller", func() {
 
 		cr         *garbagecollector.Reconciler
 		fakeClient client.Client
+		fakeClock  *testclock.FakeClock
 		scheme     *runtime.Scheme
 		scan       *dikiv1alpha1.ComplianceScan
 
@@ -47,12 +52,14 @@ var _ = Describe("Controller", func() {
 		Expect(dikiinstall.AddToScheme(scheme)).To(Succeed())
 
 		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&dikiv1alpha1.ComplianceScan{}).Build()
+		fakeClock = testclock.NewFakeClock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
 
 		cr = &garbagecollector.Reconciler{
 			Client:       fakeClient,
 			SourceClient: fakeClient,
+			Clock:        fakeClock,
 			Config: garbagecollector.Config{
-				Namespace:       jobNamespace,
+				Namespaces:      []string{jobNamespace},
 				RequeueInterval: 1 * time.Minute,
 			},
 		}
@@ -160,6 +167,133 @@ var _ = Describe("Controller", func() {
 		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(job), job)).To(Succeed())
 	})
 
+	It("should delete Jobs in all configured namespaces", func() {
+		cr.Config.Namespaces = []string{jobNamespace, "foo"}
+
+		job1 := newDikiRunJob("diki-run-uid-1", jobNamespace, "uid-1")
+		Expect(fakeClient.Create(ctx, job1)).To(Succeed())
+		job2 := newDikiRunJob("diki-run-uid-2", "foo", "uid-2")
+		Expect(fakeClient.Create(ctx, job2)).To(Succeed())
+		job3 := newDikiRunJob("diki-run-uid-3", "bar", "uid-3")
+		Expect(fakeClient.Create(ctx, job3)).To(Succeed())
+
+		res, err := cr.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(cr.Config.RequeueInterval))
+
+		for _, job := range []*batchv1.Job{job1, job2} {
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(job), job)
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+		}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(job3), job3)).To(Succeed())
+	})
+
+	It("should delete token Secrets of terminated ComplianceScans", func() {
+		scan.Status.Phase = dikiv1alpha1.ComplianceScanCompleted
+		Expect(fakeClient.Create(ctx, scan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, scan)).To(Succeed())
+
+		runningScan := &dikiv1alpha1.ComplianceScan{
+			ObjectMeta: metav1.ObjectMeta{Name: "running-scan", UID: types.UID("running-uid")},
+			Status:     dikiv1alpha1.ComplianceScanStatus{Phase: dikiv1alpha1.ComplianceScanRunning},
+		}
+		Expect(fakeClient.Create(ctx, runningScan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, runningScan)).To(Succeed())
+
+		completedSecret := newTokenSecret("diki-token-scan-uid", jobNamespace, "scan-uid")
+		Expect(fakeClient.Create(ctx, completedSecret)).To(Succeed())
+		orphanedSecret := newTokenSecret("diki-token-orphan-uid", jobNamespace, "orphan-uid")
+		Expect(fakeClient.Create(ctx, orphanedSecret)).To(Succeed())
+		runningSecret := newTokenSecret("diki-token-running-uid", jobNamespace, "running-uid")
+		Expect(fakeClient.Create(ctx, runningSecret)).To(Succeed())
+		unrelatedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: jobNamespace}}
+		Expect(fakeClient.Create(ctx, unrelatedSecret)).To(Succeed())
+
+		res, err := cr.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(cr.Config.RequeueInterval))
+
+		for _, secret := range []*corev1.Secret{completedSecret, orphanedSecret} {
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+		}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(runningSecret), runningSecret)).To(Succeed())
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(unrelatedSecret), unrelatedSecret)).To(Succeed())
+	})
+
+	It("should delete the orphaned artifacts of terminated ComplianceScans", func() {
+		scan.Status.Phase = dikiv1alpha1.ComplianceScanCompleted
+		Expect(fakeClient.Create(ctx, scan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, scan)).To(Succeed())
+
+		runningScan := &dikiv1alpha1.ComplianceScan{
+			ObjectMeta: metav1.ObjectMeta{Name: "running-scan", UID: types.UID("running-uid")},
+			Status:     dikiv1alpha1.ComplianceScanStatus{Phase: dikiv1alpha1.ComplianceScanRunning},
+		}
+		Expect(fakeClient.Create(ctx, runningScan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, runningScan)).To(Succeed())
+
+		completedConfigMap := newLabelledConfigMap("diki-config-scan-uid", jobNamespace, "scan-uid")
+		orphanedConfigMap := newLabelledConfigMap("diki-config-orphan-uid-0", jobNamespace, "orphan-uid")
+		partialReport := newLabelledConfigMap("diki-partial-report-orphan-uid-0", jobNamespace, "orphan-uid")
+		runningConfigMap := newLabelledConfigMap("diki-config-running-uid", jobNamespace, "running-uid")
+		exportedReport := newLabelledConfigMap("compliance-scan-report-abcde", jobNamespace, "scan-uid")
+		orphanedNetworkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+			Name:      "diki-run-orphan-uid",
+			Namespace: jobNamespace,
+			Labels:    map[string]string{"compliancescan.diki.gardener.cloud/uid": "orphan-uid"},
+		}}
+		runningNetworkPolicy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
+			Name:      "diki-run-running-uid",
+			Namespace: jobNamespace,
+			Labels:    map[string]string{"compliancescan.diki.gardener.cloud/uid": "running-uid"},
+		}}
+		for _, obj := range []client.Object{completedConfigMap, orphanedConfigMap, partialReport, runningConfigMap, exportedReport, orphanedNetworkPolicy, runningNetworkPolicy} {
+			Expect(fakeClient.Create(ctx, obj)).To(Succeed())
+		}
+
+		deletedConfigMapsBefore := testutil.ToFloat64(garbagecollector.DeletedResourcesTotal.WithLabelValues("ConfigMap", "orphaned"))
+		deletedNetworkPoliciesBefore := testutil.ToFloat64(garbagecollector.DeletedResourcesTotal.WithLabelValues("NetworkPolicy", "orphaned"))
+
+		res, err := cr.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(cr.Config.RequeueInterval))
+
+		for _, obj := range []client.Object{completedConfigMap, orphanedConfigMap, partialReport, orphanedNetworkPolicy} {
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+			Expect(err).To(HaveOccurred(), "%s should be deleted", obj.GetName())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+		}
+		for _, obj := range []client.Object{runningConfigMap, exportedReport, runningNetworkPolicy} {
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed(), "%s should be kept", obj.GetName())
+		}
+
+		Expect(testutil.ToFloat64(garbagecollector.DeletedResourcesTotal.WithLabelValues("ConfigMap", "orphaned"))).To(Equal(deletedConfigMapsBefore + 3))
+		Expect(testutil.ToFloat64(garbagecollector.DeletedResourcesTotal.WithLabelValues("NetworkPolicy", "orphaned"))).To(Equal(deletedNetworkPoliciesBefore + 1))
+	})
+
+	It("should delete the orphaned partial reports in the cluster of the ComplianceScans", func() {
+		sourceClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		cr.SourceClient = sourceClient
+
+		partialReport := newLabelledConfigMap("diki-partial-report-orphan-uid-0", jobNamespace, "orphan-uid")
+		Expect(fakeClient.Create(ctx, partialReport)).To(Succeed())
+		configMap := newLabelledConfigMap("diki-config-orphan-uid-0", jobNamespace, "orphan-uid")
+		Expect(sourceClient.Create(ctx, configMap)).To(Succeed())
+
+		_, err := cr.Reconcile(ctx, reconcile.Request{})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(partialReport), partialReport)
+		Expect(err).To(HaveOccurred())
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		err = sourceClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)
+		Expect(err).To(HaveOccurred())
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+	})
+
 	Context("when source and target clusters are different", func() {
 		var sourceClient client.Client
 
@@ -237,6 +371,242 @@ var _ = Describe("Controller", func() {
 		Expect(err).To(MatchError(ContainSubstring("delete-failed")))
 		Expect(res).To(Equal(reconcile.Result{}))
 	})
+
+	Describe("ComplianceScan TTL", func() {
+		newFinishedScan := func(name string, phase dikiv1alpha1.ComplianceScanPhase, finishedAgo time.Duration) *dikiv1alpha1.ComplianceScan {
+			conditionType := dikiv1alpha1.ConditionTypeCompleted
+			if phase == dikiv1alpha1.ComplianceScanFailed {
+				conditionType = dikiv1alpha1.ConditionTypeFailed
+			}
+			return &dikiv1alpha1.ComplianceScan{
+				ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name + "-uid")},
+				Status: dikiv1alpha1.ComplianceScanStatus{
+					Phase: phase,
+					Conditions: []dikiv1alpha1.Condition{{
+						Type:               conditionType,
+						Status:             dikiv1alpha1.ConditionTrue,
+						LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-finishedAgo)),
+					}},
+				},
+			}
+		}
+
+		createScans := func(scans ...*dikiv1alpha1.ComplianceScan) {
+			for _, s := range scans {
+				Expect(fakeClient.Create(ctx, s)).To(Succeed())
+				Expect(fakeClient.Status().Update(ctx, s)).To(Succeed())
+			}
+		}
+
+		expectScans := func(kept, deleted []*dikiv1alpha1.ComplianceScan) {
+			for _, s := range kept {
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(s), &dikiv1alpha1.ComplianceScan{})).To(Succeed(), "ComplianceScan %s should be kept", s.Name)
+			}
+			for _, s := range deleted {
+				err := fakeClient.Get(ctx, client.ObjectKeyFromObject(s), &dikiv1alpha1.ComplianceScan{})
+				Expect(err).To(HaveOccurred(), "ComplianceScan %s should be deleted", s.Name)
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+			}
+		}
+
+		It("should delete finished ComplianceScans whose ttlSecondsAfterFinished has expired", func() {
+			expiredCompleted := newFinishedScan("expired-completed", dikiv1alpha1.ComplianceScanCompleted, 2*time.Hour)
+			expiredCompleted.Spec.TTLSecondsAfterFinished = ptr.To[int32](3600)
+			expiredFailed := newFinishedScan("expired-failed", dikiv1alpha1.ComplianceScanFailed, 2*time.Hour)
+			expiredFailed.Spec.TTLSecondsAfterFinished = ptr.To[int32](3600)
+			notExpired := newFinishedScan("not-expired", dikiv1alpha1.ComplianceScanCompleted, 30*time.Minute)
+			notExpired.Spec.TTLSecondsAfterFinished = ptr.To[int32](3600)
+			withoutTTL := newFinishedScan("without-ttl", dikiv1alpha1.ComplianceScanCompleted, 1000*time.Hour)
+			running := newFinishedScan("running", dikiv1alpha1.ComplianceScanRunning, 2*time.Hour)
+			running.Spec.TTLSecondsAfterFinished = ptr.To[int32](0)
+			createScans(expiredCompleted, expiredFailed, notExpired, withoutTTL, running)
+
+			res, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(cr.Config.RequeueInterval))
+
+			expectScans(
+				[]*dikiv1alpha1.ComplianceScan{notExpired, withoutTTL, running},
+				[]*dikiv1alpha1.ComplianceScan{expiredCompleted, expiredFailed},
+			)
+		})
+
+		It("should apply the default TTL only to ComplianceScans not created by a ScheduledComplianceScan", func() {
+			cr.Config.ComplianceScanTTLSecondsAfterFinished = ptr.To[int32](3600)
+
+			adHoc := newFinishedScan("ad-hoc", dikiv1alpha1.ComplianceScanCompleted, 2*time.Hour)
+			scheduled := newFinishedScan("scheduled", dikiv1alpha1.ComplianceScanCompleted, 2*time.Hour)
+			scheduled.Labels = map[string]string{"scheduledcompliancescan.diki.gardener.cloud/name": "schedule"}
+			scheduledWithTTL := newFinishedScan("scheduled-with-ttl", dikiv1alpha1.ComplianceScanCompleted, 2*time.Hour)
+			scheduledWithTTL.Labels = map[string]string{"scheduledcompliancescan.diki.gardener.cloud/name": "schedule"}
+			scheduledWithTTL.Spec.TTLSecondsAfterFinished = ptr.To[int32](60)
+			overridden := newFinishedScan("overridden", dikiv1alpha1.ComplianceScanCompleted, 2*time.Hour)
+			overridden.Spec.TTLSecondsAfterFinished = ptr.To[int32](3 * 3600)
+			createScans(adHoc, scheduled, scheduledWithTTL, overridden)
+
+			_, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectScans(
+				[]*dikiv1alpha1.ComplianceScan{scheduled, overridden},
+				[]*dikiv1alpha1.ComplianceScan{adHoc, scheduledWithTTL},
+			)
+		})
+
+		It("should only mark expired ComplianceScans with a finalizer for deletion", func() {
+			expired := newFinishedScan("expired", dikiv1alpha1.ComplianceScanCompleted, 2*time.Hour)
+			expired.Finalizers = []string{"diki.gardener.cloud/compliancescan"}
+			expired.Spec.TTLSecondsAfterFinished = ptr.To[int32](3600)
+			createScans(expired)
+
+			_, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(expired), expired)).To(Succeed())
+			Expect(expired.DeletionTimestamp).NotTo(BeNil())
+			Expect(expired.Finalizers).To(ConsistOf("diki.gardener.cloud/compliancescan"))
+		})
+	})
+
+	Describe("report retention", func() {
+		var reportOutput *dikiv1alpha1.ReportOutput
+
+		BeforeEach(func() {
+			reportOutput = &dikiv1alpha1.ReportOutput{
+				ObjectMeta: metav1.ObjectMeta{Name: "output"},
+				Spec: dikiv1alpha1.ReportOutputSpec{
+					Output: dikiv1alpha1.Output{
+						ConfigMap: &dikiv1alpha1.OutputConfigMap{
+							Namespace:  "reports",
+							NamePrefix: "report-",
+							Retention:  &dikiv1alpha1.OutputConfigMapRetention{},
+						},
+					},
+				},
+			}
+		})
+
+		createReports := func(reports ...*corev1.ConfigMap) {
+			Expect(fakeClient.Create(ctx, reportOutput)).To(Succeed())
+			for _, report := range reports {
+				Expect(fakeClient.Create(ctx, report)).To(Succeed())
+			}
+		}
+
+		expectReports := func(kept []*corev1.ConfigMap, pruned []*corev1.ConfigMap) {
+			for _, report := range kept {
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(report), &corev1.ConfigMap{})).To(Succeed(), "report %s should be kept", report.Name)
+			}
+			for _, report := range pruned {
+				err := fakeClient.Get(ctx, client.ObjectKeyFromObject(report), &corev1.ConfigMap{})
+				Expect(err).To(HaveOccurred(), "report %s should be pruned", report.Name)
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+			}
+		}
+
+		It("should keep the newest reports per ComplianceScan and ScheduledComplianceScan", func() {
+			reportOutput.Spec.Output.ConfigMap.Retention.MaxCount = ptr.To[int32](2)
+
+			scanReports := []*corev1.ConfigMap{
+				newReportConfigMap("report-scan-1", "output", "scan", "", fakeClock.Now().Add(-3*time.Hour)),
+				newReportConfigMap("report-scan-2", "output", "scan", "", fakeClock.Now().Add(-2*time.Hour)),
+				newReportConfigMap("report-scan-3", "output", "scan", "", fakeClock.Now().Add(-1*time.Hour)),
+			}
+			scheduledReports := []*corev1.ConfigMap{
+				newReportConfigMap("report-schedule-1", "output", "schedule-1", "schedule", fakeClock.Now().Add(-3*time.Hour)),
+				newReportConfigMap("report-schedule-2", "output", "schedule-2", "schedule", fakeClock.Now().Add(-2*time.Hour)),
+				newReportConfigMap("report-schedule-3", "output", "schedule-3", "schedule", fakeClock.Now().Add(-1*time.Hour)),
+			}
+			otherScanReport := newReportConfigMap("report-other-scan", "output", "other-scan", "", fakeClock.Now().Add(-4*time.Hour))
+			createReports(append(append(scanReports, scheduledReports...), otherScanReport)...)
+
+			res, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.RequeueAfter).To(Equal(cr.Config.RequeueInterval))
+
+			expectReports(
+				[]*corev1.ConfigMap{scanReports[1], scanReports[2], scheduledReports[1], scheduledReports[2], otherScanReport},
+				[]*corev1.ConfigMap{scanReports[0], scheduledReports[0]},
+			)
+		})
+
+		It("should prune reports older than the maximum age", func() {
+			reportOutput.Spec.Output.ConfigMap.Retention.MaxAge = &metav1.Duration{Duration: 24 * time.Hour}
+
+			oldReport := newReportConfigMap("report-old", "output", "scan", "", fakeClock.Now().Add(-25*time.Hour))
+			newReport := newReportConfigMap("report-new", "output", "scan", "", fakeClock.Now().Add(-23*time.Hour))
+			createReports(oldReport, newReport)
+
+			_, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectReports([]*corev1.ConfigMap{newReport}, []*corev1.ConfigMap{oldReport})
+		})
+
+		It("should only prune reports of the ReportOutput", func() {
+			reportOutput.Spec.Output.ConfigMap.Retention.MaxAge = &metav1.Duration{Duration: time.Hour}
+
+			report := newReportConfigMap("report-1", "output", "scan", "", fakeClock.Now().Add(-2*time.Hour))
+			otherOutputReport := newReportConfigMap("report-2", "other-output", "scan", "", fakeClock.Now().Add(-2*time.Hour))
+			unmanagedConfigMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:              "unmanaged",
+				Namespace:         "reports",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-2 * time.Hour)),
+			}}
+			createReports(report, otherOutputReport, unmanagedConfigMap)
+
+			_, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectReports([]*corev1.ConfigMap{otherOutputReport, unmanagedConfigMap}, []*corev1.ConfigMap{report})
+		})
+
+		It("should not prune reports when the ReportOutput has no retention", func() {
+			reportOutput.Spec.Output.ConfigMap.Retention = nil
+
+			report := newReportConfigMap("report-1", "output", "scan", "", fakeClock.Now().Add(-1000*time.Hour))
+			createReports(report)
+
+			_, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectReports([]*corev1.ConfigMap{report}, nil)
+		})
+
+		It("should not prune reports in dry-run mode", func() {
+			reportOutput.Spec.Output.ConfigMap.Retention.MaxCount = ptr.To[int32](1)
+			reportOutput.Spec.Output.ConfigMap.Retention.DryRun = true
+
+			reports := []*corev1.ConfigMap{
+				newReportConfigMap("report-1", "output", "scan", "", fakeClock.Now().Add(-2*time.Hour)),
+				newReportConfigMap("report-2", "output", "scan", "", fakeClock.Now().Add(-1*time.Hour)),
+			}
+			createReports(reports...)
+
+			_, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			expectReports(reports, nil)
+		})
+
+		It("should return error when deleting a report fails", func() {
+			reportOutput.Spec.Output.ConfigMap.Retention.MaxAge = &metav1.Duration{Duration: time.Hour}
+			report := newReportConfigMap("report-1", "output", "scan", "", fakeClock.Now().Add(-2*time.Hour))
+
+			cr.Client = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(reportOutput, report).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.DeleteOption) error {
+						return errors.New("delete-failed")
+					},
+				}).Build()
+
+			res, err := cr.Reconcile(ctx, reconcile.Request{})
+			Expect(err).To(MatchError(ContainSubstring("delete-failed")))
+			Expect(res).To(Equal(reconcile.Result{}))
+		})
+	})
 })
 
 func newDikiRunJob(name, namespace, complianceScanUID string) *batchv1.Job {
@@ -260,3 +630,47 @@ func newDikiRunJob(name, namespace, complianceScanUID string) *batchv1.Job {
 		},
 	}
 }
+
+func newTokenSecret(name, namespace, complianceScanUID string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"compliancescan.diki.gardener.cloud/uid": complianceScanUID,
+			},
+		},
+		Data: map[string][]byte{"token": []byte("foo")},
+	}
+}
+
+func newReportConfigMap(name, outputName, complianceScanName, scheduledComplianceScanName string, creationTime time.Time) *corev1.ConfigMap {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "reports",
+			CreationTimestamp: metav1.NewTime(creationTime),
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":            "diki-operator",
+				"compliancescan.diki.gardener.cloud/name": complianceScanName,
+				"reportoutput.diki.gardener.cloud/name":   outputName,
+			},
+		},
+	}
+	if scheduledComplianceScanName != "" {
+		configMap.Labels["scheduledcompliancescan.diki.gardener.cloud/name"] = scheduledComplianceScanName
+	}
+	return configMap
+}
+
+func newLabelledConfigMap(name, namespace, complianceScanUID string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"compliancescan.diki.gardener.cloud/uid": complianceScanUID,
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki-operator/internal/constants"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// pruneReports deletes the report ConfigMaps exceeding the retention settings of their ReportOutput.
+func (r *Reconciler) pruneReports(ctx context.Context, deleted deletionSummary, log logr.Logger) error {
+	reportOutputList := &v1alpha1.ReportOutputList{}
+	if err := r.Client.List(ctx, reportOutputList); err != nil {
+		return fmt.Errorf("failed to list ReportOutputs: %w", err)
+	}
+
+	for _, reportOutput := range reportOutputList.Items {
+		configMapOutput := reportOutput.Spec.Output.ConfigMap
+		if configMapOutput == nil || configMapOutput.Retention == nil {
+			continue
+		}
+
+		if err := r.pruneReportConfigMaps(ctx, reportOutput.Name, configMapOutput, deleted, log.WithValues("reportOutput", reportOutput.Name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneReportConfigMaps deletes the report ConfigMaps exported to the given output which are older than the maximum age
+// or exceed the maximum count of their ComplianceScan or ScheduledComplianceScan.
+func (r *Reconciler) pruneReportConfigMaps(ctx context.Context, outputName string, config *v1alpha1.OutputConfigMap, deleted deletionSummary, log logr.Logger) error {
+	configMapList := &corev1.ConfigMapList{}
+	if err := r.Client.List(ctx, configMapList,
+		client.InNamespace(config.Namespace),
+		client.MatchingLabels{
+			constants.LabelAppManagedBy:     constants.LabelValueDikiOperator,
+			constants.LabelReportOutputName: outputName,
+		},
+		client.HasLabels{constants.LabelComplianceScanName},
+	); err != nil {
+		return fmt.Errorf("failed to list report ConfigMaps in namespace %s: %w", config.Namespace, err)
+	}
+
+	groups := make(map[string][]*corev1.ConfigMap)
+	for i := range configMapList.Items {
+		configMap := &configMapList.Items[i]
+		key := reportGroupKey(configMap)
+		groups[key] = append(groups[key], configMap)
+	}
+
+	var (
+		retention = config.Retention
+		now       = r.Clock.Now()
+	)
+
+	for _, configMaps := range groups {
+		// The newest reports are kept, hence the ConfigMaps are sorted by descending creation time.
+		slices.SortFunc(configMaps, func(a, b *corev1.ConfigMap) int {
+			if c := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		for i, configMap := range configMaps {
+			var reason string
+			switch {
+			case retention.MaxCount != nil && i >= int(*retention.MaxCount):
+				reason = "maxCount exceeded"
+			case retention.MaxAge != nil && now.Sub(configMap.CreationTimestamp.Time) > retention.MaxAge.Duration:
+				reason = "maxAge exceeded"
+			default:
+				continue
+			}
+
+			configMapLog := log.WithValues("configMap", client.ObjectKeyFromObject(configMap), "reason", reason, "age", now.Sub(configMap.CreationTimestamp.Time).Round(time.Second))
+			if retention.DryRun {
+				configMapLog.Info("Would prune report ConfigMap (dry run)")
+				continue
+			}
+
+			configMapLog.Info("Pruning report ConfigMap")
+			if err := r.Client.Delete(ctx, configMap); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("failed to delete report ConfigMap %s: %w", client.ObjectKeyFromObject(configMap), err)
+			}
+			deleted.record("ConfigMap", reasonRetention)
+		}
+	}
+
+	return nil
+}
+
+// reportGroupKey returns the key of the group a report ConfigMap is counted in for the retention.
+// Reports of ComplianceScans created by a ScheduledComplianceScan are grouped by the ScheduledComplianceScan,
+// all other reports by the name of their ComplianceScan.
+func reportGroupKey(configMap *corev1.ConfigMap) string {
+	if name, ok := configMap.Labels[constants.LabelScheduledComplianceScanName]; ok {
+		return "scheduledcompliancescan/" + name
+	}
+	return "compliancescan/" + configMap.Labels[constants.LabelComplianceScanName]
+}
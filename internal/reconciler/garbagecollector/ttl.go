@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki-operator/internal/constants"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// deleteExpiredComplianceScans deletes the finished ComplianceScans whose time to live has expired.
+// Only the deletion is triggered, the resources of the ComplianceScans are cleaned up by their finalizer.
+func (r *Reconciler) deleteExpiredComplianceScans(ctx context.Context, complianceScans []v1alpha1.ComplianceScan, deleted deletionSummary, log logr.Logger) error {
+	now := r.Clock.Now()
+
+	for i := range complianceScans {
+		complianceScan := &complianceScans[i]
+		if complianceScan.DeletionTimestamp != nil {
+			continue
+		}
+
+		ttl := r.ttlAfterFinished(complianceScan)
+		if ttl == nil {
+			continue
+		}
+
+		finishedTime, ok := finishedTime(complianceScan)
+		if !ok || now.Before(finishedTime.Add(*ttl)) {
+			continue
+		}
+
+		log.Info("Deleting expired ComplianceScan", "complianceScan", complianceScan.Name, "finishedTime", finishedTime, "ttl", *ttl)
+		if err := r.Client.Delete(ctx, complianceScan, client.Preconditions{UID: &complianceScan.UID}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to delete ComplianceScan %s: %w", complianceScan.Name, err)
+		}
+		deleted.record("ComplianceScan", reasonExpired)
+	}
+
+	return nil
+}
+
+// ttlAfterFinished returns the time to live of the given finished ComplianceScan.
+// The configured default only applies to ComplianceScans which are not created by a ScheduledComplianceScan,
+// as those are already cleaned up according to the history limits of their ScheduledComplianceScan.
+func (r *Reconciler) ttlAfterFinished(complianceScan *v1alpha1.ComplianceScan) *time.Duration {
+	seconds := complianceScan.Spec.TTLSecondsAfterFinished
+	if seconds == nil {
+		if _, ok := complianceScan.Labels[constants.LabelScheduledComplianceScanName]; ok {
+			return nil
+		}
+		seconds = r.Config.ComplianceScanTTLSecondsAfterFinished
+	}
+	if seconds == nil {
+		return nil
+	}
+
+	ttl := time.Duration(*seconds) * time.Second
+	return &ttl
+}
+
+// finishedTime returns the time at which the given ComplianceScan reached a terminal phase.
+func finishedTime(complianceScan *v1alpha1.ComplianceScan) (time.Time, bool) {
+	var conditionType v1alpha1.ConditionType
+	switch complianceScan.Status.Phase {
+	case v1alpha1.ComplianceScanCompleted:
+		conditionType = v1alpha1.ConditionTypeCompleted
+	case v1alpha1.ComplianceScanFailed:
+		conditionType = v1alpha1.ConditionTypeFailed
+	default:
+		return time.Time{}, false
+	}
+
+	for _, condition := range complianceScan.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == v1alpha1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/diki-operator/internal/constants"
+	"github.com/gardener/diki-operator/internal/controllerutils"
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// ControllerName is the name of the namespacecompliancescan controller.
+const ControllerName = "namespacecompliancescan"
+
+// SetupWithManager specifies how the controller is built to watch NamespaceComplianceScan resources.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
+
+	// ComplianceScans are cluster-scoped and cannot be owned by a NamespaceComplianceScan, hence they are mapped by their labels.
+	return builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&dikiv1alpha1.NamespaceComplianceScan{}).
+		Watches(&dikiv1alpha1.ComplianceScan{}, handler.EnqueueRequestsFromMapFunc(mapComplianceScanToNamespaceComplianceScan)).
+		WithOptions(controllerutils.Options(r.Config.ControllerOptions)).
+		Complete(r)
+}
+
+func mapComplianceScanToNamespaceComplianceScan(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, namespace := labels[constants.LabelNamespaceComplianceScanName], labels[constants.LabelNamespaceComplianceScanNamespace]
+	if name == "" || namespace == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+const (
+	// FinalizerName is the finalizer which is added to NamespaceComplianceScans to clean up their ComplianceScan on deletion.
+	FinalizerName = "diki.gardener.cloud/namespacecompliancescan"
+	// ComplianceScanNamePrefix is the prefix for the names of the ComplianceScans and ReportOutputs of NamespaceComplianceScans.
+	ComplianceScanNamePrefix = "nscan-"
+	// ConditionReasonRejected is the reason for the Failed condition when the ComplianceScan of a NamespaceComplianceScan is rejected.
+	ConditionReasonRejected = "ComplianceScanRejected"
+	// ConditionReasonComplianceScanDeleted is the reason for the Failed condition when the ComplianceScan of an unfinished NamespaceComplianceScan has been deleted.
+	ConditionReasonComplianceScanDeleted = "ComplianceScanDeleted"
+)
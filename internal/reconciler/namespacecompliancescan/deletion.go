@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/controllerutils"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// delete deletes the ComplianceScan of a deleted namespace compliance scan and waits until it is gone, because the
+// ReportOutput cannot be deleted while it is still referenced. The exported reports are retained in the namespace.
+func (r *Reconciler) delete(ctx context.Context, namespaceScan *v1alpha1.NamespaceComplianceScan, log logr.Logger) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(namespaceScan, FinalizerName) {
+		return reconcile.Result{}, nil
+	}
+
+	name := complianceScanName(namespaceScan)
+
+	complianceScan := &v1alpha1.ComplianceScan{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, complianceScan); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, fmt.Errorf("error retrieving ComplianceScan: %w", err)
+		}
+	} else {
+		if complianceScan.DeletionTimestamp == nil {
+			log.Info("Deleting ComplianceScan", "complianceScan", name)
+			if err := client.IgnoreNotFound(r.Client.Delete(ctx, complianceScan)); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete ComplianceScan: %w", err)
+			}
+		}
+
+		// The NamespaceComplianceScan is enqueued again when the deletion of the ComplianceScan is observed.
+		log.Info("Waiting for ComplianceScan to be deleted", "complianceScan", name)
+		return reconcile.Result{}, nil
+	}
+
+	reportOutput := &v1alpha1.ReportOutput{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	log.Info("Deleting ReportOutput", "reportOutput", name)
+	if err := client.IgnoreNotFound(r.Client.Delete(ctx, reportOutput)); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete ReportOutput: %w", err)
+	}
+
+	log.Info("Removing finalizer")
+	if err := controllerutils.RemoveFinalizers(ctx, r.Client, namespaceScan, FinalizerName); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+
+	return reconcile.Result{}, nil
+}
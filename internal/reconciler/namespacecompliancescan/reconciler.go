@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/controllerutils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// Reconciler reconciles namespace compliance scans.
+type Reconciler struct {
+	Client    client.Client
+	APIReader client.Reader
+	Clock     clock.Clock
+	Config    configv1alpha1.NamespaceComplianceScanConfig
+}
+
+// Reconcile handles reconciliation requests for NamespaceComplianceScan resources.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	namespaceScan := &v1alpha1.NamespaceComplianceScan{}
+	if err := r.Client.Get(ctx, req.NamespacedName, namespaceScan); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Object is gone, stop reconciling")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("error retrieving NamespaceComplianceScan: %w", err)
+	}
+
+	if namespaceScan.DeletionTimestamp != nil {
+		return r.delete(ctx, namespaceScan, log)
+	}
+
+	if !controllerutil.ContainsFinalizer(namespaceScan, FinalizerName) {
+		log.Info("Adding finalizer")
+		if err := controllerutils.AddFinalizers(ctx, r.Client, namespaceScan, FinalizerName); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	if isFinished(namespaceScan) {
+		log.Info("NamespaceComplianceScan already processed, stop reconciling", "phase", namespaceScan.Status.Phase)
+		return reconcile.Result{}, nil
+	}
+
+	if namespaceScan.Status.ComplianceScanName == "" {
+		complianceScan, err := r.deployComplianceScan(ctx, namespaceScan, log)
+		if err != nil {
+			// The ComplianceScan is rejected by the admission webhook if the NamespaceComplianceScan references
+			// options or outputs which cannot be used in its namespace. Retrying does not help in this case.
+			if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) {
+				return reconcile.Result{}, r.patchFailed(ctx, namespaceScan, ConditionReasonRejected, err, log)
+			}
+			return reconcile.Result{}, err
+		}
+
+		if err := r.setComplianceScanName(ctx, namespaceScan, complianceScan.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Info("Created ComplianceScan", "complianceScan", complianceScan.Name)
+
+		// The cache might not have observed the new ComplianceScan yet, hence its status is mirrored from the created
+		// object. Further changes of the ComplianceScan trigger a new reconciliation.
+		return reconcile.Result{}, r.updateStatus(ctx, namespaceScan, complianceScan, log)
+	}
+
+	complianceScan := &v1alpha1.ComplianceScan{}
+	if err := r.getComplianceScan(ctx, namespaceScan.Status.ComplianceScanName, complianceScan); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, r.patchFailed(ctx, namespaceScan, ConditionReasonComplianceScanDeleted,
+				fmt.Errorf("ComplianceScan %s has been deleted", namespaceScan.Status.ComplianceScanName), log)
+		}
+		return reconcile.Result{}, fmt.Errorf("error retrieving ComplianceScan: %w", err)
+	}
+
+	return reconcile.Result{}, r.updateStatus(ctx, namespaceScan, complianceScan, log)
+}
+
+// getComplianceScan reads the ComplianceScan from the cache and falls back to the API server if the cache has not
+// observed it yet, so that a stale cache is not mistaken for a deleted ComplianceScan.
+func (r *Reconciler) getComplianceScan(ctx context.Context, name string, complianceScan *v1alpha1.ComplianceScan) error {
+	err := r.Client.Get(ctx, client.ObjectKey{Name: name}, complianceScan)
+	if !apierrors.IsNotFound(err) || r.APIReader == nil {
+		return err
+	}
+	return r.APIReader.Get(ctx, client.ObjectKey{Name: name}, complianceScan)
+}
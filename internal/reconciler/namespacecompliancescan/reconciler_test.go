@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	namespacecompliancescan "github.com/gardener/diki-operator/internal/reconciler/namespacecompliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+var _ = Describe("Controller", func() {
+	var (
+		ctx = logf.IntoContext(context.Background(), logzap.New(logzap.WriteTo(GinkgoWriter)))
+
+		cr         *namespacecompliancescan.Reconciler
+		fakeClient client.Client
+		fakeClock  *testclock.FakeClock
+		scheme     *runtime.Scheme
+
+		request reconcile.Request
+
+		namespaceScan *dikiv1alpha1.NamespaceComplianceScan
+		scanKey       client.ObjectKey
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(dikiinstall.AddToScheme(scheme)).To(Succeed())
+
+		fakeClock = testclock.NewFakeClock(time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC))
+
+		namespaceScan = &dikiv1alpha1.NamespaceComplianceScan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "scan",
+				Namespace: "tenant",
+				UID:       types.UID("1"),
+			},
+			Spec: dikiv1alpha1.NamespaceComplianceScanSpec{
+				Rulesets: []dikiv1alpha1.RulesetConfig{
+					{
+						ID:      "security-hardened-k8s",
+						Version: "v0.1.0",
+						Options: &dikiv1alpha1.RulesetOptions{
+							Rules: &dikiv1alpha1.Options{
+								ConfigMapRef: &dikiv1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "tenant"},
+							},
+						},
+					},
+				},
+			},
+		}
+		scanKey = client.ObjectKey{Name: "nscan-1"}
+
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: "scan", Namespace: "tenant"}}
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&dikiv1alpha1.NamespaceComplianceScan{}, &dikiv1alpha1.ComplianceScan{}).
+			Build()
+	})
+
+	JustBeforeEach(func() {
+		cr = &namespacecompliancescan.Reconciler{
+			Client:    fakeClient,
+			APIReader: fakeClient,
+			Clock:     fakeClock,
+			Config:    configv1alpha1.NamespaceComplianceScanConfig{RunnerProfile: "tenants"},
+		}
+	})
+
+	It("should return no error when the NamespaceComplianceScan does not exist", func() {
+		res, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res).To(Equal(reconcile.Result{}))
+	})
+
+	It("should create a ComplianceScan restricted to the namespace", func() {
+		Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+
+		_, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		complianceScan := &dikiv1alpha1.ComplianceScan{}
+		Expect(fakeClient.Get(ctx, scanKey, complianceScan)).To(Succeed())
+		Expect(complianceScan.Labels).To(Equal(map[string]string{
+			"app.kubernetes.io/name":                                "diki",
+			"app.kubernetes.io/managed-by":                          "diki-operator",
+			"namespacecompliancescan.diki.gardener.cloud/name":      "scan",
+			"namespacecompliancescan.diki.gardener.cloud/namespace": "tenant",
+			"namespacecompliancescan.diki.gardener.cloud/uid":       "1",
+		}))
+		Expect(complianceScan.Spec).To(Equal(dikiv1alpha1.ComplianceScanSpec{
+			Rulesets:        namespaceScan.Spec.Rulesets,
+			RunnerProfile:   "tenants",
+			TargetNamespace: "tenant",
+		}))
+
+		err = fakeClient.Get(ctx, scanKey, &dikiv1alpha1.ReportOutput{})
+		Expect(err).To(HaveOccurred())
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		Expect(namespaceScan.Finalizers).To(ConsistOf("diki.gardener.cloud/namespacecompliancescan"))
+		Expect(namespaceScan.Status.ComplianceScanName).To(Equal("nscan-1"))
+		Expect(namespaceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanPending))
+	})
+
+	It("should not read the created ComplianceScan from the cache", func() {
+		Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+		cr.Client = interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*dikiv1alpha1.ComplianceScan); ok {
+					return apierrors.NewNotFound(dikiv1alpha1.SchemeGroupVersion.WithResource("compliancescans").GroupResource(), key.Name)
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+
+		_, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		Expect(namespaceScan.Status.ComplianceScanName).To(Equal("nscan-1"))
+		Expect(namespaceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanPending))
+		Expect(namespaceScan.Status.Conditions).To(BeEmpty())
+
+		_, err = cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		Expect(namespaceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanPending))
+		Expect(namespaceScan.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should create a ReportOutput exporting to the namespace when an output is configured", func() {
+		namespaceScan.Spec.Output = &dikiv1alpha1.NamespaceOutputConfigMap{
+			NamePrefix: "report-",
+			Retention:  &dikiv1alpha1.OutputConfigMapRetention{MaxCount: ptr.To[int32](3)},
+		}
+		Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+
+		_, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		reportOutput := &dikiv1alpha1.ReportOutput{}
+		Expect(fakeClient.Get(ctx, scanKey, reportOutput)).To(Succeed())
+		Expect(reportOutput.Spec.Output).To(Equal(dikiv1alpha1.Output{
+			ConfigMap: &dikiv1alpha1.OutputConfigMap{
+				Namespace:  "tenant",
+				NamePrefix: "report-",
+				Retention:  &dikiv1alpha1.OutputConfigMapRetention{MaxCount: ptr.To[int32](3)},
+			},
+		}))
+
+		complianceScan := &dikiv1alpha1.ComplianceScan{}
+		Expect(fakeClient.Get(ctx, scanKey, complianceScan)).To(Succeed())
+		Expect(complianceScan.Spec.Outputs).To(ConsistOf(dikiv1alpha1.ReportOutputRef{Name: "nscan-1", DeletionPolicy: dikiv1alpha1.DeletionPolicyRetain}))
+	})
+
+	It("should set the phase to Failed when the ComplianceScan is rejected", func() {
+		Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+		fakeClient = interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*dikiv1alpha1.ComplianceScan); ok {
+					return apierrors.NewForbidden(schema.GroupResource{Group: "diki.gardener.cloud", Resource: "compliancescans"}, obj.GetName(), errors.New("not allowed"))
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		})
+		cr.Client = fakeClient
+
+		_, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		Expect(namespaceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+		Expect(namespaceScan.Status.ComplianceScanName).To(BeEmpty())
+		Expect(namespaceScan.Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Type":   Equal(dikiv1alpha1.ConditionTypeFailed),
+			"Status": Equal(dikiv1alpha1.ConditionTrue),
+			"Reason": Equal("ComplianceScanRejected"),
+		})))
+	})
+
+	It("should mirror the status of the ComplianceScan", func() {
+		namespaceScan.Finalizers = []string{"diki.gardener.cloud/namespacecompliancescan"}
+		namespaceScan.Status.ComplianceScanName = "nscan-1"
+		namespaceScan.Status.Phase = dikiv1alpha1.ComplianceScanPending
+		complianceScan := &dikiv1alpha1.ComplianceScan{
+			ObjectMeta: metav1.ObjectMeta{Name: "nscan-1"},
+			Status: dikiv1alpha1.ComplianceScanStatus{
+				Phase: dikiv1alpha1.ComplianceScanCompleted,
+				Conditions: []dikiv1alpha1.Condition{
+					{Type: dikiv1alpha1.ConditionTypeCompleted, Status: dikiv1alpha1.ConditionTrue, Reason: "ComplianceScanCompleted"},
+				},
+				Rulesets: []dikiv1alpha1.RulesetSummary{{ID: "security-hardened-k8s", Version: "v0.1.0"}},
+			},
+		}
+		Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, namespaceScan)).To(Succeed())
+		Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, complianceScan)).To(Succeed())
+
+		_, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		Expect(namespaceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanCompleted))
+		Expect(namespaceScan.Status.Conditions).To(Equal(complianceScan.Status.Conditions))
+		Expect(namespaceScan.Status.Rulesets).To(Equal(complianceScan.Status.Rulesets))
+	})
+
+	It("should set the phase to Failed when the ComplianceScan has been deleted", func() {
+		namespaceScan.Finalizers = []string{"diki.gardener.cloud/namespacecompliancescan"}
+		namespaceScan.Status.ComplianceScanName = "nscan-1"
+		namespaceScan.Status.Phase = dikiv1alpha1.ComplianceScanRunning
+		Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+		Expect(fakeClient.Status().Update(ctx, namespaceScan)).To(Succeed())
+
+		_, err := cr.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		Expect(namespaceScan.Status.Phase).To(Equal(dikiv1alpha1.ComplianceScanFailed))
+		Expect(namespaceScan.Status.Conditions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Reason":  Equal("ComplianceScanDeleted"),
+			"Message": Equal("NamespaceComplianceScan failed with error: ComplianceScan nscan-1 has been deleted"),
+		})))
+	})
+
+	Describe("deletion", func() {
+		var (
+			complianceScan *dikiv1alpha1.ComplianceScan
+			reportOutput   *dikiv1alpha1.ReportOutput
+		)
+
+		BeforeEach(func() {
+			namespaceScan.Finalizers = []string{"diki.gardener.cloud/namespacecompliancescan"}
+			complianceScan = &dikiv1alpha1.ComplianceScan{ObjectMeta: metav1.ObjectMeta{Name: "nscan-1"}}
+			reportOutput = &dikiv1alpha1.ReportOutput{ObjectMeta: metav1.ObjectMeta{Name: "nscan-1"}}
+
+			Expect(fakeClient.Create(ctx, namespaceScan)).To(Succeed())
+			Expect(fakeClient.Create(ctx, reportOutput)).To(Succeed())
+			Expect(fakeClient.Delete(ctx, namespaceScan)).To(Succeed())
+		})
+
+		It("should delete the ComplianceScan and wait until it is gone", func() {
+			complianceScan.Finalizers = []string{"diki.gardener.cloud/compliancescan"}
+			Expect(fakeClient.Create(ctx, complianceScan)).To(Succeed())
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, scanKey, complianceScan)).To(Succeed())
+			Expect(complianceScan.DeletionTimestamp).NotTo(BeNil())
+			Expect(fakeClient.Get(ctx, scanKey, reportOutput)).To(Succeed())
+			Expect(fakeClient.Get(ctx, request.NamespacedName, namespaceScan)).To(Succeed())
+		})
+
+		It("should delete the ReportOutput and remove the finalizer when the ComplianceScan is gone", func() {
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fakeClient.Get(ctx, scanKey, reportOutput)
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+			err = fakeClient.Get(ctx, request.NamespacedName, namespaceScan)
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+		})
+	})
+})
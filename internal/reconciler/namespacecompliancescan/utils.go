@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/diki-operator/internal/constants"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+	v1alpha1helper "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1/helper"
+)
+
+func isFinished(namespaceScan *v1alpha1.NamespaceComplianceScan) bool {
+	return namespaceScan.Status.Phase == v1alpha1.ComplianceScanCompleted ||
+		namespaceScan.Status.Phase == v1alpha1.ComplianceScanFailed
+}
+
+// complianceScanName returns the name of the ComplianceScan and the ReportOutput of the NamespaceComplianceScan.
+// It is derived from the UID, because ComplianceScans are cluster-scoped and NamespaceComplianceScans of
+// different namespaces may have the same name.
+func complianceScanName(namespaceScan *v1alpha1.NamespaceComplianceScan) string {
+	return ComplianceScanNamePrefix + string(namespaceScan.UID)
+}
+
+func getLabels(namespaceScan *v1alpha1.NamespaceComplianceScan) map[string]string {
+	return map[string]string{
+		constants.LabelNamespaceComplianceScanName:      namespaceScan.Name,
+		constants.LabelNamespaceComplianceScanNamespace: namespaceScan.Namespace,
+		constants.LabelNamespaceComplianceScanUID:       string(namespaceScan.UID),
+		constants.LabelAppName:                          constants.LabelValueDiki,
+		constants.LabelAppManagedBy:                     constants.LabelValueDikiOperator,
+	}
+}
+
+// deployComplianceScan creates the ComplianceScan which runs the NamespaceComplianceScan restricted to its namespace.
+// If an output is configured, a ReportOutput exporting to the namespace is created beforehand.
+func (r *Reconciler) deployComplianceScan(ctx context.Context, namespaceScan *v1alpha1.NamespaceComplianceScan, log logr.Logger) (*v1alpha1.ComplianceScan, error) {
+	name := complianceScanName(namespaceScan)
+
+	complianceScan := &v1alpha1.ComplianceScan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: getLabels(namespaceScan),
+		},
+		Spec: v1alpha1.ComplianceScanSpec{
+			Rulesets:        namespaceScan.DeepCopy().Spec.Rulesets,
+			RunnerProfile:   r.Config.RunnerProfile,
+			TargetNamespace: namespaceScan.Namespace,
+		},
+	}
+
+	if output := namespaceScan.Spec.Output; output != nil {
+		reportOutput := &v1alpha1.ReportOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: getLabels(namespaceScan),
+			},
+			Spec: v1alpha1.ReportOutputSpec{
+				Output: v1alpha1.Output{
+					ConfigMap: &v1alpha1.OutputConfigMap{
+						Namespace:  namespaceScan.Namespace,
+						NamePrefix: output.NamePrefix,
+						Retention:  output.Retention.DeepCopy(),
+					},
+				},
+			},
+		}
+
+		if err := r.Client.Create(ctx, reportOutput); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create ReportOutput: %w", err)
+		}
+		log.Info("Created ReportOutput", "reportOutput", reportOutput.Name)
+
+		complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: reportOutput.Name, DeletionPolicy: v1alpha1.DeletionPolicyRetain}}
+	}
+
+	if err := r.Client.Create(ctx, complianceScan); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create ComplianceScan: %w", err)
+	}
+
+	return complianceScan, nil
+}
+
+func (r *Reconciler) setComplianceScanName(ctx context.Context, namespaceScan *v1alpha1.NamespaceComplianceScan, name string) error {
+	patch := client.MergeFrom(namespaceScan.DeepCopy())
+	namespaceScan.Status.ComplianceScanName = name
+	namespaceScan.Status.Phase = v1alpha1.ComplianceScanPending
+	if err := r.Client.Status().Patch(ctx, namespaceScan, patch); err != nil {
+		return fmt.Errorf("failed to update NamespaceComplianceScan status: %w", err)
+	}
+	return nil
+}
+
+// updateStatus mirrors the status of the ComplianceScan to the NamespaceComplianceScan.
+func (r *Reconciler) updateStatus(ctx context.Context, namespaceScan *v1alpha1.NamespaceComplianceScan, complianceScan *v1alpha1.ComplianceScan, log logr.Logger) error {
+	oldStatus := namespaceScan.Status.DeepCopy()
+	patch := client.MergeFrom(namespaceScan.DeepCopy())
+
+	if complianceScan.Status.Phase != "" {
+		namespaceScan.Status.Phase = complianceScan.Status.Phase
+	}
+	namespaceScan.Status.Conditions = slices.Clone(complianceScan.Status.Conditions)
+	namespaceScan.Status.Rulesets = slices.Clone(complianceScan.Status.Rulesets)
+	namespaceScan.Status.Outputs = slices.Clone(complianceScan.Status.Outputs)
+
+	if apiequality.Semantic.DeepEqual(oldStatus, &namespaceScan.Status) {
+		return nil
+	}
+
+	if err := r.Client.Status().Patch(ctx, namespaceScan, patch); err != nil {
+		return fmt.Errorf("failed to update NamespaceComplianceScan status: %w", err)
+	}
+
+	log.Info("Updated NamespaceComplianceScan status", "phase", namespaceScan.Status.Phase)
+	return nil
+}
+
+func (r *Reconciler) patchFailed(ctx context.Context, namespaceScan *v1alpha1.NamespaceComplianceScan, reason string, err error, log logr.Logger) error {
+	patch := client.MergeFrom(namespaceScan.DeepCopy())
+	namespaceScan.Status.Phase = v1alpha1.ComplianceScanFailed
+	namespaceScan.Status.Conditions = v1alpha1helper.UpdateConditions(
+		namespaceScan.Status.Conditions,
+		v1alpha1.ConditionTypeFailed,
+		v1alpha1.ConditionTrue,
+		reason,
+		fmt.Sprintf("NamespaceComplianceScan failed with error: %s", err.Error()),
+		r.Clock.Now(),
+	)
+
+	if err2 := r.Client.Status().Patch(ctx, namespaceScan, patch); err2 != nil {
+		return fmt.Errorf("failed to update NamespaceComplianceScan status to Failed: %w, original error: %w", err2, err)
+	}
+
+	log.Info("Updated NamespaceComplianceScan phase to Failed", "error", err.Error())
+	return nil
+}
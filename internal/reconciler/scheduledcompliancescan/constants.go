@@ -5,10 +5,16 @@
 package reconciler
 
 const (
-	// LabelScheduledComplianceScanName is the label used to identify ComplianceScans
-	// created by a specific ScheduledComplianceScan by name.
-	LabelScheduledComplianceScanName = "scheduledcompliancescan.diki.gardener.cloud/name"
-	// LabelScheduledComplianceScanUID is the label used to identify ComplianceScans
-	// created by a specific ScheduledComplianceScan by UID.
-	LabelScheduledComplianceScanUID = "scheduledcompliancescan.diki.gardener.cloud/uid"
+	// ConditionReasonScheduleValid is the reason for the ScheduleValid condition when the schedule is a valid cron expression.
+	ConditionReasonScheduleValid = "ScheduleValid"
+	// ConditionReasonInvalidSchedule is the reason for the ScheduleValid condition when the schedule cannot be parsed.
+	ConditionReasonInvalidSchedule = "InvalidSchedule"
+	// ConditionReasonComplianceScanCompleted is the reason for the LastRunSucceeded condition when the last finished ComplianceScan has completed.
+	ConditionReasonComplianceScanCompleted = "ComplianceScanCompleted"
+	// ConditionReasonComplianceScanFailed is the reason for the LastRunSucceeded condition when the last finished ComplianceScan has failed.
+	ConditionReasonComplianceScanFailed = "ComplianceScanFailed"
+	// ConditionReasonSuspended is the reason for the Suspended condition when scheduling is suspended.
+	ConditionReasonSuspended = "Suspended"
+	// ConditionReasonActive is the reason for the Suspended condition when scheduling is active.
+	ConditionReasonActive = "Active"
 )
@@ -18,6 +18,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/gardener/diki-operator/internal/constants"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
@@ -25,6 +27,7 @@ import (
 type Reconciler struct {
 	Client client.Client
 	Clock  clock.Clock
+	Config configv1alpha1.ScheduledComplianceScanConfig
 }
 
 // Reconcile handles reconciliation requests for ScheduledComplianceScan resources.
@@ -42,8 +45,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	childScans := &v1alpha1.ComplianceScanList{}
 	if err := r.Client.List(ctx, childScans, client.MatchingLabels{
-		LabelScheduledComplianceScanName: scheduledScan.Name,
-		LabelScheduledComplianceScanUID:  string(scheduledScan.UID),
+		constants.LabelScheduledComplianceScanName: scheduledScan.Name,
+		constants.LabelScheduledComplianceScanUID:  string(scheduledScan.UID),
 	}); err != nil {
 		return reconcile.Result{}, fmt.Errorf("error listing child ComplianceScans: %w", err)
 	}
@@ -64,6 +67,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	now := r.Clock.Now()
 
+	expr, scheduleErr := ParseCronScheduleWithPanicRecovery(scheduledScan.Spec.Schedule)
+	if err := r.updateConditions(ctx, scheduledScan, scheduleErr, successfulScans, failedScans, now); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Detect active scan completion: if status references an active scan but it is now finished.
 	if scheduledScan.Status.Active != nil && activeScan == nil {
 		patch := client.MergeFrom(scheduledScan.DeepCopy())
@@ -90,9 +98,15 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{RequeueAfter: 1 * time.Minute}, nil
 	}
 
-	expr, err := ParseCronScheduleWithPanicRecovery(scheduledScan.Spec.Schedule)
-	if err != nil {
-		log.Error(err, "Invalid cron expression", "schedule", scheduledScan.Spec.Schedule)
+	if scheduleErr != nil {
+		log.Error(scheduleErr, "Invalid cron expression", "schedule", scheduledScan.Spec.Schedule)
+		return reconcile.Result{}, nil
+	}
+
+	if ptr.Deref(scheduledScan.Spec.Suspend, false) {
+		log.Info("ScheduledComplianceScan is suspended, skipping scheduling of new ComplianceScans")
+		r.cleanupOldScans(ctx, log, successfulScans, int(ptr.Deref(scheduledScan.Spec.SuccessfulScansHistoryLimit, 0)))
+		r.cleanupOldScans(ctx, log, failedScans, int(ptr.Deref(scheduledScan.Spec.FailedScansHistoryLimit, 0)))
 		return reconcile.Result{}, nil
 	}
 
@@ -419,4 +419,164 @@ var _ = Describe("Controller", func() {
 			))
 		})
 	})
+
+	Context("conditions", func() {
+		getCondition := func(conditionType dikiv1alpha1.ConditionType) *dikiv1alpha1.Condition {
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: scheduledScan.Name}, scheduledScan)).To(Succeed())
+			for i := range scheduledScan.Status.Conditions {
+				if scheduledScan.Status.Conditions[i].Type == conditionType {
+					return &scheduledScan.Status.Conditions[i]
+				}
+			}
+			return nil
+		}
+
+		It("should set the ScheduleValid and Suspended conditions", func() {
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduleValid := getCondition(dikiv1alpha1.ConditionTypeScheduleValid)
+			Expect(scheduleValid).NotTo(BeNil())
+			Expect(scheduleValid.Status).To(Equal(dikiv1alpha1.ConditionTrue))
+			Expect(scheduleValid.Reason).To(Equal(scheduledcompliancescan.ConditionReasonScheduleValid))
+
+			suspended := getCondition(dikiv1alpha1.ConditionTypeSuspended)
+			Expect(suspended).NotTo(BeNil())
+			Expect(suspended.Status).To(Equal(dikiv1alpha1.ConditionFalse))
+			Expect(suspended.Reason).To(Equal(scheduledcompliancescan.ConditionReasonActive))
+
+			Expect(getCondition(dikiv1alpha1.ConditionTypeLastRunSucceeded)).To(BeNil())
+		})
+
+		It("should set the ScheduleValid condition to False and not create a ComplianceScan when the schedule is invalid", func() {
+			scheduledScan.Spec.Schedule = "invalid"
+			Expect(fakeClient.Update(ctx, scheduledScan)).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{}))
+
+			scheduleValid := getCondition(dikiv1alpha1.ConditionTypeScheduleValid)
+			Expect(scheduleValid).NotTo(BeNil())
+			Expect(scheduleValid.Status).To(Equal(dikiv1alpha1.ConditionFalse))
+			Expect(scheduleValid.Reason).To(Equal(scheduledcompliancescan.ConditionReasonInvalidSchedule))
+			Expect(scheduleValid.LastTransitionTime.Time).To(BeTemporally("==", baseTime))
+
+			childScans := &dikiv1alpha1.ComplianceScanList{}
+			Expect(fakeClient.List(ctx, childScans)).To(Succeed())
+			Expect(childScans.Items).To(BeEmpty())
+		})
+
+		It("should set the Suspended condition and not create a ComplianceScan when suspended", func() {
+			scheduledScan.Spec.Suspend = ptr.To(true)
+			Expect(fakeClient.Update(ctx, scheduledScan)).To(Succeed())
+
+			res, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{}))
+
+			suspended := getCondition(dikiv1alpha1.ConditionTypeSuspended)
+			Expect(suspended).NotTo(BeNil())
+			Expect(suspended.Status).To(Equal(dikiv1alpha1.ConditionTrue))
+			Expect(suspended.Reason).To(Equal(scheduledcompliancescan.ConditionReasonSuspended))
+			Expect(scheduledScan.Status.LastScheduleTime).To(BeNil())
+
+			childScans := &dikiv1alpha1.ComplianceScanList{}
+			Expect(fakeClient.List(ctx, childScans)).To(Succeed())
+			Expect(childScans.Items).To(BeEmpty())
+		})
+
+		It("should set the LastRunSucceeded condition based on the most recently finished ComplianceScan", func() {
+			scheduledScan.Status.LastScheduleTime = &metav1.Time{Time: baseTime}
+			Expect(fakeClient.Status().Update(ctx, scheduledScan)).To(Succeed())
+
+			for _, s := range []struct {
+				suffix string
+				offset time.Duration
+				phase  dikiv1alpha1.ComplianceScanPhase
+			}{
+				{"-s-old", -3 * time.Hour, dikiv1alpha1.ComplianceScanCompleted},
+				{"-f-new", -1 * time.Hour, dikiv1alpha1.ComplianceScanFailed},
+			} {
+				Expect(fakeClient.Create(ctx, &dikiv1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              scheduledScan.Name + s.suffix,
+						CreationTimestamp: metav1.Time{Time: baseTime.Add(s.offset)},
+						Labels: map[string]string{
+							"scheduledcompliancescan.diki.gardener.cloud/name": scheduledScan.Name,
+							"scheduledcompliancescan.diki.gardener.cloud/uid":  string(scheduledScan.UID),
+						},
+					},
+					Status: dikiv1alpha1.ComplianceScanStatus{Phase: s.phase},
+				})).To(Succeed())
+			}
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			lastRunSucceeded := getCondition(dikiv1alpha1.ConditionTypeLastRunSucceeded)
+			Expect(lastRunSucceeded).NotTo(BeNil())
+			Expect(lastRunSucceeded.Status).To(Equal(dikiv1alpha1.ConditionFalse))
+			Expect(lastRunSucceeded.Reason).To(Equal(scheduledcompliancescan.ConditionReasonComplianceScanFailed))
+			Expect(lastRunSucceeded.Message).To(ContainSubstring(scheduledScan.Name + "-f-new"))
+		})
+
+		It("should not overwrite the LastRunSucceeded condition with an older ComplianceScan after the last one was pruned", func() {
+			scheduledScan.Spec.SuccessfulScansHistoryLimit = ptr.To(int32(1))
+			scheduledScan.Spec.FailedScansHistoryLimit = ptr.To(int32(0))
+			Expect(fakeClient.Update(ctx, scheduledScan)).To(Succeed())
+			scheduledScan.Status.LastScheduleTime = &metav1.Time{Time: baseTime}
+			scheduledScan.Status.Active = &corev1.ObjectReference{Name: scheduledScan.Name + "-f-new"}
+			Expect(fakeClient.Status().Update(ctx, scheduledScan)).To(Succeed())
+
+			for _, s := range []struct {
+				suffix string
+				offset time.Duration
+				phase  dikiv1alpha1.ComplianceScanPhase
+			}{
+				{"-s-old", -3 * time.Hour, dikiv1alpha1.ComplianceScanCompleted},
+				{"-f-new", -1 * time.Hour, dikiv1alpha1.ComplianceScanFailed},
+			} {
+				Expect(fakeClient.Create(ctx, &dikiv1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              scheduledScan.Name + s.suffix,
+						CreationTimestamp: metav1.Time{Time: baseTime.Add(s.offset)},
+						Labels: map[string]string{
+							"scheduledcompliancescan.diki.gardener.cloud/name": scheduledScan.Name,
+							"scheduledcompliancescan.diki.gardener.cloud/uid":  string(scheduledScan.UID),
+						},
+					},
+					Status: dikiv1alpha1.ComplianceScanStatus{Phase: s.phase},
+				})).To(Succeed())
+			}
+
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fakeClient.Get(ctx, client.ObjectKey{Name: scheduledScan.Name + "-f-new"}, &dikiv1alpha1.ComplianceScan{})
+			Expect(err).To(HaveOccurred())
+			Expect(client.IgnoreNotFound(err)).To(Succeed())
+
+			fakeClock.Step(time.Hour)
+			_, err = cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			lastRunSucceeded := getCondition(dikiv1alpha1.ConditionTypeLastRunSucceeded)
+			Expect(lastRunSucceeded).NotTo(BeNil())
+			Expect(lastRunSucceeded.Status).To(Equal(dikiv1alpha1.ConditionFalse))
+			Expect(lastRunSucceeded.Message).To(ContainSubstring(scheduledScan.Name + "-f-new"))
+		})
+
+		It("should not change the conditions when nothing changed", func() {
+			_, err := cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			oldCondition := getCondition(dikiv1alpha1.ConditionTypeScheduleValid).DeepCopy()
+
+			fakeClock.Step(time.Hour)
+			_, err = cr.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(getCondition(dikiv1alpha1.ConditionTypeScheduleValid)).To(Equal(oldCondition))
+		})
+	})
 })
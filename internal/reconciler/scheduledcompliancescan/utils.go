@@ -14,6 +14,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
@@ -21,8 +22,105 @@ import (
 
 	"github.com/gardener/diki-operator/internal/constants"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+	v1alpha1helper "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1/helper"
 )
 
+// updateConditions computes the conditions of the ScheduledComplianceScan and patches its status if any of them changed.
+func (r *Reconciler) updateConditions(
+	ctx context.Context,
+	scheduledScan *v1alpha1.ScheduledComplianceScan,
+	scheduleErr error,
+	successfulScans, failedScans []v1alpha1.ComplianceScan,
+	now time.Time,
+) error {
+	oldStatus := scheduledScan.Status.DeepCopy()
+	patch := client.MergeFrom(scheduledScan.DeepCopy())
+
+	conditions := slices.Clone(scheduledScan.Status.Conditions)
+	if scheduleErr != nil {
+		conditions = v1alpha1helper.UpdateConditions(
+			conditions, v1alpha1.ConditionTypeScheduleValid, v1alpha1.ConditionFalse,
+			ConditionReasonInvalidSchedule, scheduleErr.Error(), now,
+		)
+	} else {
+		conditions = v1alpha1helper.UpdateConditions(
+			conditions, v1alpha1.ConditionTypeScheduleValid, v1alpha1.ConditionTrue,
+			ConditionReasonScheduleValid, fmt.Sprintf("Schedule %q is a valid cron expression.", scheduledScan.Spec.Schedule), now,
+		)
+	}
+
+	if ptr.Deref(scheduledScan.Spec.Suspend, false) {
+		conditions = v1alpha1helper.UpdateConditions(
+			conditions, v1alpha1.ConditionTypeSuspended, v1alpha1.ConditionTrue,
+			ConditionReasonSuspended, "Scheduling of new ComplianceScans is suspended.", now,
+		)
+	} else {
+		conditions = v1alpha1helper.UpdateConditions(
+			conditions, v1alpha1.ConditionTypeSuspended, v1alpha1.ConditionFalse,
+			ConditionReasonActive, "Scheduling of new ComplianceScans is active.", now,
+		)
+	}
+
+	if lastScan := lastRunScan(scheduledScan, conditions, successfulScans, failedScans); lastScan != nil {
+		if lastScan.Status.Phase == v1alpha1.ComplianceScanCompleted {
+			conditions = v1alpha1helper.UpdateConditions(
+				conditions, v1alpha1.ConditionTypeLastRunSucceeded, v1alpha1.ConditionTrue,
+				ConditionReasonComplianceScanCompleted, fmt.Sprintf("ComplianceScan %q has completed successfully.", lastScan.Name), now,
+			)
+		} else {
+			conditions = v1alpha1helper.UpdateConditions(
+				conditions, v1alpha1.ConditionTypeLastRunSucceeded, v1alpha1.ConditionFalse,
+				ConditionReasonComplianceScanFailed, fmt.Sprintf("ComplianceScan %q has failed.", lastScan.Name), now,
+			)
+		}
+	}
+
+	scheduledScan.Status.Conditions = conditions
+	if apiequality.Semantic.DeepEqual(oldStatus, &scheduledScan.Status) {
+		return nil
+	}
+
+	if err := r.Client.Status().Patch(ctx, scheduledScan, patch); err != nil {
+		return fmt.Errorf("failed to update ScheduledComplianceScan conditions: %w", err)
+	}
+	return nil
+}
+
+// lastRunScan returns the finished ComplianceScan whose result should be reported by the LastRunSucceeded condition.
+// Finished scans are pruned according to the history limits, hence once the condition is set it is only updated by the
+// scan referenced as active. Otherwise, an older retained scan could overwrite the result of a newer, pruned one.
+func lastRunScan(scheduledScan *v1alpha1.ScheduledComplianceScan, conditions []v1alpha1.Condition, successfulScans, failedScans []v1alpha1.ComplianceScan) *v1alpha1.ComplianceScan {
+	if scheduledScan.Status.Active != nil {
+		for _, scans := range [][]v1alpha1.ComplianceScan{successfulScans, failedScans} {
+			for i := range scans {
+				if scans[i].Name == scheduledScan.Status.Active.Name {
+					return &scans[i]
+				}
+			}
+		}
+	}
+
+	if slices.ContainsFunc(conditions, func(c v1alpha1.Condition) bool {
+		return c.Type == v1alpha1.ConditionTypeLastRunSucceeded
+	}) {
+		return nil
+	}
+	return lastFinishedScan(successfulScans, failedScans)
+}
+
+// lastFinishedScan returns the most recently created ComplianceScan out of the given finished scans.
+func lastFinishedScan(successfulScans, failedScans []v1alpha1.ComplianceScan) *v1alpha1.ComplianceScan {
+	var lastScan *v1alpha1.ComplianceScan
+	for _, scans := range [][]v1alpha1.ComplianceScan{successfulScans, failedScans} {
+		for i := range scans {
+			if lastScan == nil || lastScan.CreationTimestamp.Before(&scans[i].CreationTimestamp) {
+				lastScan = &scans[i]
+			}
+		}
+	}
+	return lastScan
+}
+
 func (r *Reconciler) setActiveScan(ctx context.Context, scheduledScan *v1alpha1.ScheduledComplianceScan, scan *v1alpha1.ComplianceScan, scheduleTime time.Time) error {
 	patch := client.MergeFrom(scheduledScan.DeepCopy())
 	scheduledScan.Status.Active = &corev1.ObjectReference{
@@ -43,10 +141,10 @@ func (r *Reconciler) deployComplianceScan(ctx context.Context, parent *v1alpha1.
 		ObjectMeta: metav1.ObjectMeta{
 			Name: childScanName(parent.Name, now),
 			Labels: map[string]string{
-				LabelScheduledComplianceScanName: parent.Name,
-				LabelScheduledComplianceScanUID:  string(parent.UID),
-				constants.LabelAppName:           constants.LabelValueDiki,
-				constants.LabelAppManagedBy:      constants.LabelValueDikiOperator,
+				constants.LabelScheduledComplianceScanName: parent.Name,
+				constants.LabelScheduledComplianceScanUID:  string(parent.UID),
+				constants.LabelAppName:                     constants.LabelValueDiki,
+				constants.LabelAppManagedBy:                constants.LabelValueDikiOperator,
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -8,25 +8,42 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/diki-operator/internal/config"
 )
 
 const (
 	// HandlerName is the name of this admission webhook handler.
 	HandlerName = "compliancescan"
-	// WebhookPath is the HTTP handler path for this admission webhook handler.
-	WebhookPath = "/webhooks/compliancescan"
+	// ValidatingWebhookPath is the HTTP handler path for the validating admission webhook.
+	ValidatingWebhookPath = "/webhooks/compliancescan/validate"
+	// MutatingWebhookPath is the HTTP handler path for the mutating admission webhook.
+	MutatingWebhookPath = "/webhooks/compliancescan/mutate"
 )
 
-// AddToManager adds Handler to the given manager.
-func AddToManager(mgr manager.Manager) error {
-	webhook := &admission.Webhook{
-		Handler: &Handler{
-			Client:  mgr.GetClient(),
-			Decoder: admission.NewDecoder(mgr.GetScheme()),
+// AddToManager registers the validating and mutating webhook handlers with the given manager.
+// The references of requests of the given operator username are not authorized.
+func AddToManager(mgr manager.Manager, configStore *config.Store, operatorUsername string) error {
+	decoder := admission.NewDecoder(mgr.GetScheme())
+
+	mgr.GetWebhookServer().Register(ValidatingWebhookPath, &admission.Webhook{
+		Handler: &ValidatingHandler{
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			Decoder:          decoder,
+			ConfigStore:      configStore,
+			OperatorUsername: operatorUsername,
+		},
+		RecoverPanic: ptr.To(true),
+	})
+
+	mgr.GetWebhookServer().Register(MutatingWebhookPath, &admission.Webhook{
+		Handler: &MutatingHandler{
+			Decoder:     decoder,
+			ConfigStore: configStore,
 		},
 		RecoverPanic: ptr.To(true),
-	}
+	})
 
-	mgr.GetWebhookServer().Register(WebhookPath, webhook)
 	return nil
 }
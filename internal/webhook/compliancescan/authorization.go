@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compliancescan
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// AuthorizeReferences validates that the user creating a ComplianceScan is allowed to read the referenced option
+// ConfigMaps and to create ConfigMaps in the namespaces of the referenced ReportOutputs. Otherwise, the user could
+// use the permissions of the operator to read or write ConfigMaps in any namespace. Requests of the operator itself
+// are not checked, as it only creates ComplianceScans on behalf of resources which have been authorized already.
+// The ReportOutputs are read with the given reader, references to ReportOutputs which cannot be read are denied.
+func AuthorizeReferences(ctx context.Context, c client.Client, reader client.Reader, userInfo authenticationv1.UserInfo, operatorUsername string, spec *dikiv1alpha1.ComplianceScanSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if operatorUsername != "" && userInfo.Username == operatorUsername {
+		return allErrs
+	}
+
+	for rIdx, ruleset := range spec.Rulesets {
+		if ruleset.Options == nil {
+			continue
+		}
+
+		optionsPath := fldPath.Child("rulesets").Index(rIdx).Child("options")
+		for _, options := range []struct {
+			options *dikiv1alpha1.Options
+			fldPath *field.Path
+		}{
+			{options: ruleset.Options.Ruleset, fldPath: optionsPath.Child("ruleset", "configMapRef")},
+			{options: ruleset.Options.Rules, fldPath: optionsPath.Child("rules", "configMapRef")},
+		} {
+			if options.options == nil || options.options.ConfigMapRef == nil {
+				continue
+			}
+
+			configMapRef := options.options.ConfigMapRef
+			allErrs = append(allErrs, authorize(ctx, c, userInfo, &authorizationv1.ResourceAttributes{
+				Namespace: configMapRef.Namespace,
+				Verb:      "get",
+				Resource:  "configmaps",
+				Name:      configMapRef.Name,
+			}, options.fldPath)...)
+		}
+	}
+
+	for i, output := range spec.Outputs {
+		outputPath := fldPath.Child("outputs").Index(i).Child("name")
+
+		reportOutput := &dikiv1alpha1.ReportOutput{}
+		if err := reader.Get(ctx, client.ObjectKey{Name: output.Name}, reportOutput); err != nil {
+			if apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.Forbidden(outputPath, fmt.Sprintf("ReportOutput %q does not exist, hence the reference cannot be authorized", output.Name)))
+				continue
+			}
+			allErrs = append(allErrs, field.InternalError(outputPath, err))
+			continue
+		}
+
+		if configMap := reportOutput.Spec.Output.ConfigMap; configMap != nil {
+			allErrs = append(allErrs, authorize(ctx, c, userInfo, &authorizationv1.ResourceAttributes{
+				Namespace: configMap.Namespace,
+				Verb:      "create",
+				Resource:  "configmaps",
+			}, outputPath)...)
+		}
+	}
+
+	return allErrs
+}
+
+// authorize performs a SubjectAccessReview for the given user and resource attributes.
+func authorize(ctx context.Context, c client.Client, userInfo authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes, fldPath *field.Path) field.ErrorList {
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               userInfo.Username,
+			Groups:             userInfo.Groups,
+			UID:                userInfo.UID,
+			Extra:              extra,
+		},
+	}
+
+	if err := c.Create(ctx, review); err != nil {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("failed to create SubjectAccessReview: %w", err))}
+	}
+
+	if !review.Status.Allowed {
+		return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf("user %q is not allowed to %s configmaps in namespace %s", userInfo.Username, attributes.Verb, attributes.Namespace))}
+	}
+
+	return nil
+}
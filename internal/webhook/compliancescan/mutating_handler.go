@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compliancescan
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/securityhardenedk8s"
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/diki-operator/internal/config"
+	compscanreconciler "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// MutatingHandler is an admission webhook handler that sets defaults on ComplianceScan resources.
+type MutatingHandler struct {
+	Decoder admission.Decoder
+	Config  configv1alpha1.ComplianceScanConfig
+	// ConfigStore holds the configuration if it can be reloaded at runtime. Config is used if it is nil.
+	ConfigStore *config.Store
+}
+
+var _ admission.Handler = &MutatingHandler{}
+
+// latestRulesetVersions maps the IDs of the supported rulesets to their latest version.
+var latestRulesetVersions = map[string]string{
+	disak8sstig.RulesetID:         disak8sstig.SupportedVersions[0],
+	securityhardenedk8s.RulesetID: securityhardenedk8s.SupportedVersions[0],
+}
+
+// Handle sets default values on ComplianceScan resources. Only creations are mutated because the spec of a
+// ComplianceScan cannot be updated.
+func (h *MutatingHandler) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	complianceScan := &dikiv1alpha1.ComplianceScan{}
+	if err := h.Decoder.DecodeRaw(req.Object, complianceScan); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	original := complianceScan.DeepCopy()
+	setDefaults(&complianceScan.Spec, h.getConfig())
+
+	if apiequality.Semantic.DeepEqual(original, complianceScan) {
+		return admission.Allowed("")
+	}
+
+	marshaledScan, err := json.Marshal(complianceScan)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledScan)
+}
+
+func (h *MutatingHandler) getConfig() *configv1alpha1.ComplianceScanConfig {
+	if h.ConfigStore != nil {
+		return h.ConfigStore.Get()
+	}
+
+	return &h.Config
+}
+
+// setDefaults sets the default ruleset versions, options keys and outputs of the given ComplianceScan spec.
+func setDefaults(spec *dikiv1alpha1.ComplianceScanSpec, cfg *configv1alpha1.ComplianceScanConfig) {
+	for i := range spec.Rulesets {
+		ruleset := &spec.Rulesets[i]
+
+		if ruleset.Version == "" {
+			if version, ok := latestRulesetVersions[ruleset.ID]; ok {
+				ruleset.Version = version
+			}
+		}
+
+		if ruleset.Options == nil {
+			continue
+		}
+		if options := ruleset.Options.Ruleset; options != nil && options.ConfigMapRef != nil && options.ConfigMapRef.Key == nil {
+			options.ConfigMapRef.Key = ptr.To(ruleset.ID)
+		}
+		if options := ruleset.Options.Rules; options != nil && options.ConfigMapRef != nil && options.ConfigMapRef.Key == nil {
+			options.ConfigMapRef.Key = ptr.To(ruleset.ID + compscanreconciler.RuleOptionsSuffix)
+		}
+	}
+
+	// The default outputs are not used by compliance scans restricted to a namespace, as they might export to other namespaces.
+	if len(spec.Outputs) == 0 && spec.TargetNamespace == "" {
+		for _, name := range cfg.DefaultReportOutputs {
+			spec.Outputs = append(spec.Outputs, dikiv1alpha1.ReportOutputRef{Name: name})
+		}
+	}
+}
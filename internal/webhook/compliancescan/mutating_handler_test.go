@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compliancescan_test
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/securityhardenedk8s"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/diki-operator/internal/webhook/compliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+var _ = Describe("MutatingHandler", func() {
+	var (
+		ctx = context.TODO()
+
+		scheme  *runtime.Scheme
+		handler *compliancescan.MutatingHandler
+		request admission.Request
+
+		complianceScan *v1alpha1.ComplianceScan
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(authenticationv1.AddToScheme(scheme)).To(Succeed())
+
+		handler = &compliancescan.MutatingHandler{
+			Decoder: admission.NewDecoder(scheme),
+		}
+
+		request = admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+			},
+		}
+
+		complianceScan = &v1alpha1.ComplianceScan{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: v1alpha1.ComplianceScanSpec{
+				Rulesets: []v1alpha1.RulesetConfig{
+					{
+						ID: disak8sstig.RulesetID,
+						Options: &v1alpha1.RulesetOptions{
+							Ruleset: &v1alpha1.Options{
+								ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system"},
+							},
+							Rules: &v1alpha1.Options{
+								ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system"},
+							},
+						},
+					},
+					{
+						ID: securityhardenedk8s.RulesetID,
+					},
+				},
+			},
+		}
+	})
+
+	It("should set all defaults when fields are omitted", func() {
+		handler.Config = configv1alpha1.ComplianceScanConfig{
+			DefaultReportOutputs: []string{"output-one", "output-two"},
+		}
+
+		expected := complianceScan.DeepCopy()
+		expected.Spec.Rulesets[0].Version = disak8sstig.SupportedVersions[0]
+		expected.Spec.Rulesets[0].Options.Ruleset.ConfigMapRef.Key = ptr.To(disak8sstig.RulesetID)
+		expected.Spec.Rulesets[0].Options.Rules.ConfigMapRef.Key = ptr.To(disak8sstig.RulesetID + "-rules")
+		expected.Spec.Rulesets[1].Version = securityhardenedk8s.SupportedVersions[0]
+		expected.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "output-one"}, {Name: "output-two"}}
+
+		resp := handle(ctx, &request, handler, complianceScan)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(ConsistOf(patchResponse(complianceScan, expected).Patches))
+	})
+
+	It("should not override values that are already set", func() {
+		handler.Config = configv1alpha1.ComplianceScanConfig{
+			DefaultReportOutputs: []string{"output-one"},
+		}
+
+		complianceScan.Spec.Rulesets[0].Version = "v2r4"
+		complianceScan.Spec.Rulesets[0].Options.Ruleset.ConfigMapRef.Key = ptr.To("ruleset-key")
+		complianceScan.Spec.Rulesets[0].Options.Rules.ConfigMapRef.Key = ptr.To("rules-key")
+		complianceScan.Spec.Rulesets[1].Version = "v0.1.0"
+		complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "custom-output"}}
+
+		resp := handle(ctx, &request, handler, complianceScan)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(BeEmpty())
+	})
+
+	It("should not default the version of unknown rulesets", func() {
+		complianceScan.Spec.Rulesets = []v1alpha1.RulesetConfig{{ID: "unknown"}}
+
+		resp := handle(ctx, &request, handler, complianceScan)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(BeEmpty())
+	})
+
+	It("should not default the outputs when no default report outputs are configured", func() {
+		complianceScan.Spec.Rulesets = []v1alpha1.RulesetConfig{{ID: disak8sstig.RulesetID, Version: "v2r4"}}
+
+		resp := handle(ctx, &request, handler, complianceScan)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(BeEmpty())
+	})
+
+	It("should not default the outputs of ComplianceScans restricted to a namespace", func() {
+		handler.Config = configv1alpha1.ComplianceScanConfig{
+			DefaultReportOutputs: []string{"output-one"},
+		}
+		complianceScan.Spec.Rulesets = []v1alpha1.RulesetConfig{{ID: disak8sstig.RulesetID, Version: "v2r4"}}
+		complianceScan.Spec.TargetNamespace = "tenant"
+
+		resp := handle(ctx, &request, handler, complianceScan)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(BeEmpty())
+	})
+
+	It("should not mutate ComplianceScans on update", func() {
+		request.Operation = admissionv1.Update
+
+		resp := handle(ctx, &request, handler, complianceScan)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(BeEmpty())
+	})
+})
+
+func handle(ctx context.Context, request *admission.Request, handler admission.Handler, scan *v1alpha1.ComplianceScan) admission.Response {
+	raw, err := json.Marshal(scan)
+	Expect(err).ToNot(HaveOccurred())
+	request.Object.Raw = raw
+
+	return handler.Handle(ctx, *request)
+}
+
+func patchResponse(original, mutated *v1alpha1.ComplianceScan) admission.Response {
+	originalJSON, err := json.Marshal(original)
+	Expect(err).ToNot(HaveOccurred())
+	mutatedJSON, err := json.Marshal(mutated)
+	Expect(err).ToNot(HaveOccurred())
+
+	return admission.PatchResponseFromRaw(originalJSON, mutatedJSON)
+}
@@ -0,0 +1,407 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compliancescan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	dikiconfig "github.com/gardener/diki/pkg/config"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/disak8sstig"
+	"github.com/gardener/diki/pkg/provider/managedk8s/ruleset/securityhardenedk8s"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/diki-operator/internal/config"
+	compscanreconciler "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// ValidatingHandler is an admission webhook handler that restricts creation or updates to
+// certain ComplianceScan resources.
+type ValidatingHandler struct {
+	Client client.Client
+	// APIReader reads the referenced ReportOutputs from the API server, so that ReportOutputs which were created right
+	// before the ComplianceScan are found and authorized. Client is used if it is nil.
+	APIReader client.Reader
+	Decoder   admission.Decoder
+	Config    configv1alpha1.ComplianceScanConfig
+	// ConfigStore holds the configuration if it can be reloaded at runtime. Config is used if it is nil.
+	ConfigStore *config.Store
+	// OperatorUsername is the username of the operator. The references of its requests are not authorized.
+	OperatorUsername string
+}
+
+// rulesetConfigValidator validates the configuration of a diki ruleset.
+type rulesetConfigValidator func(rulesetConfig dikiconfig.RulesetConfig, fldPath *field.Path) field.ErrorList
+
+// rulesetValidation describes how the options of a supported ruleset are validated.
+type rulesetValidation struct {
+	versions []string
+	validate rulesetConfigValidator
+}
+
+var (
+	_ admission.Handler = &ValidatingHandler{}
+
+	supportedDeletionPolicies = sets.New(dikiv1alpha1.DeletionPolicyRetain, dikiv1alpha1.DeletionPolicyDelete)
+
+	supportedRulesets = map[string]rulesetValidation{
+		disak8sstig.RulesetID: {
+			versions: disak8sstig.SupportedVersions,
+			validate: disak8sstig.ValidateRulesetConfig,
+		},
+		securityhardenedk8s.RulesetID: {
+			versions: securityhardenedk8s.SupportedVersions,
+			validate: securityhardenedk8s.ValidateRulesetConfig,
+		},
+	}
+)
+
+// Handle handles an admission request for a ComplianceScan resource and restricts updates
+// and creations if it contains references to invalid ConfigMaps.
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	complianceScan := &dikiv1alpha1.ComplianceScan{}
+	if err := h.Decoder.DecodeRaw(req.Object, complianceScan); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if req.Operation == admissionv1.Update {
+		oldComplianceScan := &dikiv1alpha1.ComplianceScan{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldComplianceScan); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !apiequality.Semantic.DeepEqual(oldComplianceScan.Spec, complianceScan.Spec) {
+			return admission.Denied("updating the ComplianceScan spec is not permitted")
+		}
+		return admission.Allowed("")
+	}
+
+	if req.Operation == admissionv1.Create {
+		var (
+			specFieldPath = field.NewPath("spec", "rulesets")
+			cfg           = h.getConfig()
+			allErrs       field.ErrorList
+		)
+
+		if profile := complianceScan.Spec.RunnerProfile; profile != "" {
+			if _, ok := cfg.DikiRunnerProfiles[profile]; !ok {
+				allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "runnerProfile"), profile, configv1alpha1helper.DikiRunnerProfileNames(cfg)))
+			}
+		}
+
+		allErrs = append(allErrs, ValidateImageOverrides(complianceScan.Spec.Image, cfg, field.NewPath("spec", "image"))...)
+		allErrs = append(allErrs, ValidateOutputs(complianceScan.Spec.Outputs, field.NewPath("spec", "outputs"))...)
+		allErrs = append(allErrs, ValidateOutputReferences(ctx, h.getReader(), complianceScan.Spec.Outputs, field.NewPath("spec", "outputs"))...)
+		allErrs = append(allErrs, ValidateTargetNamespace(ctx, h.getReader(), &complianceScan.Spec, field.NewPath("spec"))...)
+		allErrs = append(allErrs, AuthorizeReferences(ctx, h.Client, h.getReader(), req.UserInfo, h.OperatorUsername, &complianceScan.Spec, field.NewPath("spec"))...)
+
+		if complianceScan.Spec.Parallelism != nil && *complianceScan.Spec.Parallelism < 1 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "parallelism"), *complianceScan.Spec.Parallelism, "must be greater than 0"))
+		}
+
+		if ttl := complianceScan.Spec.TTLSecondsAfterFinished; ttl != nil && *ttl < 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "ttlSecondsAfterFinished"), *ttl, "must not be negative"))
+		}
+
+		for rIdx, ruleset := range complianceScan.Spec.Rulesets {
+			var (
+				indexedRulesetPath       = specFieldPath.Index(rIdx)
+				indexedRulesetConfigPath = indexedRulesetPath.Child("options")
+				rulesetOptionsPath       = indexedRulesetConfigPath.Child("ruleset")
+				ruleOptionsPath          = indexedRulesetConfigPath.Child("rules")
+			)
+
+			validation, supported := supportedRulesets[ruleset.ID]
+
+			switch {
+			case ruleset.Version == "":
+				allErrs = append(allErrs, field.Required(indexedRulesetPath.Child("version"), "the version of the ruleset must be set"))
+			case supported && !slices.Contains(validation.versions, ruleset.Version):
+				allErrs = append(allErrs, field.NotSupported(indexedRulesetPath.Child("version"), ruleset.Version, validation.versions))
+				supported = false
+			}
+
+			if ruleset.Options == nil {
+				continue
+			}
+
+			if ruleset.Options.Ruleset != nil && ruleset.Options.Ruleset.ConfigMapRef != nil {
+				data, errs := validateConfigMapReference(ctx, h.Client, ruleset.Options.Ruleset.ConfigMapRef, rulesetOptionsPath)
+				allErrs = append(allErrs, errs...)
+				if supported && len(errs) == 0 {
+					allErrs = append(allErrs, validateRulesetOptions(data, ruleset.Version, validation.validate, rulesetOptionsPath.Child("key").Key(*ruleset.Options.Ruleset.ConfigMapRef.Key))...)
+				}
+			}
+
+			if ruleset.Options.Rules != nil && ruleset.Options.Rules.ConfigMapRef != nil {
+				data, errs := validateConfigMapReference(ctx, h.Client, ruleset.Options.Rules.ConfigMapRef, ruleOptionsPath)
+				allErrs = append(allErrs, errs...)
+				if supported && len(errs) == 0 {
+					allErrs = append(allErrs, validateRuleOptions(data, ruleset.Version, validation.validate, ruleOptionsPath.Child("key").Key(*ruleset.Options.Rules.ConfigMapRef.Key))...)
+				}
+			}
+		}
+
+		if len(allErrs) > 0 {
+			return admission.Denied(allErrs.ToAggregate().Error())
+		}
+		return admission.Allowed("").WithWarnings(OutputWarnings(complianceScan.Spec.Outputs, field.NewPath("spec", "outputs"))...)
+	}
+
+	return admission.Allowed("")
+}
+
+func (h *ValidatingHandler) getConfig() *configv1alpha1.ComplianceScanConfig {
+	if h.ConfigStore != nil {
+		return h.ConfigStore.Get()
+	}
+
+	return &h.Config
+}
+
+func (h *ValidatingHandler) getReader() client.Reader {
+	if h.APIReader != nil {
+		return h.APIReader
+	}
+
+	return h.Client
+}
+
+// ValidateImageOverrides validates that the overridden images belong to the repositories allowed by the operator configuration.
+func ValidateImageOverrides(images *dikiv1alpha1.ImageOverrides, config *configv1alpha1.ComplianceScanConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if images == nil {
+		return allErrs
+	}
+
+	for _, image := range []struct {
+		ref     string
+		fldPath *field.Path
+	}{
+		{ref: images.Diki, fldPath: fldPath.Child("diki")},
+		{ref: images.ReportExporter, fldPath: fldPath.Child("reportExporter")},
+	} {
+		if image.ref == "" {
+			continue
+		}
+
+		if len(config.AllowedImageRepositories) == 0 {
+			allErrs = append(allErrs, field.Forbidden(image.fldPath, "image overrides are not allowed by the operator configuration"))
+			continue
+		}
+
+		allowed, err := configv1alpha1helper.IsImageAllowed(config, image.ref)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(image.fldPath, image.ref, fmt.Sprintf("invalid image reference: %s", err.Error())))
+			continue
+		}
+
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(image.fldPath, fmt.Sprintf("image %q does not belong to any of the allowed repositories: %s", image.ref, strings.Join(config.AllowedImageRepositories, ", "))))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateOutputs validates the report output references of a ComplianceScan.
+func ValidateOutputs(outputs []dikiv1alpha1.ReportOutputRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.New[string]()
+
+	for i, output := range outputs {
+		if names.Has(output.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), output.Name))
+		}
+		names.Insert(output.Name)
+
+		if output.DeletionPolicy != "" && !supportedDeletionPolicies.Has(output.DeletionPolicy) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("deletionPolicy"), output.DeletionPolicy, sets.List(supportedDeletionPolicies)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateOutputReferences validates that the ReportOutputs referenced by a ComplianceScan exist.
+func ValidateOutputReferences(ctx context.Context, c client.Reader, outputs []dikiv1alpha1.ReportOutputRef, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, output := range outputs {
+		if err := c.Get(ctx, client.ObjectKey{Name: output.Name}, &dikiv1alpha1.ReportOutput{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.NotFound(fldPath.Index(i).Child("name"), output.Name))
+				continue
+			}
+			allErrs = append(allErrs, field.InternalError(fldPath.Index(i).Child("name"), err))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateTargetNamespace validates that a ComplianceScan restricted to a namespace is run with a parallelism of 1,
+// only scans rulesets which can be restricted to a namespace and only references options and ReportOutputs within
+// the target namespace.
+func ValidateTargetNamespace(ctx context.Context, c client.Reader, spec *dikiv1alpha1.ComplianceScanSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	targetNamespace := spec.TargetNamespace
+
+	if targetNamespace == "" {
+		return allErrs
+	}
+
+	for _, msg := range validation.IsDNS1123Label(targetNamespace) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetNamespace"), targetNamespace, msg))
+	}
+
+	if spec.Parallelism != nil && *spec.Parallelism > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("parallelism"), "must not be greater than 1 for compliance scans restricted to a namespace"))
+	}
+
+	for rIdx, ruleset := range spec.Rulesets {
+		if !compscanreconciler.NamespaceScopedRulesets.Has(ruleset.ID) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("rulesets").Index(rIdx).Child("id"), ruleset.ID, sets.List(compscanreconciler.NamespaceScopedRulesets)))
+		}
+
+		if ruleset.Options == nil {
+			continue
+		}
+
+		optionsPath := fldPath.Child("rulesets").Index(rIdx).Child("options")
+		for _, options := range []struct {
+			options *dikiv1alpha1.Options
+			fldPath *field.Path
+		}{
+			{options: ruleset.Options.Ruleset, fldPath: optionsPath.Child("ruleset")},
+			{options: ruleset.Options.Rules, fldPath: optionsPath.Child("rules")},
+		} {
+			if options.options == nil || options.options.ConfigMapRef == nil || options.options.ConfigMapRef.Namespace == targetNamespace {
+				continue
+			}
+			allErrs = append(allErrs, field.Forbidden(options.fldPath.Child("configMapRef", "namespace"), fmt.Sprintf("must be the target namespace %s", targetNamespace)))
+		}
+	}
+
+	for i, output := range spec.Outputs {
+		// Missing ReportOutputs are reported by the validation of the output references.
+		reportOutput := &dikiv1alpha1.ReportOutput{}
+		if err := c.Get(ctx, client.ObjectKey{Name: output.Name}, reportOutput); err != nil {
+			continue
+		}
+
+		if configMap := reportOutput.Spec.Output.ConfigMap; configMap != nil && configMap.Namespace != targetNamespace {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("outputs").Index(i).Child("name"),
+				fmt.Sprintf("ReportOutput %q exports to namespace %s outside of the target namespace %s", output.Name, configMap.Namespace, targetNamespace)))
+		}
+	}
+
+	return allErrs
+}
+
+// OutputWarnings returns the admission warnings for the report outputs of a ComplianceScan.
+func OutputWarnings(outputs []dikiv1alpha1.ReportOutputRef, fldPath *field.Path) []string {
+	if len(outputs) == 0 {
+		return []string{fmt.Sprintf("%s: no report outputs are set, the report of the scan will not be exported", fldPath)}
+	}
+
+	return nil
+}
+
+// validateConfigMapReference validates that the referenced ConfigMap exists and contains the referenced key.
+// It returns the value of the referenced key. The key is defaulted by the mutating webhook, hence it is required.
+func validateConfigMapReference(ctx context.Context, c client.Client, configMapRef *dikiv1alpha1.OptionsConfigMapRef, fldPath *field.Path) (string, field.ErrorList) {
+	allErrs := field.ErrorList{}
+	if configMapRef.Key == nil {
+		return "", append(allErrs, field.Required(fldPath.Child("key"), "the key within the configMap must be set"))
+	}
+
+	optionsConfigMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapRef.Name,
+			Namespace: configMapRef.Namespace,
+		},
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(optionsConfigMap), optionsConfigMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", append(allErrs, field.NotFound(fldPath, "the referenced configMap does not exist"))
+		}
+		return "", append(allErrs, field.InternalError(fldPath, err))
+	}
+
+	data, ok := optionsConfigMap.Data[*configMapRef.Key]
+	if !ok {
+		return "", append(allErrs, field.NotFound(fldPath.Child("key"), "the referenced key within the configMap does not exist"))
+	}
+
+	return data, allErrs
+}
+
+// validateRulesetOptions parses the global options of a ruleset and validates them with the validation of the
+// ruleset. The returned errors point into the given ConfigMap key path.
+func validateRulesetOptions(data, version string, validate rulesetConfigValidator, fldPath *field.Path) field.ErrorList {
+	rulesetOptions, err := compscanreconciler.ParseRulesetOptions(data)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse ruleset options: %v", err))}
+	}
+
+	errs := validate(dikiconfig.RulesetConfig{Version: version, Args: rulesetOptions}, nil)
+	return rebaseFieldErrors(errs, "args", fldPath)
+}
+
+// validateRuleOptions parses the rule options of a ruleset and validates them with the validation of the
+// ruleset. The returned errors point into the given ConfigMap key path.
+func validateRuleOptions(data, version string, validate rulesetConfigValidator, fldPath *field.Path) field.ErrorList {
+	ruleOptions, err := compscanreconciler.ParseRuleOptions(data)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("failed to parse rule options: %v", err))}
+	}
+
+	errs := validate(dikiconfig.RulesetConfig{Version: version, RuleOptions: ruleOptions}, nil)
+	return rebaseFieldErrors(errs, "ruleOptions", fldPath)
+}
+
+// rebaseFieldErrors replaces the given root of the error paths returned by a ruleset validation with fldPath.
+// Errors which do not point into root are reported on fldPath, keeping their original path in the detail.
+func rebaseFieldErrors(errs field.ErrorList, root string, fldPath *field.Path) field.ErrorList {
+	var (
+		rebasedErrs = make(field.ErrorList, 0, len(errs))
+		rootPath    = field.NewPath(root).String()
+	)
+
+	for _, err := range errs {
+		rebasedErr := *err
+
+		switch suffix, found := strings.CutPrefix(err.Field, rootPath); {
+		case found && (suffix == "" || strings.HasPrefix(suffix, ".") || strings.HasPrefix(suffix, "[")):
+			rebasedErr.Field = fldPath.String() + suffix
+		default:
+			rebasedErr.Field = fldPath.String()
+			if err.Field != "" {
+				rebasedErr.Detail = strings.TrimSuffix(fmt.Sprintf("%s: %s", err.Field, err.Detail), ": ")
+			}
+		}
+
+		rebasedErrs = append(rebasedErrs, &rebasedErr)
+	}
+
+	return rebasedErrs
+}
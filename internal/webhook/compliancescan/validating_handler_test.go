@@ -0,0 +1,1199 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compliancescan_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	compscanreconciler "github.com/gardener/diki-operator/internal/reconciler/compliancescan"
+	"github.com/gardener/diki-operator/internal/webhook/compliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+var _ = Describe("ValidatingHandler", func() {
+	var (
+		ctx = context.TODO()
+
+		scheme            *runtime.Scheme
+		decoder           admission.Decoder
+		handler           admission.Handler
+		request           admission.Request
+		encoder           runtime.Encoder
+		fakeClient        client.Client
+		reportOutputs     []client.Object
+		oldComplianceScan *v1alpha1.ComplianceScan
+		complianceScan    *v1alpha1.ComplianceScan
+		namespace         *v1.Namespace
+
+		responseAllowed = admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Code: int32(http.StatusOK),
+				},
+			},
+		}
+
+		responseForbidden = admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:   http.StatusForbidden,
+					Reason: metav1.StatusReasonForbidden,
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(authenticationv1.AddToScheme(scheme)).To(Succeed())
+		Expect(v1.AddToScheme(scheme)).To(Succeed())
+		Expect(dikiinstall.AddToScheme(scheme)).To(Succeed())
+
+		reportOutputs = []client.Object{
+			&v1alpha1.ReportOutput{ObjectMeta: metav1.ObjectMeta{Name: "output"}},
+			&v1alpha1.ReportOutput{ObjectMeta: metav1.ObjectMeta{Name: "other-output"}},
+		}
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(reportOutputs...).Build()
+		ctx = context.TODO()
+		decoder = admission.NewDecoder(scheme)
+		handler = &compliancescan.ValidatingHandler{
+			Decoder:          decoder,
+			Client:           fakeClient,
+			OperatorUsername: "diki-operator",
+		}
+
+		encoder = &json.Serializer{}
+		request = admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "diki-operator",
+				},
+				Resource: metav1.GroupVersionResource{
+					Group:    "diki.gardener.cloud",
+					Resource: "ComplianceScan",
+					Version:  "v1alpha1",
+				},
+				Operation: admissionv1.Create,
+			},
+		}
+
+		namespace = &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kube-system",
+			},
+		}
+
+		complianceScan = &v1alpha1.ComplianceScan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "compliance-scan",
+				Namespace: "diki",
+			},
+			Spec: v1alpha1.ComplianceScanSpec{
+				Rulesets: []v1alpha1.RulesetConfig{
+					{
+						ID:      "ruleset-one",
+						Version: "v0.0.0",
+					},
+				},
+				Outputs: []v1alpha1.ReportOutputRef{
+					{Name: "output"},
+				},
+			},
+		}
+
+		oldComplianceScan = complianceScan.DeepCopy()
+	})
+
+	Describe("#Handle", func() {
+		Context("test updating the ComplianceScan resource", func() {
+
+			It("should allow updating the ComplianceScan's metadata", func() {
+				oldComplianceScanObj, err := runtime.Encode(encoder, oldComplianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldComplianceScanObj
+
+				complianceScan.Labels = map[string]string{
+					"foo": "bar",
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				request.Operation = admissionv1.Update
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow updating the ComplianceScan's status field", func() {
+				oldComplianceScan.Status = v1alpha1.ComplianceScanStatus{
+					Phase: v1alpha1.ComplianceScanRunning,
+				}
+				oldComplianceScanObj, err := runtime.Encode(encoder, oldComplianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldComplianceScanObj
+
+				complianceScan.Status = v1alpha1.ComplianceScanStatus{
+					Phase: v1alpha1.ComplianceScanCompleted,
+				}
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				request.Operation = admissionv1.Update
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should deny updating the ComplianceScan's spec field", func() {
+				oldComplianceScan.Spec.Rulesets = append(oldComplianceScan.Spec.Rulesets, v1alpha1.RulesetConfig{
+					ID:      "ruleset-two",
+					Version: "v0.1.0",
+				})
+				oldComplianceScanObj, err := runtime.Encode(encoder, oldComplianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldComplianceScanObj
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+				request.Operation = admissionv1.Update
+
+				responseForbidden.Result.Message = "updating the ComplianceScan spec is not permitted"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+		})
+
+		Context("test creating the ComplianceScan resource", func() {
+			It("should allow creating the ComplianceScan if it has no options configured", func() {
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow creating a ComplianceScan referencing a configured runner profile", func() {
+				handler = &compliancescan.ValidatingHandler{
+					Decoder: decoder,
+					Client:  fakeClient,
+					Config: configv1alpha1.ComplianceScanConfig{
+						DikiRunnerProfiles: map[string]configv1alpha1.DikiRunnerConfig{"foo": {}},
+					},
+				}
+				complianceScan.Spec.RunnerProfile = "foo"
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should forbid creating a ComplianceScan referencing a runner profile that is not configured", func() {
+				handler = &compliancescan.ValidatingHandler{
+					Decoder: decoder,
+					Client:  fakeClient,
+					Config: configv1alpha1.ComplianceScanConfig{
+						DikiRunnerProfiles: map[string]configv1alpha1.DikiRunnerConfig{"bar": {}, "baz": {}},
+					},
+				}
+				complianceScan.Spec.RunnerProfile = "foo"
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.runnerProfile: Unsupported value: \"foo\": supported values: \"bar\", \"baz\""
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should allow creating a ComplianceScan overriding images from allowed repositories", func() {
+				handler = &compliancescan.ValidatingHandler{
+					Decoder: decoder,
+					Client:  fakeClient,
+					Config: configv1alpha1.ComplianceScanConfig{
+						AllowedImageRepositories: []string{"registry.example.com"},
+					},
+				}
+				complianceScan.Spec.Image = &v1alpha1.ImageOverrides{
+					Diki:           "registry.example.com/diki:v1.0.0",
+					ReportExporter: "registry.example.com/report-exporter:v1.0.0",
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should forbid creating a ComplianceScan overriding images when no repositories are allowed", func() {
+				complianceScan.Spec.Image = &v1alpha1.ImageOverrides{
+					Diki: "registry.example.com/diki:v1.0.0",
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.image.diki: Forbidden: image overrides are not allowed by the operator configuration"
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan overriding images from repositories which are not allowed", func() {
+				handler = &compliancescan.ValidatingHandler{
+					Decoder: decoder,
+					Client:  fakeClient,
+					Config: configv1alpha1.ComplianceScanConfig{
+						AllowedImageRepositories: []string{"registry.example.com/diki"},
+					},
+				}
+				complianceScan.Spec.Image = &v1alpha1.ImageOverrides{
+					Diki:           "registry.example.com/diki:v1.0.0",
+					ReportExporter: "registry.example.org/report-exporter:v1.0.0",
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.image.reportExporter: Forbidden: image \"registry.example.org/report-exporter:v1.0.0\" does not belong to any of the allowed repositories: registry.example.com/diki"
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan with a parallelism lower than 1", func() {
+				complianceScan.Spec.Parallelism = ptr.To[int32](0)
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.parallelism: Invalid value: 0: must be greater than 0"
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan with a negative ttlSecondsAfterFinished", func() {
+				complianceScan.Spec.TTLSecondsAfterFinished = ptr.To[int32](-1)
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.ttlSecondsAfterFinished: Invalid value: -1: must not be negative"
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan with an unsupported output deletion policy", func() {
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{
+					{Name: "output", DeletionPolicy: v1alpha1.DeletionPolicyDelete},
+					{Name: "other-output", DeletionPolicy: "Orphan"},
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.outputs[1].deletionPolicy: Unsupported value: \"Orphan\": supported values: \"Delete\", \"Retain\""
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan with duplicate or non-existent outputs", func() {
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{
+					{Name: "output"},
+					{Name: "missing-output"},
+					{Name: "output"},
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "[spec.outputs[2].name: Duplicate value: \"output\", spec.outputs[1].name: Not found: \"missing-output\"]"
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should read the referenced outputs from the API server", func() {
+				handler = &compliancescan.ValidatingHandler{
+					Decoder:          decoder,
+					OperatorUsername: "diki-operator",
+					APIReader:        fakeClient,
+					// The cache of the client has not observed the ReportOutput yet.
+					Client: interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+						Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+							if _, ok := obj.(*v1alpha1.ReportOutput); ok {
+								return apierrors.NewNotFound(v1alpha1.SchemeGroupVersion.WithResource("reportoutputs").GroupResource(), key.Name)
+							}
+							return c.Get(ctx, key, obj, opts...)
+						},
+					}),
+				}
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "output"}}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should warn when creating a ComplianceScan without outputs", func() {
+				complianceScan.Spec.Outputs = nil
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed.WithWarnings("spec.outputs: no report outputs are set, the report of the scan will not be exported")))
+			})
+
+			Context("test ComplianceScans restricted to a namespace", func() {
+				BeforeEach(func() {
+					Expect(fakeClient.Create(ctx, &v1alpha1.ReportOutput{
+						ObjectMeta: metav1.ObjectMeta{Name: "tenant-output"},
+						Spec: v1alpha1.ReportOutputSpec{
+							Output: v1alpha1.Output{ConfigMap: &v1alpha1.OutputConfigMap{Namespace: "tenant"}},
+						},
+					})).To(Succeed())
+					Expect(fakeClient.Create(ctx, &v1alpha1.ReportOutput{
+						ObjectMeta: metav1.ObjectMeta{Name: "kube-system-output"},
+						Spec: v1alpha1.ReportOutputSpec{
+							Output: v1alpha1.Output{ConfigMap: &v1alpha1.OutputConfigMap{Namespace: "kube-system"}},
+						},
+					})).To(Succeed())
+					Expect(fakeClient.Create(ctx, &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "options", Namespace: "tenant"},
+						Data:       map[string]string{"ruleset-one-rules": ""},
+					})).To(Succeed())
+
+					complianceScan.Spec.TargetNamespace = "tenant"
+					namespaceScopedRulesets := compscanreconciler.NamespaceScopedRulesets
+					compscanreconciler.NamespaceScopedRulesets = sets.New("ruleset-one")
+					DeferCleanup(func() { compscanreconciler.NamespaceScopedRulesets = namespaceScopedRulesets })
+				})
+
+				It("should allow creating a ComplianceScan which only references options and outputs in the target namespace", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Rules: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "tenant", Key: ptr.To("ruleset-one-rules")},
+						},
+					}
+					complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "tenant-output"}}
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should forbid creating a ComplianceScan which cannot be restricted to the target namespace", func() {
+					complianceScan.Spec.Parallelism = ptr.To[int32](2)
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Ruleset: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system", Key: ptr.To("ruleset-one")},
+						},
+					}
+					complianceScan.Spec.Rulesets = append(complianceScan.Spec.Rulesets, v1alpha1.RulesetConfig{ID: "ruleset-two", Version: "v0.0.0"})
+					complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "tenant-output"}, {Name: "kube-system-output"}}
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(And(
+						ContainSubstring("spec.parallelism: Forbidden: must not be greater than 1 for compliance scans restricted to a namespace"),
+						ContainSubstring("spec.rulesets[1].id: Unsupported value: \"ruleset-two\": supported values: \"ruleset-one\""),
+						ContainSubstring("spec.rulesets[0].options.ruleset.configMapRef.namespace: Forbidden: must be the target namespace tenant"),
+						ContainSubstring("spec.outputs[1].name: Forbidden: ReportOutput \"kube-system-output\" exports to namespace kube-system outside of the target namespace tenant"),
+					))
+				})
+
+				It("should forbid creating a ComplianceScan with an invalid target namespace", func() {
+					complianceScan.Spec.TargetNamespace = "Tenant_1"
+					complianceScan.Spec.Outputs = nil
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(ContainSubstring("spec.targetNamespace: Invalid value: \"Tenant_1\""))
+				})
+			})
+
+			It("should allow creating a ComplianceScan containing a rule option pointing to an existing configMap", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Rules: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one-rules"),
+						},
+					},
+				}
+
+				configMap := &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "configmap",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{
+						"ruleset-one-rules": "options",
+					},
+				}
+				Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+				Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow creating a ComplianceScan containing a ruleset option pointing to an existing configMap", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one"),
+						},
+					},
+				}
+
+				configMap := &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "configmap",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{
+						"ruleset-one": "options",
+					},
+				}
+				Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow creating a ComplianceScan containing both options that point to existing configMaps", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "ruleset-options-configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one"),
+						},
+					},
+					Rules: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "rule-options-configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one-rules"),
+						},
+					},
+				}
+
+				ruleOptionsConfigMap := &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "rule-options-configmap",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{
+						"ruleset-one-rules": "options",
+					},
+				}
+				Expect(fakeClient.Create(ctx, ruleOptionsConfigMap)).To(Succeed())
+
+				rulesetOptionsConfigMap := &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "ruleset-options-configmap",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{
+						"ruleset-one": "options",
+					},
+				}
+				Expect(fakeClient.Create(ctx, rulesetOptionsConfigMap)).To(Succeed())
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should forbid creating a ComplianceScan that has a rule option pointing to a non-existent configMap", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Rules: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "rule-options-configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one-rules"),
+						},
+					},
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.rulesets[0].options.rules: Not found: \"the referenced configMap does not exist\""
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan that has a ruleset option pointing to a non-existent configMap", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "ruleset-options-configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one"),
+						},
+					},
+				}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.rulesets[0].options.ruleset: Not found: \"the referenced configMap does not exist\""
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ComplianceScan that contains at least one invalid reference", func() {
+				complianceScan.Spec.Rulesets = append(complianceScan.Spec.Rulesets, v1alpha1.RulesetConfig{
+					ID:      "ruleset-two",
+					Version: "v0.0.0",
+				})
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one"),
+						},
+					},
+				}
+				complianceScan.Spec.Rulesets[1].Options = &v1alpha1.RulesetOptions{
+					Rules: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "non-existent-configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("ruleset-one-rules"),
+						},
+					},
+				}
+
+				configMap := &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "configmap",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{
+						"ruleset-one": "options",
+					},
+				}
+				Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+				Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.rulesets[1].options.rules: Not found: \"the referenced configMap does not exist\""
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			Context("test configMap references with data keys", func() {
+				It("should allow creating a ComplianceScan containing a rule option with an existing configMap key", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Rules: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("key"),
+							},
+						},
+					}
+
+					configMap := &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: "kube-system",
+						},
+						Data: map[string]string{
+							"key": "some-value",
+						},
+					}
+
+					Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+					Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should allow creating a ComplianceScan containing a ruleset option with an existing configMap key", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Ruleset: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("key"),
+							},
+						},
+					}
+
+					configMap := &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: "kube-system",
+						},
+						Data: map[string]string{
+							"key": "some-value",
+						},
+					}
+					Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should forbid creating a ComplianceScan containing a rule option reference with an non-existent configMap key", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Rules: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("no-key"),
+							},
+						},
+					}
+
+					configMap := &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: "kube-system",
+						},
+						Data: map[string]string{},
+					}
+					Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					responseForbidden.Result.Message = "spec.rulesets[0].options.rules.key: Not found: \"the referenced key within the configMap does not exist\""
+					Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+				})
+
+				It("should forbid creating a ComplianceScan containing a ruleset option reference with an non-existent configMap key", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Ruleset: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("no-key"),
+							},
+						},
+					}
+
+					configMap := &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: "kube-system",
+						},
+						Data: map[string]string{},
+					}
+					Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					responseForbidden.Result.Message = "spec.rulesets[0].options.ruleset.key: Not found: \"the referenced key within the configMap does not exist\""
+					Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+				})
+			})
+
+			Context("test configMap references without keys", func() {
+				It("should forbid creating a ComplianceScan when the rule options key is not set", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Rules: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+							},
+						},
+					}
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					responseForbidden.Result.Message = "spec.rulesets[0].options.rules.key: Required value: the key within the configMap must be set"
+					Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+				})
+
+				It("should forbid creating a ComplianceScan when the ruleset options key is not set", func() {
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Ruleset: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+							},
+						},
+					}
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					responseForbidden.Result.Message = "spec.rulesets[0].options.ruleset.key: Required value: the key within the configMap must be set"
+					Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+				})
+			})
+
+			Context("test options content of supported rulesets", func() {
+				BeforeEach(func() {
+					complianceScan.Spec.Rulesets[0].ID = "disa-kubernetes-stig"
+					complianceScan.Spec.Rulesets[0].Version = "v2r6"
+					complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+						Ruleset: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("disa-kubernetes-stig"),
+							},
+						},
+						Rules: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("disa-kubernetes-stig-rules"),
+							},
+						},
+					}
+					Expect(fakeClient.Create(ctx, namespace)).To(Succeed())
+				})
+
+				createOptionsConfigMap := func(rulesetOptions, ruleOptions string) {
+					Expect(fakeClient.Create(ctx, &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "configmap",
+							Namespace: "kube-system",
+						},
+						Data: map[string]string{
+							"disa-kubernetes-stig":       rulesetOptions,
+							"disa-kubernetes-stig-rules": ruleOptions,
+						},
+					})).To(Succeed())
+				}
+
+				It("should allow creating a ComplianceScan with valid options", func() {
+					createOptionsConfigMap("maxRetries: 3\n", `- ruleID: "242414"
+  args:
+    acceptedPods:
+    - namespaceMatchLabels:
+        foo: bar
+      matchLabels:
+        foo: bar
+      justification: "accepted"
+      ports:
+      - 53
+`)
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should forbid creating a ComplianceScan with options which cannot be parsed", func() {
+					createOptionsConfigMap("maxRetries: [", "foo: bar\n")
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(And(
+						ContainSubstring("spec.rulesets[0].options.ruleset.key[disa-kubernetes-stig]: Invalid value: failed to parse ruleset options"),
+						ContainSubstring("spec.rulesets[0].options.rules.key[disa-kubernetes-stig-rules]: Invalid value: failed to parse rule options"),
+					))
+				})
+
+				It("should forbid creating a ComplianceScan with options which do not match the ruleset option types", func() {
+					createOptionsConfigMap("maxRetries: many\n", `- ruleID: "242383"
+  args: {}
+- ruleID: "242414"
+  args:
+    acceptedPods:
+    - ports: ["http"]
+`)
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Message).To(And(
+						ContainSubstring("spec.rulesets[0].options.ruleset.key[disa-kubernetes-stig]: Invalid value:"),
+						ContainSubstring("spec.rulesets[0].options.rules.key[disa-kubernetes-stig-rules][1].args: Invalid value:"),
+					))
+				})
+
+				It("should forbid creating a ComplianceScan with an unsupported ruleset version", func() {
+					complianceScan.Spec.Rulesets[0].Version = "v1r1"
+					createOptionsConfigMap("maxRetries: many\n", "foo: bar\n")
+
+					complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+					Expect(err).ToNot(HaveOccurred())
+					request.Object.Raw = complianceScanObj
+
+					responseForbidden.Result.Message = "spec.rulesets[0].version: Unsupported value: \"v1r1\": supported values: \"v2r6\", \"v2r5\""
+					Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+				})
+			})
+
+			It("should forbid creating a ComplianceScan without a ruleset version", func() {
+				complianceScan.Spec.Rulesets[0].Version = ""
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.rulesets[0].version: Required value: the version of the ruleset must be set"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should concatenate multiple errors", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+							Name:      "configmap",
+							Namespace: "kube-system",
+							Key:       ptr.To("no-key"),
+						},
+					},
+				}
+				complianceScan.Spec.Rulesets = append(complianceScan.Spec.Rulesets, v1alpha1.RulesetConfig{
+					ID:      "ruleset-two",
+					Version: "v0.0.0",
+					Options: &v1alpha1.RulesetOptions{
+						Rules: &v1alpha1.Options{
+							ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+								Name:      "non-existent-configmap",
+								Namespace: "kube-system",
+								Key:       ptr.To("ruleset-one-rules"),
+							},
+						},
+					},
+				})
+
+				configMap := &v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "configmap",
+						Namespace: "kube-system",
+					},
+					Data: map[string]string{},
+				}
+				Expect(fakeClient.Create(ctx, configMap)).To(Succeed())
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "[spec.rulesets[0].options.ruleset.key: Not found: \"the referenced key within the configMap does not exist\", spec.rulesets[1].options.rules: Not found: \"the referenced configMap does not exist\"]"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+		})
+
+		Context("test authorization of the referenced configMaps", func() {
+			var (
+				reviews      []authorizationv1.SubjectAccessReviewSpec
+				createReview func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error
+			)
+
+			BeforeEach(func() {
+				reviews = nil
+				Expect(fakeClient.Create(ctx, &v1alpha1.ReportOutput{
+					ObjectMeta: metav1.ObjectMeta{Name: "tenant-output"},
+					Spec: v1alpha1.ReportOutputSpec{
+						Output: v1alpha1.Output{ConfigMap: &v1alpha1.OutputConfigMap{Namespace: "tenant"}},
+					},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &v1alpha1.ReportOutput{
+					ObjectMeta: metav1.ObjectMeta{Name: "kube-system-output"},
+					Spec: v1alpha1.ReportOutputSpec{
+						Output: v1alpha1.Output{ConfigMap: &v1alpha1.OutputConfigMap{Namespace: "kube-system"}},
+					},
+				})).To(Succeed())
+				for _, ns := range []string{"tenant", "kube-system"} {
+					Expect(fakeClient.Create(ctx, &v1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{Name: "options", Namespace: ns},
+						Data:       map[string]string{"ruleset-one": "", "ruleset-one-rules": ""},
+					})).To(Succeed())
+				}
+
+				// The user is only allowed to access configMaps in the tenant namespace.
+				createReview = func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					review, ok := obj.(*authorizationv1.SubjectAccessReview)
+					if !ok {
+						return c.Create(ctx, obj, opts...)
+					}
+					reviews = append(reviews, review.Spec)
+					review.Status.Allowed = review.Spec.User == "user" && review.Spec.ResourceAttributes.Namespace == "tenant"
+					return nil
+				}
+				handler = &compliancescan.ValidatingHandler{
+					Decoder:          decoder,
+					OperatorUsername: "diki-operator",
+					Client:           interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{Create: createReview}),
+				}
+
+				request.UserInfo = authenticationv1.UserInfo{
+					Username: "user",
+					UID:      "1",
+					Groups:   []string{"tenants"},
+					Extra:    map[string]authenticationv1.ExtraValue{"scopes": {"all"}},
+				}
+			})
+
+			It("should allow creating a ComplianceScan referencing configMaps the user is allowed to access", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "tenant", Key: ptr.To("ruleset-one")},
+					},
+				}
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "tenant-output"}}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				Expect(reviews).To(ConsistOf(
+					authorizationv1.SubjectAccessReviewSpec{
+						ResourceAttributes: &authorizationv1.ResourceAttributes{Namespace: "tenant", Verb: "get", Resource: "configmaps", Name: "options"},
+						User:               "user",
+						UID:                "1",
+						Groups:             []string{"tenants"},
+						Extra:              map[string]authorizationv1.ExtraValue{"scopes": {"all"}},
+					},
+					authorizationv1.SubjectAccessReviewSpec{
+						ResourceAttributes: &authorizationv1.ResourceAttributes{Namespace: "tenant", Verb: "create", Resource: "configmaps"},
+						User:               "user",
+						UID:                "1",
+						Groups:             []string{"tenants"},
+						Extra:              map[string]authorizationv1.ExtraValue{"scopes": {"all"}},
+					},
+				))
+			})
+
+			It("should forbid creating a ComplianceScan referencing configMaps the user is not allowed to access", func() {
+				complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+					Ruleset: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "tenant", Key: ptr.To("ruleset-one")},
+					},
+					Rules: &v1alpha1.Options{
+						ConfigMapRef: &v1alpha1.OptionsConfigMapRef{Name: "options", Namespace: "kube-system", Key: ptr.To("ruleset-one-rules")},
+					},
+				}
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "tenant-output"}, {Name: "kube-system-output"}}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "[spec.rulesets[0].options.rules.configMapRef: Forbidden: user \"user\" is not allowed to get configmaps in namespace kube-system, " +
+					"spec.outputs[1].name: Forbidden: user \"user\" is not allowed to create configmaps in namespace kube-system]"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should authorize ReportOutputs which have not been observed by the cache yet", func() {
+				handler = &compliancescan.ValidatingHandler{
+					Decoder:          decoder,
+					OperatorUsername: "diki-operator",
+					APIReader:        fakeClient,
+					Client: interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+						Create: createReview,
+						Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+							if _, ok := obj.(*v1alpha1.ReportOutput); ok {
+								return apierrors.NewNotFound(v1alpha1.SchemeGroupVersion.WithResource("reportoutputs").GroupResource(), key.Name)
+							}
+							return c.Get(ctx, key, obj, opts...)
+						},
+					}),
+				}
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "kube-system-output"}}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				responseForbidden.Result.Message = "spec.outputs[0].name: Forbidden: user \"user\" is not allowed to create configmaps in namespace kube-system"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid references to ReportOutputs which cannot be resolved", func() {
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "missing-output"}}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				response := handler.Handle(ctx, request)
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Message).To(ContainSubstring("spec.outputs[0].name: Forbidden: ReportOutput \"missing-output\" does not exist, hence the reference cannot be authorized"))
+			})
+
+			It("should not review the references of the operator", func() {
+				request.UserInfo = authenticationv1.UserInfo{Username: "diki-operator"}
+				complianceScan.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "kube-system-output"}}
+
+				complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = complianceScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				Expect(reviews).To(BeEmpty())
+			})
+		})
+
+		It("should return an internal error when the configMap get fails with a non-NotFound error", func() {
+			fakeErr := errors.New("internal server error")
+			interceptedClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(reportOutputs...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if _, ok := obj.(*v1.ConfigMap); ok {
+							return fakeErr
+						}
+						return c.Get(ctx, key, obj, opts...)
+					},
+				}).Build()
+
+			handler = &compliancescan.ValidatingHandler{
+				Decoder: decoder,
+				Client:  interceptedClient,
+			}
+
+			complianceScan.Spec.Rulesets[0].Options = &v1alpha1.RulesetOptions{
+				Rules: &v1alpha1.Options{
+					ConfigMapRef: &v1alpha1.OptionsConfigMapRef{
+						Name:      "configmap",
+						Namespace: "kube-system",
+						Key:       ptr.To("ruleset-one-rules"),
+					},
+				},
+			}
+
+			complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+			Expect(err).ToNot(HaveOccurred())
+			request.Object.Raw = complianceScanObj
+
+			response := handler.Handle(ctx, request)
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Message).To(ContainSubstring("Internal error"))
+		})
+
+		It("should allow requests for operations other than Create and Update", func() {
+			complianceScanObj, err := runtime.Encode(encoder, complianceScan)
+			Expect(err).ToNot(HaveOccurred())
+			request.Object.Raw = complianceScanObj
+
+			request.Operation = admissionv1.Delete
+			Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+		})
+	})
+
+	Describe("#RebaseFieldErrors", func() {
+		var fldPath = field.NewPath("spec", "rulesets").Index(0).Child("options", "rules", "key").Key("rules")
+
+		It("should replace the root of the error paths with the given path", func() {
+			errs := field.ErrorList{
+				field.Invalid(field.NewPath("ruleOptions"), "foo", "invalid"),
+				field.Invalid(field.NewPath("ruleOptions").Index(1).Child("args"), "foo", "invalid"),
+			}
+
+			Expect(compliancescan.RebaseFieldErrors(errs, "ruleOptions", fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("spec.rulesets[0].options.rules.key[rules]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("spec.rulesets[0].options.rules.key[rules][1].args")})),
+			))
+			Expect(errs[0].Field).To(Equal("ruleOptions"))
+		})
+
+		It("should report errors outside of the root on the given path", func() {
+			errs := field.ErrorList{
+				field.Invalid(field.NewPath("ruleOptionsFoo"), "foo", "invalid"),
+				field.Required(field.NewPath("version"), ""),
+			}
+
+			Expect(compliancescan.RebaseFieldErrors(errs, "ruleOptions", fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Field":  Equal("spec.rulesets[0].options.rules.key[rules]"),
+					"Detail": Equal("ruleOptionsFoo: invalid"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Field":  Equal("spec.rulesets[0].options.rules.key[rules]"),
+					"Detail": Equal("version"),
+				})),
+			))
+		})
+	})
+})
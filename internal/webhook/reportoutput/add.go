@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reportoutput
+
+import (
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// HandlerName is the name of this admission webhook handler.
+	HandlerName = "reportoutput"
+	// ValidatingWebhookPath is the HTTP handler path for the validating admission webhook.
+	ValidatingWebhookPath = "/webhooks/reportoutput/validate"
+)
+
+// AddToManager registers the validating webhook handler with the given manager.
+func AddToManager(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(ValidatingWebhookPath, &admission.Webhook{
+		Handler: &ValidatingHandler{
+			Client:  mgr.GetClient(),
+			Decoder: admission.NewDecoder(mgr.GetScheme()),
+		},
+		RecoverPanic: ptr.To(true),
+	})
+
+	return nil
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reportoutput
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+// ValidatingHandler is an admission webhook handler that validates ReportOutput resources and
+// protects ReportOutputs referenced by ComplianceScans or ScheduledComplianceScans from deletion and
+// from changes of their namespace.
+type ValidatingHandler struct {
+	Client  client.Client
+	Decoder admission.Decoder
+}
+
+var _ admission.Handler = &ValidatingHandler{}
+
+// Handle handles an admission request for a ReportOutput resource.
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete {
+		return h.handleDelete(ctx, req)
+	}
+
+	reportOutput := &dikiv1alpha1.ReportOutput{}
+	if err := h.Decoder.DecodeRaw(req.Object, reportOutput); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if allErrs := validateOutput(reportOutput.Spec.Output, field.NewPath("spec", "output")); len(allErrs) > 0 {
+		return admission.Denied(allErrs.ToAggregate().Error())
+	}
+
+	if req.Operation == admissionv1.Update {
+		return h.handleUpdate(ctx, req, reportOutput)
+	}
+
+	return admission.Allowed("")
+}
+
+// handleUpdate forbids changing the namespace of a ReportOutput while it is referenced. The references were
+// authorized against the namespace of the ReportOutput, so changing it would redirect the reports of the referencing
+// scans to a namespace their requesters might not be allowed to write to.
+func (h *ValidatingHandler) handleUpdate(ctx context.Context, req admission.Request, reportOutput *dikiv1alpha1.ReportOutput) admission.Response {
+	oldReportOutput := &dikiv1alpha1.ReportOutput{}
+	if err := h.Decoder.DecodeRaw(req.OldObject, oldReportOutput); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if outputNamespace(oldReportOutput) == outputNamespace(reportOutput) {
+		return admission.Allowed("")
+	}
+
+	referencedBy, err := h.referencingScans(ctx, req.Name)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if len(referencedBy) > 0 {
+		return admission.Denied(field.Forbidden(field.NewPath("spec", "output", "configMap", "namespace"),
+			fmt.Sprintf("must not be changed while the ReportOutput is referenced by %s", strings.Join(referencedBy, ", "))).Error())
+	}
+
+	return admission.Allowed("")
+}
+
+func (h *ValidatingHandler) handleDelete(ctx context.Context, req admission.Request) admission.Response {
+	referencedBy, err := h.referencingScans(ctx, req.Name)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if len(referencedBy) > 0 {
+		return admission.Denied(fmt.Sprintf("ReportOutput %q is still referenced by %s", req.Name, strings.Join(referencedBy, ", ")))
+	}
+
+	return admission.Allowed("")
+}
+
+// referencingScans returns the ComplianceScans which are not finished yet and the ScheduledComplianceScan templates
+// that reference the ReportOutput with the given name. Finished ComplianceScans do not export reports anymore.
+func (h *ValidatingHandler) referencingScans(ctx context.Context, name string) ([]string, error) {
+	var referencedBy []string
+
+	complianceScans := &dikiv1alpha1.ComplianceScanList{}
+	if err := h.Client.List(ctx, complianceScans); err != nil {
+		return nil, fmt.Errorf("failed to list ComplianceScans: %w", err)
+	}
+	for _, complianceScan := range complianceScans.Items {
+		if isFinished(&complianceScan) {
+			continue
+		}
+		if referencesOutput(complianceScan.Spec.Outputs, name) {
+			referencedBy = append(referencedBy, "ComplianceScan "+complianceScan.Name)
+		}
+	}
+
+	scheduledScans := &dikiv1alpha1.ScheduledComplianceScanList{}
+	if err := h.Client.List(ctx, scheduledScans); err != nil {
+		return nil, fmt.Errorf("failed to list ScheduledComplianceScans: %w", err)
+	}
+	for _, scheduledScan := range scheduledScans.Items {
+		if referencesOutput(scheduledScan.Spec.ScanTemplate.Spec.Outputs, name) {
+			referencedBy = append(referencedBy, "ScheduledComplianceScan "+scheduledScan.Name)
+		}
+	}
+
+	return referencedBy, nil
+}
+
+func isFinished(complianceScan *dikiv1alpha1.ComplianceScan) bool {
+	return complianceScan.Status.Phase == dikiv1alpha1.ComplianceScanCompleted || complianceScan.Status.Phase == dikiv1alpha1.ComplianceScanFailed
+}
+
+func outputNamespace(reportOutput *dikiv1alpha1.ReportOutput) string {
+	if reportOutput.Spec.Output.ConfigMap == nil {
+		return ""
+	}
+
+	return reportOutput.Spec.Output.ConfigMap.Namespace
+}
+
+func referencesOutput(outputs []dikiv1alpha1.ReportOutputRef, name string) bool {
+	return slices.ContainsFunc(outputs, func(output dikiv1alpha1.ReportOutputRef) bool {
+		return output.Name == name
+	})
+}
+
+// validateOutput validates that an output type is set and validates its configuration.
+func validateOutput(output dikiv1alpha1.Output, fldPath *field.Path) field.ErrorList {
+	if output.ConfigMap == nil {
+		return field.ErrorList{field.Required(fldPath, "exactly one output type must be set")}
+	}
+
+	return validateOutputConfigMap(output.ConfigMap, fldPath.Child("configMap"))
+}
+
+func validateOutputConfigMap(configMap *dikiv1alpha1.OutputConfigMap, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if configMap.Namespace == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), "the namespace of the report ConfigMaps must be set"))
+	} else {
+		for _, msg := range apivalidation.ValidateNamespaceName(configMap.Namespace, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), configMap.Namespace, msg))
+		}
+	}
+
+	if configMap.NamePrefix == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namePrefix"), "the name prefix of the report ConfigMaps must be set"))
+	} else {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(configMap.NamePrefix, true) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namePrefix"), configMap.NamePrefix, msg))
+		}
+	}
+
+	if retention := configMap.Retention; retention != nil {
+		retentionPath := fldPath.Child("retention")
+		if retention.MaxCount != nil && *retention.MaxCount < 1 {
+			allErrs = append(allErrs, field.Invalid(retentionPath.Child("maxCount"), *retention.MaxCount, "must be greater than 0"))
+		}
+		if retention.MaxAge != nil && retention.MaxAge.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(retentionPath.Child("maxAge"), retention.MaxAge.Duration.String(), "must be greater than 0"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reportoutput_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/diki-operator/internal/webhook/reportoutput"
+	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
+	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
+)
+
+var _ = Describe("ValidatingHandler", func() {
+	var (
+		ctx = context.TODO()
+
+		scheme       *runtime.Scheme
+		decoder      admission.Decoder
+		handler      *reportoutput.ValidatingHandler
+		request      admission.Request
+		encoder      runtime.Encoder
+		fakeClient   client.Client
+		reportOutput *v1alpha1.ReportOutput
+
+		responseAllowed = admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result: &metav1.Status{
+					Code: int32(http.StatusOK),
+				},
+			},
+		}
+
+		responseForbidden = admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Code:   http.StatusForbidden,
+					Reason: metav1.StatusReasonForbidden,
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(authenticationv1.AddToScheme(scheme)).To(Succeed())
+		Expect(dikiinstall.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		decoder = admission.NewDecoder(scheme)
+		handler = &reportoutput.ValidatingHandler{
+			Client:  fakeClient,
+			Decoder: decoder,
+		}
+
+		encoder = &json.Serializer{}
+		request = admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Name: "output",
+				Resource: metav1.GroupVersionResource{
+					Group:    "diki.gardener.cloud",
+					Resource: "reportoutputs",
+					Version:  "v1alpha1",
+				},
+				Operation: admissionv1.Create,
+			},
+		}
+
+		reportOutput = &v1alpha1.ReportOutput{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "output",
+			},
+			Spec: v1alpha1.ReportOutputSpec{
+				Output: v1alpha1.Output{
+					ConfigMap: &v1alpha1.OutputConfigMap{
+						Namespace:  "kube-system",
+						NamePrefix: "compliance-scan-report-",
+					},
+				},
+			},
+		}
+	})
+
+	encodeReportOutput := func() {
+		reportOutputObj, err := runtime.Encode(encoder, reportOutput)
+		Expect(err).ToNot(HaveOccurred())
+		request.Object.Raw = reportOutputObj
+	}
+
+	Describe("#Handle", func() {
+		Context("test creating and updating the ReportOutput resource", func() {
+			It("should allow creating a valid ReportOutput", func() {
+				reportOutput.Spec.Output.ConfigMap.Retention = &v1alpha1.OutputConfigMapRetention{
+					MaxCount: ptr.To[int32](5),
+					MaxAge:   &metav1.Duration{Duration: 24 * time.Hour},
+				}
+				encodeReportOutput()
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should forbid creating a ReportOutput without an output type", func() {
+				reportOutput.Spec.Output.ConfigMap = nil
+				encodeReportOutput()
+
+				responseForbidden.Result.Message = "spec.output: Required value: exactly one output type must be set"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should forbid creating a ReportOutput with an invalid ConfigMap output", func() {
+				reportOutput.Spec.Output.ConfigMap.Namespace = "Kube_System"
+				reportOutput.Spec.Output.ConfigMap.NamePrefix = "Report."
+				reportOutput.Spec.Output.ConfigMap.Retention = &v1alpha1.OutputConfigMapRetention{
+					MaxCount: ptr.To[int32](0),
+					MaxAge:   &metav1.Duration{Duration: -time.Hour},
+				}
+				encodeReportOutput()
+
+				response := handler.Handle(ctx, request)
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Message).To(And(
+					ContainSubstring("spec.output.configMap.namespace: Invalid value: \"Kube_System\""),
+					ContainSubstring("spec.output.configMap.namePrefix: Invalid value: \"Report.\""),
+					ContainSubstring("spec.output.configMap.retention.maxCount: Invalid value: 0: must be greater than 0"),
+					ContainSubstring("spec.output.configMap.retention.maxAge: Invalid value: \"-1h0m0s\": must be greater than 0"),
+				))
+			})
+
+			It("should forbid creating a ReportOutput with an empty namespace and name prefix", func() {
+				reportOutput.Spec.Output.ConfigMap.Namespace = ""
+				reportOutput.Spec.Output.ConfigMap.NamePrefix = ""
+				encodeReportOutput()
+
+				responseForbidden.Result.Message = "[spec.output.configMap.namespace: Required value: the namespace of the report ConfigMaps must be set, " +
+					"spec.output.configMap.namePrefix: Required value: the name prefix of the report ConfigMaps must be set]"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should validate updates of a ReportOutput", func() {
+				request.Operation = admissionv1.Update
+				reportOutput.Spec.Output.ConfigMap = nil
+				encodeReportOutput()
+
+				responseForbidden.Result.Message = "spec.output: Required value: exactly one output type must be set"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+		})
+
+		Context("test changing the namespace of the ReportOutput resource", func() {
+			BeforeEach(func() {
+				request.Operation = admissionv1.Update
+
+				oldReportOutputObj, err := runtime.Encode(encoder, reportOutput)
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldReportOutputObj
+
+				reportOutput.Spec.Output.ConfigMap.Namespace = "other"
+				encodeReportOutput()
+			})
+
+			It("should allow changing the namespace of a ReportOutput which is only referenced by finished ComplianceScans", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "completed-scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+					},
+					Status: v1alpha1.ComplianceScanStatus{Phase: v1alpha1.ComplianceScanCompleted},
+				})).To(Succeed())
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow other changes of a referenced ReportOutput", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ScheduledComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "scheduled-scan"},
+					Spec: v1alpha1.ScheduledComplianceScanSpec{
+						ScanTemplate: v1alpha1.ScheduledComplianceScanTemplate{
+							Spec: v1alpha1.ComplianceScanSpec{
+								Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+							},
+						},
+					},
+				})).To(Succeed())
+				reportOutput.Spec.Output.ConfigMap.Namespace = "kube-system"
+				reportOutput.Spec.Output.ConfigMap.NamePrefix = "other-prefix-"
+				encodeReportOutput()
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should forbid changing the namespace of a ReportOutput referenced by ComplianceScans and ScheduledComplianceScans", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+					},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &v1alpha1.ScheduledComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "scheduled-scan"},
+					Spec: v1alpha1.ScheduledComplianceScanSpec{
+						ScanTemplate: v1alpha1.ScheduledComplianceScanTemplate{
+							Spec: v1alpha1.ComplianceScanSpec{
+								Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+							},
+						},
+					},
+				})).To(Succeed())
+
+				responseForbidden.Result.Message = "spec.output.configMap.namespace: Forbidden: must not be changed while the ReportOutput is referenced by ComplianceScan scan, ScheduledComplianceScan scheduled-scan"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+		})
+
+		Context("test deleting the ReportOutput resource", func() {
+			BeforeEach(func() {
+				request.Operation = admissionv1.Delete
+			})
+
+			It("should allow deleting a ReportOutput which is not referenced", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "other-output"}},
+					},
+				})).To(Succeed())
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should allow deleting a ReportOutput which is only referenced by finished ComplianceScans", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "completed-scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+					},
+					Status: v1alpha1.ComplianceScanStatus{Phase: v1alpha1.ComplianceScanCompleted},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "failed-scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+					},
+					Status: v1alpha1.ComplianceScanStatus{Phase: v1alpha1.ComplianceScanFailed},
+				})).To(Succeed())
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should forbid deleting a ReportOutput referenced by ComplianceScans and ScheduledComplianceScans", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "other-output"}, {Name: "output"}},
+					},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &v1alpha1.ComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "running-scan"},
+					Spec: v1alpha1.ComplianceScanSpec{
+						Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+					},
+					Status: v1alpha1.ComplianceScanStatus{Phase: v1alpha1.ComplianceScanRunning},
+				})).To(Succeed())
+				Expect(fakeClient.Create(ctx, &v1alpha1.ScheduledComplianceScan{
+					ObjectMeta: metav1.ObjectMeta{Name: "scheduled-scan"},
+					Spec: v1alpha1.ScheduledComplianceScanSpec{
+						ScanTemplate: v1alpha1.ScheduledComplianceScanTemplate{
+							Spec: v1alpha1.ComplianceScanSpec{
+								Outputs: []v1alpha1.ReportOutputRef{{Name: "output"}},
+							},
+						},
+					},
+				})).To(Succeed())
+
+				responseForbidden.Result.Message = "ReportOutput \"output\" is still referenced by ComplianceScan running-scan, ComplianceScan scan, ScheduledComplianceScan scheduled-scan"
+				Expect(handler.Handle(ctx, request)).To(Equal(responseForbidden))
+			})
+
+			It("should return an internal error when listing the ComplianceScans fails", func() {
+				handler.Client = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+					List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+						return errors.New("fake error")
+					},
+				}).Build()
+
+				response := handler.Handle(ctx, request)
+				Expect(response.Allowed).To(BeFalse())
+				Expect(response.Result.Code).To(Equal(int32(http.StatusInternalServerError)))
+				Expect(response.Result.Message).To(Equal("failed to list ComplianceScans: fake error"))
+			})
+		})
+	})
+})
@@ -8,6 +8,8 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/gardener/diki-operator/internal/config"
 )
 
 const (
@@ -20,12 +22,17 @@ const (
 )
 
 // AddToManager registers the validating and mutating webhook handlers with the given manager.
-func AddToManager(mgr manager.Manager) error {
+// The references of requests of the given operator username are not authorized.
+func AddToManager(mgr manager.Manager, configStore *config.Store, operatorUsername string) error {
 	decoder := admission.NewDecoder(mgr.GetScheme())
 
 	mgr.GetWebhookServer().Register(ValidatingWebhookPath, &admission.Webhook{
 		Handler: &ValidatingHandler{
-			Decoder: decoder,
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			Decoder:          decoder,
+			ConfigStore:      configStore,
+			OperatorUsername: operatorUsername,
 		},
 		RecoverPanic: ptr.To(true),
 	})
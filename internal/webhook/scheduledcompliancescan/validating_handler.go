@@ -12,22 +12,36 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/gardener/diki-operator/internal/config"
 	scheduledcompliancescan "github.com/gardener/diki-operator/internal/reconciler/scheduledcompliancescan"
+	compliancescanwebhook "github.com/gardener/diki-operator/internal/webhook/compliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	configv1alpha1helper "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
 	dikiv1alpha1 "github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
 // ValidatingHandler is an admission webhook handler that validates ScheduledComplianceScan resources.
 type ValidatingHandler struct {
-	Decoder admission.Decoder
+	Client client.Client
+	// APIReader reads the referenced ReportOutputs from the API server, so that ReportOutputs which were created right
+	// before the ScheduledComplianceScan are found and authorized. Client is used if it is nil.
+	APIReader client.Reader
+	Decoder   admission.Decoder
+	Config    configv1alpha1.ComplianceScanConfig
+	// ConfigStore holds the configuration if it can be reloaded at runtime. Config is used if it is nil.
+	ConfigStore *config.Store
+	// OperatorUsername is the username of the operator. The references of its requests are not authorized.
+	OperatorUsername string
 }
 
 var _ admission.Handler = &ValidatingHandler{}
 
 // Handle handles an admission request for a ScheduledComplianceScan resource and validates
 // the embedded ComplianceScan spec template.
-func (h *ValidatingHandler) Handle(_ context.Context, req admission.Request) admission.Response {
+func (h *ValidatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
 	scheduledScan := &dikiv1alpha1.ScheduledComplianceScan{}
 	if err := h.Decoder.DecodeRaw(req.Object, scheduledScan); err != nil {
 		return admission.Errored(http.StatusBadRequest, err)
@@ -35,6 +49,7 @@ func (h *ValidatingHandler) Handle(_ context.Context, req admission.Request) adm
 
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
+	cfg := h.getConfig()
 
 	if scheduledScan.Spec.Schedule != "" {
 		if _, err := scheduledcompliancescan.ParseCronScheduleWithPanicRecovery(scheduledScan.Spec.Schedule); err != nil {
@@ -47,6 +62,28 @@ func (h *ValidatingHandler) Handle(_ context.Context, req admission.Request) adm
 	if scheduledScan.Spec.FailedScansHistoryLimit != nil && *scheduledScan.Spec.FailedScansHistoryLimit < 0 {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("failedScansHistoryLimit"), *scheduledScan.Spec.FailedScansHistoryLimit, "must not be negative"))
 	}
+	allErrs = append(allErrs, compliancescanwebhook.ValidateOutputs(scheduledScan.Spec.ScanTemplate.Spec.Outputs, specPath.Child("scanTemplate", "spec", "outputs"))...)
+
+	if parallelism := scheduledScan.Spec.ScanTemplate.Spec.Parallelism; parallelism != nil && *parallelism < 1 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("scanTemplate", "spec", "parallelism"), *parallelism, "must be greater than 0"))
+	}
+	if ttl := scheduledScan.Spec.ScanTemplate.Spec.TTLSecondsAfterFinished; ttl != nil && *ttl < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("scanTemplate", "spec", "ttlSecondsAfterFinished"), *ttl, "must not be negative"))
+	}
+
+	// The scan template cannot be updated, hence the referenced ReportOutputs only need to exist on creation and
+	// runner profiles or image repositories removed from the configuration later on do not block updates of other fields.
+	if req.Operation == admissionv1.Create {
+		allErrs = append(allErrs, compliancescanwebhook.ValidateImageOverrides(scheduledScan.Spec.ScanTemplate.Spec.Image, cfg, specPath.Child("scanTemplate", "spec", "image"))...)
+		if profile := scheduledScan.Spec.ScanTemplate.Spec.RunnerProfile; profile != "" {
+			if _, ok := cfg.DikiRunnerProfiles[profile]; !ok {
+				allErrs = append(allErrs, field.NotSupported(specPath.Child("scanTemplate", "spec", "runnerProfile"), profile, configv1alpha1helper.DikiRunnerProfileNames(cfg)))
+			}
+		}
+		allErrs = append(allErrs, compliancescanwebhook.ValidateOutputReferences(ctx, h.getReader(), scheduledScan.Spec.ScanTemplate.Spec.Outputs, specPath.Child("scanTemplate", "spec", "outputs"))...)
+		allErrs = append(allErrs, compliancescanwebhook.ValidateTargetNamespace(ctx, h.getReader(), &scheduledScan.Spec.ScanTemplate.Spec, specPath.Child("scanTemplate", "spec"))...)
+		allErrs = append(allErrs, compliancescanwebhook.AuthorizeReferences(ctx, h.Client, h.getReader(), req.UserInfo, h.OperatorUsername, &scheduledScan.Spec.ScanTemplate.Spec, specPath.Child("scanTemplate", "spec"))...)
+	}
 
 	if req.Operation == admissionv1.Update {
 		oldScheduledScan := &dikiv1alpha1.ScheduledComplianceScan{}
@@ -62,5 +99,21 @@ func (h *ValidatingHandler) Handle(_ context.Context, req admission.Request) adm
 		return admission.Denied(allErrs.ToAggregate().Error())
 	}
 
-	return admission.Allowed("")
+	return admission.Allowed("").WithWarnings(compliancescanwebhook.OutputWarnings(scheduledScan.Spec.ScanTemplate.Spec.Outputs, specPath.Child("scanTemplate", "spec", "outputs"))...)
+}
+
+func (h *ValidatingHandler) getConfig() *configv1alpha1.ComplianceScanConfig {
+	if h.ConfigStore != nil {
+		return h.ConfigStore.Get()
+	}
+
+	return &h.Config
+}
+
+func (h *ValidatingHandler) getReader() client.Reader {
+	if h.APIReader != nil {
+		return h.APIReader
+	}
+
+	return h.Client
 }
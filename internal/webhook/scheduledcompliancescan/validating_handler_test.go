@@ -12,13 +12,20 @@ import (
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/gardener/diki-operator/internal/webhook/scheduledcompliancescan"
+	configv1alpha1 "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	dikiinstall "github.com/gardener/diki-operator/pkg/apis/diki/install"
 	"github.com/gardener/diki-operator/pkg/apis/diki/v1alpha1"
 )
 
@@ -28,6 +35,7 @@ var _ = Describe("handler", func() {
 
 		scheme        *runtime.Scheme
 		decoder       admission.Decoder
+		fakeClient    client.Client
 		handler       admission.Handler
 		request       admission.Request
 		encoder       runtime.Encoder
@@ -46,11 +54,17 @@ var _ = Describe("handler", func() {
 	BeforeEach(func() {
 		scheme = runtime.NewScheme()
 		Expect(authenticationv1.AddToScheme(scheme)).To(Succeed())
+		Expect(dikiinstall.AddToScheme(scheme)).To(Succeed())
 
 		ctx = context.TODO()
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1alpha1.ReportOutput{ObjectMeta: metav1.ObjectMeta{Name: "output"}},
+		).Build()
 		decoder = admission.NewDecoder(scheme)
 		handler = &scheduledcompliancescan.ValidatingHandler{
-			Decoder: decoder,
+			Client:           fakeClient,
+			Decoder:          decoder,
+			OperatorUsername: "diki-operator",
 		}
 
 		encoder = &json.Serializer{}
@@ -84,6 +98,9 @@ var _ = Describe("handler", func() {
 								Version: "v0.0.0",
 							},
 						},
+						Outputs: []v1alpha1.ReportOutputRef{
+							{Name: "output"},
+						},
 					},
 				},
 			},
@@ -133,6 +150,165 @@ var _ = Describe("handler", func() {
 				Expect(resp.Result.Message).To(ContainSubstring("spec.failedScansHistoryLimit"))
 			})
 
+			It("should deny creating with a parallelism lower than 1", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.Parallelism = ptr.To[int32](0)
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				resp := handler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.scanTemplate.spec.parallelism"))
+			})
+
+			It("should deny creating with a negative ttlSecondsAfterFinished", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.TTLSecondsAfterFinished = ptr.To[int32](-1)
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				resp := handler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.scanTemplate.spec.ttlSecondsAfterFinished"))
+			})
+
+			It("should allow creating with a configured runner profile", func() {
+				handler = &scheduledcompliancescan.ValidatingHandler{
+					Client:  fakeClient,
+					Decoder: decoder,
+					Config: configv1alpha1.ComplianceScanConfig{
+						DikiRunnerProfiles: map[string]configv1alpha1.DikiRunnerConfig{"foo": {}},
+					},
+				}
+				scheduledScan.Spec.ScanTemplate.Spec.RunnerProfile = "foo"
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should deny creating with a runner profile that is not configured", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.RunnerProfile = "foo"
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				resp := handler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.scanTemplate.spec.runnerProfile"))
+			})
+
+			It("should allow creating with images from an allowed repository", func() {
+				handler = &scheduledcompliancescan.ValidatingHandler{
+					Client:  fakeClient,
+					Decoder: decoder,
+					Config: configv1alpha1.ComplianceScanConfig{
+						AllowedImageRepositories: []string{"registry.example.com"},
+					},
+				}
+				scheduledScan.Spec.ScanTemplate.Spec.Image = &v1alpha1.ImageOverrides{Diki: "registry.example.com/diki:v1.0.0"}
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should deny creating with images from a repository which is not allowed", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.Image = &v1alpha1.ImageOverrides{Diki: "registry.example.com/diki:v1.0.0"}
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				resp := handler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.scanTemplate.spec.image.diki"))
+			})
+
+			It("should deny creating with outputs the user is not allowed to write to", func() {
+				Expect(fakeClient.Create(ctx, &v1alpha1.ReportOutput{
+					ObjectMeta: metav1.ObjectMeta{Name: "kube-system-output"},
+					Spec: v1alpha1.ReportOutputSpec{
+						Output: v1alpha1.Output{ConfigMap: &v1alpha1.OutputConfigMap{Namespace: "kube-system"}},
+					},
+				})).To(Succeed())
+				handler = &scheduledcompliancescan.ValidatingHandler{
+					Client: interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+						Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							if _, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+								return nil
+							}
+							return c.Create(ctx, obj, opts...)
+						},
+					}),
+					Decoder:          decoder,
+					OperatorUsername: "diki-operator",
+				}
+				request.UserInfo = authenticationv1.UserInfo{Username: "user"}
+				scheduledScan.Spec.ScanTemplate.Spec.Outputs = []v1alpha1.ReportOutputRef{{Name: "kube-system-output"}}
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				resp := handler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(Equal("spec.scanTemplate.spec.outputs[0].name: Forbidden: user \"user\" is not allowed to create configmaps in namespace kube-system"))
+			})
+
+			It("should read the referenced outputs from the API server", func() {
+				handler = &scheduledcompliancescan.ValidatingHandler{
+					Client: interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+						Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+							if _, ok := obj.(*v1alpha1.ReportOutput); ok {
+								return apierrors.NewNotFound(v1alpha1.SchemeGroupVersion.WithResource("reportoutputs").GroupResource(), key.Name)
+							}
+							return c.Get(ctx, key, obj, opts...)
+						},
+						Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+							if sar, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+								sar.Status.Allowed = true
+								return nil
+							}
+							return c.Create(ctx, obj, opts...)
+						},
+					}),
+					APIReader:        fakeClient,
+					Decoder:          decoder,
+					OperatorUsername: "diki-operator",
+				}
+				request.UserInfo = authenticationv1.UserInfo{Username: "user"}
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should deny creating with duplicate or non-existent outputs", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.Outputs = []v1alpha1.ReportOutputRef{
+					{Name: "output"},
+					{Name: "missing-output"},
+					{Name: "output"},
+				}
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				resp := handler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(Equal("[spec.scanTemplate.spec.outputs[2].name: Duplicate value: \"output\", spec.scanTemplate.spec.outputs[1].name: Not found: \"missing-output\"]"))
+			})
+
+			It("should warn when creating without outputs", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.Outputs = nil
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed.WithWarnings("spec.scanTemplate.spec.outputs: no report outputs are set, the report of the scan will not be exported")))
+			})
+
 			It("should deny creating with multiple validation errors", func() {
 				scheduledScan.Spec.Schedule = "not-a-cron"
 				scheduledScan.Spec.SuccessfulScansHistoryLimit = ptr.To[int32](-1)
@@ -243,6 +419,55 @@ var _ = Describe("handler", func() {
 				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
 			})
 
+			It("should not check whether the referenced outputs exist on update", func() {
+				Expect(fakeClient.Delete(ctx, &v1alpha1.ReportOutput{ObjectMeta: metav1.ObjectMeta{Name: "output"}})).To(Succeed())
+
+				oldScheduledScanObj, err := runtime.Encode(encoder, scheduledScan.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldScheduledScanObj
+
+				scheduledScan.Labels = map[string]string{"foo": "bar"}
+
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+				request.Operation = admissionv1.Update
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should not check whether the runner profile is configured on update", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.RunnerProfile = "removed"
+				oldScheduledScanObj, err := runtime.Encode(encoder, scheduledScan.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldScheduledScanObj
+
+				scheduledScan.Spec.Schedule = "*/5 * * * *"
+
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+				request.Operation = admissionv1.Update
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should not check whether the image repositories are allowed on update", func() {
+				scheduledScan.Spec.ScanTemplate.Spec.Image = &v1alpha1.ImageOverrides{Diki: "registry.example.com/diki:v1.0.0"}
+				oldScheduledScanObj, err := runtime.Encode(encoder, scheduledScan.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+				request.OldObject.Raw = oldScheduledScanObj
+
+				scheduledScan.Spec.Schedule = "*/5 * * * *"
+
+				scheduledScanObj, err := runtime.Encode(encoder, scheduledScan)
+				Expect(err).ToNot(HaveOccurred())
+				request.Object.Raw = scheduledScanObj
+				request.Operation = admissionv1.Update
+
+				Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
 			It("should allow updating the metadata", func() {
 				oldScheduledScan := scheduledScan.DeepCopy()
 				oldScheduledScanObj, err := runtime.Encode(encoder, oldScheduledScan)
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
+	"k8s.io/utils/ptr"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -36,8 +37,61 @@ func SetDefaults_Log(obj *Log) {
 
 // SetDefaults_ComplianceScanConfig sets defaults for the ComplianceScanConfig object.
 func SetDefaults_ComplianceScanConfig(obj *ComplianceScanConfig) {
+	setDefaultsControllerOptions(&obj.ControllerOptions, 5, 10*time.Minute)
+
 	if obj.SyncPeriod == nil {
-		obj.SyncPeriod = &metav1.Duration{Duration: time.Hour}
+		obj.SyncPeriod = &metav1.Duration{Duration: time.Minute}
+	}
+
+	// defaulter-gen does not generate defaulting calls for map values.
+	for name, dikiRunner := range obj.DikiRunnerProfiles {
+		SetDefaults_DikiRunnerConfig(&dikiRunner)
+		obj.DikiRunnerProfiles[name] = dikiRunner
+	}
+}
+
+// SetDefaults_ScheduledComplianceScanConfig sets defaults for the ScheduledComplianceScanConfig object.
+func SetDefaults_ScheduledComplianceScanConfig(obj *ScheduledComplianceScanConfig) {
+	setDefaultsControllerOptions(&obj.ControllerOptions, 1, 5*time.Minute)
+}
+
+// SetDefaults_NamespaceComplianceScanConfig sets defaults for the NamespaceComplianceScanConfig object.
+func SetDefaults_NamespaceComplianceScanConfig(obj *NamespaceComplianceScanConfig) {
+	setDefaultsControllerOptions(&obj.ControllerOptions, 1, 5*time.Minute)
+}
+
+// SetDefaults_GarbageCollectorConfig sets defaults for the GarbageCollectorConfig object.
+func SetDefaults_GarbageCollectorConfig(obj *GarbageCollectorConfig) {
+	setDefaultsControllerOptions(&obj.ControllerOptions, 1, 5*time.Minute)
+
+	if obj.SyncPeriod == nil {
+		obj.SyncPeriod = &metav1.Duration{Duration: 2 * time.Minute}
+	}
+}
+
+// setDefaultsControllerOptions sets defaults for the ControllerOptions object.
+// The defaults of the concurrent syncs and the reconciliation timeout differ between the controllers.
+func setDefaultsControllerOptions(obj *ControllerOptions, concurrentSyncs int, reconciliationTimeout time.Duration) {
+	if obj.ConcurrentSyncs == nil {
+		obj.ConcurrentSyncs = &concurrentSyncs
+	}
+	if obj.ReconciliationTimeout == nil {
+		obj.ReconciliationTimeout = &metav1.Duration{Duration: reconciliationTimeout}
+	}
+	if obj.RateLimiter == nil {
+		obj.RateLimiter = &RateLimiterConfig{}
+	}
+	if obj.RateLimiter.BaseDelay == nil {
+		obj.RateLimiter.BaseDelay = &metav1.Duration{Duration: DefaultRateLimiterBaseDelay}
+	}
+	if obj.RateLimiter.MaxDelay == nil {
+		obj.RateLimiter.MaxDelay = &metav1.Duration{Duration: DefaultRateLimiterMaxDelay}
+	}
+	if obj.RateLimiter.QPS == nil {
+		obj.RateLimiter.QPS = ptr.To(DefaultRateLimiterQPS)
+	}
+	if obj.RateLimiter.Burst == nil {
+		obj.RateLimiter.Burst = ptr.To(DefaultRateLimiterBurst)
 	}
 }
 
@@ -52,6 +106,18 @@ func SetDefaults_DikiRunnerConfig(obj *DikiRunnerConfig) {
 	if obj.TargetKubeconfig != nil && len(obj.TargetKubeconfig.MountPath) == 0 {
 		obj.TargetKubeconfig.MountPath = DefaultKubeconfigMountPath
 	}
+	if obj.TargetKubeconfig != nil && obj.TargetKubeconfig.TokenRequest != nil && obj.TargetKubeconfig.TokenRequest.ExpirationSeconds == nil {
+		obj.TargetKubeconfig.TokenRequest.ExpirationSeconds = ptr.To(DefaultTokenExpirationSeconds)
+	}
+	if obj.NetworkPolicy == nil {
+		obj.NetworkPolicy = &DikiRunnerNetworkPolicy{}
+	}
+	if obj.NetworkPolicy.Enabled == nil {
+		obj.NetworkPolicy.Enabled = ptr.To(true)
+	}
+	if obj.JobLayout == "" {
+		obj.JobLayout = JobLayoutConcurrent
+	}
 }
 
 // SetDefaults_ServerConfiguration sets defaults for the ServerConfiguration object.
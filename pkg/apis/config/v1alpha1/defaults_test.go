@@ -85,19 +85,118 @@ var _ = Describe("Defaults", func() {
 			It("should default sync period", func() {
 				SetDefaults_ComplianceScanConfig(obj)
 
-				Expect(obj.SyncPeriod).To(Equal(&metav1.Duration{Duration: time.Hour}))
+				Expect(obj.SyncPeriod).To(Equal(&metav1.Duration{Duration: time.Minute}))
 			})
 
 			It("should not overwrite already set value for sync period", func() {
-				obj.SyncPeriod = &metav1.Duration{Duration: time.Minute}
+				obj.SyncPeriod = &metav1.Duration{Duration: time.Hour}
 
 				SetDefaults_ComplianceScanConfig(obj)
 
-				Expect(obj.SyncPeriod).To(Equal(&metav1.Duration{Duration: time.Minute}))
+				Expect(obj.SyncPeriod).To(Equal(&metav1.Duration{Duration: time.Hour}))
+			})
+		})
+
+		Context("ControllerOptions", func() {
+			It("should default the controller options", func() {
+				SetDefaults_ComplianceScanConfig(obj)
+
+				Expect(obj.ControllerOptions).To(Equal(ControllerOptions{
+					ConcurrentSyncs:       ptr.To(5),
+					ReconciliationTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+					RateLimiter: &RateLimiterConfig{
+						BaseDelay: &metav1.Duration{Duration: 5 * time.Second},
+						MaxDelay:  &metav1.Duration{Duration: 2 * time.Minute},
+						QPS:       ptr.To(int32(10)),
+						Burst:     ptr.To(int32(100)),
+					},
+				}))
+			})
+
+			It("should not overwrite already set controller options", func() {
+				obj.ConcurrentSyncs = ptr.To(10)
+				obj.ReconciliationTimeout = &metav1.Duration{Duration: time.Minute}
+				obj.RateLimiter = &RateLimiterConfig{QPS: ptr.To(int32(1))}
+
+				SetDefaults_ComplianceScanConfig(obj)
+
+				Expect(obj.ConcurrentSyncs).To(Equal(ptr.To(10)))
+				Expect(obj.ReconciliationTimeout).To(Equal(&metav1.Duration{Duration: time.Minute}))
+				Expect(obj.RateLimiter).To(Equal(&RateLimiterConfig{
+					BaseDelay: &metav1.Duration{Duration: 5 * time.Second},
+					MaxDelay:  &metav1.Duration{Duration: 2 * time.Minute},
+					QPS:       ptr.To(int32(1)),
+					Burst:     ptr.To(int32(100)),
+				}))
+			})
+		})
+
+		Context("DikiRunnerProfiles", func() {
+			It("should default the DikiRunner configuration of every profile", func() {
+				obj.DikiRunnerProfiles = map[string]DikiRunnerConfig{
+					"foo": {},
+					"bar": {Namespace: "bar"},
+				}
+
+				SetDefaults_ComplianceScanConfig(obj)
+
+				Expect(obj.DikiRunnerProfiles).To(Equal(map[string]DikiRunnerConfig{
+					"foo": {Namespace: DefaultDikiRunnerNamespace, PodCompletionTimeout: &metav1.Duration{Duration: DefaultPodCompletionTimeout}, NetworkPolicy: &DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}, JobLayout: JobLayoutConcurrent},
+					"bar": {Namespace: "bar", PodCompletionTimeout: &metav1.Duration{Duration: DefaultPodCompletionTimeout}, NetworkPolicy: &DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}, JobLayout: JobLayoutConcurrent},
+				}))
 			})
 		})
 	})
 
+	Describe("#SetDefaults_ScheduledComplianceScanConfig", func() {
+		It("should default the controller options", func() {
+			obj := &ScheduledComplianceScanConfig{}
+
+			SetDefaults_ScheduledComplianceScanConfig(obj)
+
+			Expect(obj.ConcurrentSyncs).To(Equal(ptr.To(1)))
+			Expect(obj.ReconciliationTimeout).To(Equal(&metav1.Duration{Duration: 5 * time.Minute}))
+			Expect(obj.RateLimiter).NotTo(BeNil())
+		})
+	})
+
+	Describe("#SetDefaults_NamespaceComplianceScanConfig", func() {
+		It("should default the controller options", func() {
+			obj := &NamespaceComplianceScanConfig{}
+
+			SetDefaults_NamespaceComplianceScanConfig(obj)
+
+			Expect(obj.ConcurrentSyncs).To(Equal(ptr.To(1)))
+			Expect(obj.ReconciliationTimeout).To(Equal(&metav1.Duration{Duration: 5 * time.Minute}))
+			Expect(obj.RateLimiter).NotTo(BeNil())
+		})
+	})
+
+	Describe("#SetDefaults_GarbageCollectorConfig", func() {
+		var obj *GarbageCollectorConfig
+
+		BeforeEach(func() {
+			obj = &GarbageCollectorConfig{}
+		})
+
+		It("should default the controller options and sync period", func() {
+			SetDefaults_GarbageCollectorConfig(obj)
+
+			Expect(obj.ConcurrentSyncs).To(Equal(ptr.To(1)))
+			Expect(obj.ReconciliationTimeout).To(Equal(&metav1.Duration{Duration: 5 * time.Minute}))
+			Expect(obj.RateLimiter).NotTo(BeNil())
+			Expect(obj.SyncPeriod).To(Equal(&metav1.Duration{Duration: 2 * time.Minute}))
+		})
+
+		It("should not overwrite already set value for sync period", func() {
+			obj.SyncPeriod = &metav1.Duration{Duration: time.Hour}
+
+			SetDefaults_GarbageCollectorConfig(obj)
+
+			Expect(obj.SyncPeriod).To(Equal(&metav1.Duration{Duration: time.Hour}))
+		})
+	})
+
 	Describe("#SetDefaults_DikiRunnerConfig", func() {
 		var obj *DikiRunnerConfig
 
@@ -163,6 +262,76 @@ var _ = Describe("Defaults", func() {
 				Expect(obj.TargetKubeconfig.MountPath).To(Equal("/custom/path"))
 			})
 		})
+
+		Context("TargetKubeconfig.TokenRequest.ExpirationSeconds", func() {
+			It("should default expiration seconds when tokenRequest is set", func() {
+				obj.TargetKubeconfig = &KubeconfigConfig{
+					SecretRef:    SecretRef{Name: "my-secret"},
+					TokenRequest: &TokenRequestConfig{},
+				}
+
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.TargetKubeconfig.TokenRequest.ExpirationSeconds).To(Equal(ptr.To(DefaultTokenExpirationSeconds)))
+			})
+
+			It("should not overwrite already set value for expiration seconds", func() {
+				obj.TargetKubeconfig = &KubeconfigConfig{
+					SecretRef:    SecretRef{Name: "my-secret"},
+					TokenRequest: &TokenRequestConfig{ExpirationSeconds: ptr.To[int64](7200)},
+				}
+
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.TargetKubeconfig.TokenRequest.ExpirationSeconds).To(Equal(ptr.To[int64](7200)))
+			})
+		})
+
+		Context("JobLayout", func() {
+			It("should default the job layout", func() {
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.JobLayout).To(Equal(JobLayoutConcurrent))
+			})
+
+			It("should not overwrite already set value for the job layout", func() {
+				obj.JobLayout = JobLayoutSequential
+
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.JobLayout).To(Equal(JobLayoutSequential))
+			})
+		})
+
+		Context("NetworkPolicy", func() {
+			It("should enable the network policy by default", func() {
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.NetworkPolicy).To(Equal(&DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}))
+			})
+
+			It("should enable the network policy if enabled is not set", func() {
+				obj.NetworkPolicy = &DikiRunnerNetworkPolicy{APIServerCIDRs: []string{"10.0.0.1/32"}}
+
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.NetworkPolicy.Enabled).To(Equal(ptr.To(true)))
+			})
+
+			It("should not overwrite already set values", func() {
+				obj.NetworkPolicy = &DikiRunnerNetworkPolicy{
+					Enabled:        ptr.To(false),
+					APIServerCIDRs: []string{"10.0.0.1/32"},
+				}
+
+				SetDefaults_DikiRunnerConfig(obj)
+
+				Expect(obj.NetworkPolicy).To(Equal(&DikiRunnerNetworkPolicy{
+					Enabled:        ptr.To(false),
+					APIServerCIDRs: []string{"10.0.0.1/32"},
+				}))
+			})
+		})
 	})
 
 	Describe("#SetDefaults_ServerConfiguration", func() {
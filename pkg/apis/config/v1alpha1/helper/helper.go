@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/distribution/reference"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+)
+
+// GetDikiRunnerConfig returns the DikiRunner configuration for the given runner profile.
+// The default DikiRunner configuration is returned if the profile is empty.
+func GetDikiRunnerConfig(config *v1alpha1.ComplianceScanConfig, profile string) (*v1alpha1.DikiRunnerConfig, error) {
+	if profile == "" {
+		return &config.DikiRunner, nil
+	}
+
+	dikiRunner, ok := config.DikiRunnerProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("runner profile %q is not configured", profile)
+	}
+
+	return &dikiRunner, nil
+}
+
+// GetPriorityClassName returns the name of the PriorityClass the given priority is mapped to by the DikiRunner configuration.
+// It is empty if the priority is lower than the MinPriority of all configured PriorityClasses.
+func GetPriorityClassName(dikiRunner *v1alpha1.DikiRunnerConfig, priority int32) string {
+	var mapping *v1alpha1.PriorityClassMapping
+	for i, priorityClass := range dikiRunner.PriorityClasses {
+		if priorityClass.MinPriority <= priority && (mapping == nil || priorityClass.MinPriority > mapping.MinPriority) {
+			mapping = &dikiRunner.PriorityClasses[i]
+		}
+	}
+
+	if mapping == nil {
+		return ""
+	}
+
+	return mapping.PriorityClassName
+}
+
+// DikiRunnerProfileNames returns the sorted names of all configured runner profiles.
+func DikiRunnerProfileNames(config *v1alpha1.ComplianceScanConfig) []string {
+	return slices.Sorted(maps.Keys(config.DikiRunnerProfiles))
+}
+
+// DikiRunnerNamespaces returns the sorted, unique namespaces of the default DikiRunner configuration and all runner profiles.
+func DikiRunnerNamespaces(config *v1alpha1.ComplianceScanConfig) []string {
+	namespaces := sets.New(config.DikiRunner.Namespace)
+	for _, dikiRunner := range config.DikiRunnerProfiles {
+		namespaces.Insert(dikiRunner.Namespace)
+	}
+
+	return sets.List(namespaces)
+}
+
+// IsImageAllowed returns whether the given image reference belongs to one of the allowed image repositories.
+// Entries without a path component are treated as registries, all other entries as repositories.
+func IsImageAllowed(config *v1alpha1.ComplianceScanConfig, image string) (bool, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return false, err
+	}
+
+	for _, allowed := range config.AllowedImageRepositories {
+		if !strings.Contains(allowed, "/") {
+			if reference.Domain(named) == allowed {
+				return true, nil
+			}
+			continue
+		}
+
+		allowedNamed, err := reference.ParseNormalizedNamed(allowed)
+		if err != nil {
+			continue
+		}
+
+		if named.Name() == allowedNamed.Name() || strings.HasPrefix(named.Name(), allowedNamed.Name()+"/") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
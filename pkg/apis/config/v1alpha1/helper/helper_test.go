@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package helper_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	. "github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
+)
+
+var _ = Describe("Helper", func() {
+	var config *v1alpha1.ComplianceScanConfig
+
+	BeforeEach(func() {
+		config = &v1alpha1.ComplianceScanConfig{
+			DikiRunner: v1alpha1.DikiRunnerConfig{
+				Namespace: "kube-system",
+			},
+			DikiRunnerProfiles: map[string]v1alpha1.DikiRunnerConfig{
+				"foo": {Namespace: "foo"},
+				"bar": {Namespace: "kube-system"},
+			},
+		}
+	})
+
+	Describe("#GetDikiRunnerConfig", func() {
+		It("should return the default DikiRunner configuration when the profile is empty", func() {
+			dikiRunner, err := GetDikiRunnerConfig(config, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dikiRunner).To(Equal(&config.DikiRunner))
+		})
+
+		It("should return the DikiRunner configuration of the profile", func() {
+			dikiRunner, err := GetDikiRunnerConfig(config, "foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dikiRunner.Namespace).To(Equal("foo"))
+		})
+
+		It("should return an error when the profile is not configured", func() {
+			dikiRunner, err := GetDikiRunnerConfig(config, "baz")
+			Expect(err).To(MatchError(`runner profile "baz" is not configured`))
+			Expect(dikiRunner).To(BeNil())
+		})
+	})
+
+	Describe("#GetPriorityClassName", func() {
+		BeforeEach(func() {
+			config.DikiRunner.PriorityClasses = []v1alpha1.PriorityClassMapping{
+				{MinPriority: 100, PriorityClassName: "diki-high"},
+				{MinPriority: 0, PriorityClassName: "diki-default"},
+			}
+		})
+
+		DescribeTable("should return the PriorityClass with the highest matching MinPriority",
+			func(priority int32, expected string) {
+				Expect(GetPriorityClassName(&config.DikiRunner, priority)).To(Equal(expected))
+			},
+			Entry("priority below all thresholds", int32(-1), ""),
+			Entry("priority equal to the lowest threshold", int32(0), "diki-default"),
+			Entry("priority between the thresholds", int32(99), "diki-default"),
+			Entry("priority above the highest threshold", int32(1000), "diki-high"),
+		)
+
+		It("should return an empty name when no PriorityClasses are configured", func() {
+			Expect(GetPriorityClassName(&v1alpha1.DikiRunnerConfig{}, 100)).To(BeEmpty())
+		})
+	})
+
+	Describe("#DikiRunnerProfileNames", func() {
+		It("should return the sorted profile names", func() {
+			Expect(DikiRunnerProfileNames(config)).To(Equal([]string{"bar", "foo"}))
+		})
+	})
+
+	Describe("#DikiRunnerNamespaces", func() {
+		It("should return the sorted, unique namespaces", func() {
+			Expect(DikiRunnerNamespaces(config)).To(Equal([]string{"foo", "kube-system"}))
+		})
+	})
+
+	Describe("#IsImageAllowed", func() {
+		BeforeEach(func() {
+			config.AllowedImageRepositories = []string{
+				"registry.example.com",
+				"europe-docker.pkg.dev/gardener-project/releases",
+			}
+		})
+
+		DescribeTable("should check whether the image belongs to an allowed repository",
+			func(image string, expected bool) {
+				allowed, err := IsImageAllowed(config, image)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(Equal(expected))
+			},
+			Entry("image of an allowed registry", "registry.example.com/diki:v1.0.0", true),
+			Entry("image of an allowed repository", "europe-docker.pkg.dev/gardener-project/releases/gardener/diki:v1.0.0", true),
+			Entry("image of an allowed repository with digest", "europe-docker.pkg.dev/gardener-project/releases/gardener/diki@sha256:4d5a2a7a9a2f3c8e1b4f1e0a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c", true),
+			Entry("image of a different registry", "registry.example.org/diki:v1.0.0", false),
+			Entry("image of a repository sharing the prefix", "europe-docker.pkg.dev/gardener-project/releases-dev/diki:v1.0.0", false),
+			Entry("image of a parent repository", "europe-docker.pkg.dev/gardener-project/diki:v1.0.0", false),
+			Entry("image without registry", "diki:v1.0.0", false),
+		)
+
+		It("should return an error for an invalid image reference", func() {
+			allowed, err := IsImageAllowed(config, "registry.example.com/Diki")
+			Expect(err).To(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+		})
+	})
+})
@@ -7,6 +7,7 @@ package v1alpha1
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
@@ -22,6 +23,16 @@ const (
 	DefaultPodCompletionTimeout = 10 * time.Minute
 	// DefaultKubeconfigMountPath is the default mount path for the projected kubeconfig volume in the Job pod.
 	DefaultKubeconfigMountPath = "/var/run/secrets/target-cluster/kubeconfig"
+	// DefaultTokenExpirationSeconds is the default validity duration of requested service account tokens.
+	DefaultTokenExpirationSeconds int64 = 3600
+	// DefaultRateLimiterBaseDelay is the default base delay of the exponential per-item rate limiter of a controller.
+	DefaultRateLimiterBaseDelay = 5 * time.Second
+	// DefaultRateLimiterMaxDelay is the default maximum delay of the exponential per-item rate limiter of a controller.
+	DefaultRateLimiterMaxDelay = 2 * time.Minute
+	// DefaultRateLimiterQPS is the default number of overall requeues per second of a controller.
+	DefaultRateLimiterQPS int32 = 10
+	// DefaultRateLimiterBurst is the default burst of overall requeues of a controller.
+	DefaultRateLimiterBurst int32 = 100
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,16 +66,113 @@ type Log struct {
 type ControllerConfiguration struct {
 	// ComplianceScan is the configuration for the compliance scan controller.
 	ComplianceScan ComplianceScanConfig `json:"complianceScan"`
+	// ScheduledComplianceScan is the configuration for the scheduled compliance scan controller.
+	// +optional
+	ScheduledComplianceScan ScheduledComplianceScanConfig `json:"scheduledComplianceScan"`
+	// GarbageCollector is the configuration for the garbage collector controller.
+	// +optional
+	GarbageCollector GarbageCollectorConfig `json:"garbageCollector"`
+	// NamespaceComplianceScan is the configuration for the namespace compliance scan controller.
+	// +optional
+	NamespaceComplianceScan NamespaceComplianceScanConfig `json:"namespaceComplianceScan"`
+}
+
+// ControllerOptions contains the tuning options which are common to all controllers.
+type ControllerOptions struct {
+	// ConcurrentSyncs is the maximum number of concurrent reconciliations of the controller.
+	// +optional
+	ConcurrentSyncs *int `json:"concurrentSyncs,omitempty"`
+	// ReconciliationTimeout is the timeout of a single reconciliation of the controller.
+	// +optional
+	ReconciliationTimeout *metav1.Duration `json:"reconciliationTimeout,omitempty"`
+	// RateLimiter configures the rate limiter of the work queue of the controller.
+	// +optional
+	RateLimiter *RateLimiterConfig `json:"rateLimiter,omitempty"`
+}
+
+// RateLimiterConfig configures a rate limiter which combines an exponential per-item backoff with an overall token bucket.
+type RateLimiterConfig struct {
+	// BaseDelay is the delay of the first retry of a failed item. It doubles with every further failure.
+	// Defaults to 5s.
+	// +optional
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+	// MaxDelay is the maximum delay of the retries of a failed item.
+	// Defaults to 2m.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+	// QPS is the number of items which are processed per second overall.
+	// Defaults to 10.
+	// +optional
+	QPS *int32 `json:"qps,omitempty"`
+	// Burst is the number of items which are processed at once overall.
+	// Defaults to 100.
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// ScheduledComplianceScanConfig contains configuration for the ScheduledComplianceScan controller.
+type ScheduledComplianceScanConfig struct {
+	ControllerOptions `json:",inline"`
+}
+
+// NamespaceComplianceScanConfig contains configuration for the NamespaceComplianceScan controller.
+type NamespaceComplianceScanConfig struct {
+	ControllerOptions `json:",inline"`
+
+	// RunnerProfile is the name of the DikiRunner profile which is used to run NamespaceComplianceScans.
+	// The default DikiRunner configuration is used if it is not set. Namespace compliance scans are only
+	// supported by DikiRunner configurations without a target kubeconfig.
+	// +optional
+	RunnerProfile string `json:"runnerProfile,omitempty"`
+}
+
+// GarbageCollectorConfig contains configuration for the garbage collector controller.
+type GarbageCollectorConfig struct {
+	ControllerOptions `json:",inline"`
+
+	// SyncPeriod is the interval in which the garbage collector cleans up resources which are no longer needed.
+	// Defaults to 2m.
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+	// ComplianceScanTTLSecondsAfterFinished is the default duration in seconds after which finished ComplianceScans
+	// which do not set spec.ttlSecondsAfterFinished are deleted. It does not apply to ComplianceScans created by
+	// a ScheduledComplianceScan. Finished ComplianceScans are kept indefinitely if not set.
+	// +optional
+	ComplianceScanTTLSecondsAfterFinished *int32 `json:"complianceScanTTLSecondsAfterFinished,omitempty"`
 }
 
 // ComplianceScanConfig contains configuration for the ComplianceScan controller.
 type ComplianceScanConfig struct {
-	// SyncPeriod is the duration how often the controller performs its reconciliation.
+	ControllerOptions `json:",inline"`
+
+	// SyncPeriod is the interval in which the controller checks the progress of running and queued ComplianceScans.
+	// ComplianceScans are also reconciled when their diki-run Jobs finish, hence it only serves as a safety net.
+	// Defaults to 1m.
 	// +optional
 	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
 	// DikiRunner is the configuration for the DikiRunner.
+	// It is used for ComplianceScans which do not reference a runner profile.
 	// +optional
 	DikiRunner DikiRunnerConfig `json:"dikiRunner,omitempty"`
+	// DikiRunnerProfiles are named DikiRunner configurations which can be
+	// referenced by ComplianceScans via their runnerProfile field.
+	// +optional
+	DikiRunnerProfiles map[string]DikiRunnerConfig `json:"dikiRunnerProfiles,omitempty"`
+	// AllowedImageRepositories are the registries (e.g. `europe-docker.pkg.dev`) or repositories
+	// (e.g. `europe-docker.pkg.dev/gardener-project/releases`) from which ComplianceScans are allowed to
+	// override the diki and report-exporter images. Image overrides are rejected if it is empty.
+	// +optional
+	AllowedImageRepositories []string `json:"allowedImageRepositories,omitempty"`
+	// MaxConcurrentJobs is the maximum number of diki-run Jobs which are running at the same time.
+	// ComplianceScans which would exceed it are queued and admitted in the order of their priority and creation.
+	// A ComplianceScan runs one Job per shard. It is always admitted if no other Job is running.
+	// There is no limit if it is not set.
+	// +optional
+	MaxConcurrentJobs *int32 `json:"maxConcurrentJobs,omitempty"`
+	// DefaultReportOutputs are the names of the ReportOutputs which are set as outputs of ComplianceScans
+	// that do not specify any outputs.
+	// +optional
+	DefaultReportOutputs []string `json:"defaultReportOutputs,omitempty"`
 }
 
 // DikiRunnerConfig contains configuration for the DikiRunner.
@@ -81,6 +189,109 @@ type DikiRunnerConfig struct {
 	// When set, the Job mounts a projected volume with the kubeconfig and optional token.
 	// +optional
 	TargetKubeconfig *KubeconfigConfig `json:"targetKubeconfig,omitempty"`
+	// PodTemplate contains settings that are merged into the pod template of the DikiRunner Job.
+	// +optional
+	PodTemplate *DikiRunnerPodTemplate `json:"podTemplate,omitempty"`
+	// NetworkPolicy configures the NetworkPolicy which restricts the network access of DikiRunner pods.
+	// +optional
+	NetworkPolicy *DikiRunnerNetworkPolicy `json:"networkPolicy,omitempty"`
+	// JobLayout is the layout of the containers in the DikiRunner Job.
+	// Defaults to Concurrent.
+	// +optional
+	JobLayout JobLayout `json:"jobLayout,omitempty"`
+	// MaxConcurrentJobs is the maximum number of diki-run Jobs of ComplianceScans using this DikiRunner configuration,
+	// i.e. scanning the same target, which are running at the same time.
+	// There is no limit if it is not set.
+	// +optional
+	MaxConcurrentJobs *int32 `json:"maxConcurrentJobs,omitempty"`
+	// PriorityClasses map the priority of ComplianceScans to the PriorityClass of the DikiRunner pods.
+	// The PriorityClass of the entry with the highest MinPriority which is not greater than the priority
+	// of the ComplianceScan is used. It takes precedence over the PriorityClassName of the PodTemplate.
+	// +optional
+	PriorityClasses []PriorityClassMapping `json:"priorityClasses,omitempty"`
+}
+
+// PriorityClassMapping maps a range of ComplianceScan priorities to a PriorityClass.
+type PriorityClassMapping struct {
+	// MinPriority is the minimum priority of ComplianceScans which use the PriorityClass.
+	MinPriority int32 `json:"minPriority"`
+	// PriorityClassName is the name of the PriorityClass.
+	PriorityClassName string `json:"priorityClassName"`
+}
+
+// JobLayout is the layout of the containers in the DikiRunner Job.
+type JobLayout string
+
+const (
+	// JobLayoutConcurrent runs the diki-scan and report-exporter containers concurrently.
+	// The report-exporter waits for the report file written by the diki-scan container.
+	JobLayoutConcurrent JobLayout = "Concurrent"
+	// JobLayoutSequential runs the diki-scan container as init container and the report-exporter container
+	// once the diki-scan container has completed successfully.
+	JobLayoutSequential JobLayout = "Sequential"
+)
+
+// DikiRunnerNetworkPolicy configures the NetworkPolicy which is created for every ComplianceScan.
+// The NetworkPolicy denies all ingress traffic and only allows egress traffic to the cluster DNS and the API server(s).
+type DikiRunnerNetworkPolicy struct {
+	// Enabled specifies whether a NetworkPolicy is created for DikiRunner pods.
+	// Set it to false to disable the NetworkPolicy, e.g. if the network access is restricted otherwise.
+	// Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// APIServerCIDRs are the CIDRs of the API server(s) DikiRunner pods are allowed to connect to.
+	// If not set, the endpoints of the default/kubernetes service are used.
+	// It is required when the DikiRunner scans a remote target cluster with a target kubeconfig.
+	// +optional
+	APIServerCIDRs []string `json:"apiServerCIDRs,omitempty"`
+	// APIServerPorts are the ports of the API server(s) DikiRunner pods are allowed to connect to.
+	// If not set, the ports of the default/kubernetes service endpoints are used,
+	// or port 443 if APIServerCIDRs are set.
+	// +optional
+	APIServerPorts []int32 `json:"apiServerPorts,omitempty"`
+}
+
+// DikiRunnerPodTemplate contains settings that are merged into the pod template of the DikiRunner Job.
+type DikiRunnerPodTemplate struct {
+	// Labels are additional labels added to DikiRunner pods.
+	// Labels managed by the operator take precedence.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are additional annotations added to DikiRunner pods.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Resources contains the compute resources of the DikiRunner containers.
+	// +optional
+	Resources *DikiRunnerResources `json:"resources,omitempty"`
+	// NodeSelector is a selector which must match a node's labels for DikiRunner pods to be scheduled on that node.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity contains the scheduling constraints of DikiRunner pods.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Tolerations are the tolerations of DikiRunner pods.
+	// When set, they replace the default tolerations which tolerate all NoSchedule and NoExecute taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PriorityClassName is the name of the PriorityClass of DikiRunner pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// ImagePullSecrets are references to Secrets in the DikiRunner namespace used for pulling the DikiRunner images.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// RuntimeClassName is the name of the RuntimeClass used to run DikiRunner pods.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+}
+
+// DikiRunnerResources contains the compute resources of the DikiRunner containers.
+type DikiRunnerResources struct {
+	// DikiScan contains the compute resources of the diki-scan container.
+	// +optional
+	DikiScan *corev1.ResourceRequirements `json:"dikiScan,omitempty"`
+	// ReportExporter contains the compute resources of the report-exporter container.
+	// +optional
+	ReportExporter *corev1.ResourceRequirements `json:"reportExporter,omitempty"`
 }
 
 // KubeconfigConfig holds references to Secrets for target cluster access.
@@ -89,14 +300,46 @@ type KubeconfigConfig struct {
 	SecretRef SecretRef `json:"secretRef"`
 	// TokenSecretRef optionally references a Secret containing a service account token
 	// that the kubeconfig may reference via its tokenFile field.
+	// It is mutually exclusive with TokenRequest.
 	// +optional
 	TokenSecretRef *SecretRef `json:"tokenSecretRef,omitempty"`
+	// TokenRequest optionally configures the operator to request a short-lived service account token
+	// for every scan via the TokenRequest API. The token is mounted at the same location as the
+	// token of TokenSecretRef. It is mutually exclusive with TokenSecretRef.
+	// +optional
+	TokenRequest *TokenRequestConfig `json:"tokenRequest,omitempty"`
 	// MountPath is the mount path for the projected kubeconfig volume in the Job pod.
 	// Defaults to "/var/run/secrets/target-cluster/kubeconfig".
 	// +optional
 	MountPath string `json:"mountPath,omitempty"`
 }
 
+// TokenRequestConfig contains configuration for requesting service account tokens via the TokenRequest API.
+type TokenRequestConfig struct {
+	// ServiceAccount references the ServiceAccount in the target cluster for which tokens are requested.
+	ServiceAccount ServiceAccountRef `json:"serviceAccount"`
+	// ExpirationSeconds is the requested validity duration of the token.
+	// It must be at least 600 seconds and cover the PodCompletionTimeout. Defaults to 3600.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+	// Audiences are the intended audiences of the token.
+	// Defaults to the audiences of the API server of the target cluster.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+	// ManagementKubeconfigSecretRef optionally references a Secret containing a kubeconfig
+	// which is used to request the tokens. The operator's own credentials for the target cluster are used if it is not set.
+	// +optional
+	ManagementKubeconfigSecretRef *SecretRef `json:"managementKubeconfigSecretRef,omitempty"`
+}
+
+// ServiceAccountRef is a reference to a ServiceAccount.
+type ServiceAccountRef struct {
+	// Name is the name of the ServiceAccount.
+	Name string `json:"name"`
+	// Namespace is the namespace of the ServiceAccount.
+	Namespace string `json:"namespace"`
+}
+
 // SecretRef is a reference to a Secret that resides in the same namespace as the diki runner Job.
 type SecretRef struct {
 	// Name is the name of the Secret.
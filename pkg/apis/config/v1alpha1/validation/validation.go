@@ -5,19 +5,31 @@
 package validation
 
 import (
+	"fmt"
+	"net"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/distribution/reference"
 	"github.com/gardener/gardener/pkg/logger"
 	validationutils "github.com/gardener/gardener/pkg/utils/validation"
+	kubernetescorevalidation "github.com/gardener/gardener/pkg/utils/validation/kubernetes/core"
+	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/diki-operator/pkg/apis/config/v1alpha1"
+	"github.com/gardener/diki-operator/pkg/apis/config/v1alpha1/helper"
 )
 
+var supportedJobLayouts = sets.New(v1alpha1.JobLayoutConcurrent, v1alpha1.JobLayoutSequential)
+
 // ValidateDikiOperatorConfiguration validates the given `DikiOperatorConfiguration`.
 func ValidateDikiOperatorConfiguration(conf *v1alpha1.DikiOperatorConfiguration) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -53,7 +65,170 @@ func validateLog(log *v1alpha1.Log, fldPath *field.Path) field.ErrorList {
 func validateControllers(controllers *v1alpha1.ControllerConfiguration, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	allErrs = append(allErrs, validateDikiRunner(controllers.ComplianceScan.DikiRunner, fldPath.Child("complianceScan", "dikiRunner"))...)
+	complianceScanPath := fldPath.Child("complianceScan")
+	allErrs = append(allErrs, validateControllerOptions(controllers.ComplianceScan.ControllerOptions, complianceScanPath)...)
+	allErrs = append(allErrs, validateSyncPeriod(controllers.ComplianceScan.SyncPeriod, complianceScanPath.Child("syncPeriod"))...)
+	allErrs = append(allErrs, validateDikiRunner(controllers.ComplianceScan.DikiRunner, complianceScanPath.Child("dikiRunner"))...)
+
+	for _, name := range helper.DikiRunnerProfileNames(&controllers.ComplianceScan) {
+		var (
+			dikiRunner  = controllers.ComplianceScan.DikiRunnerProfiles[name]
+			profilePath = complianceScanPath.Child("dikiRunnerProfiles").Key(name)
+		)
+
+		for _, msg := range apivalidation.NameIsDNSLabel(name, false) {
+			allErrs = append(allErrs, field.Invalid(profilePath, name, msg))
+		}
+
+		allErrs = append(allErrs, validateDikiRunner(dikiRunner, profilePath)...)
+
+		// The operator either scans the cluster it runs on or a remote target cluster, hence all profiles have to agree on it.
+		if (dikiRunner.TargetKubeconfig == nil) != (controllers.ComplianceScan.DikiRunner.TargetKubeconfig == nil) {
+			allErrs = append(allErrs, field.Invalid(profilePath.Child("targetKubeconfig"), dikiRunner.TargetKubeconfig, "must be set if and only if dikiRunner.targetKubeconfig is set"))
+		}
+	}
+
+	for i, repository := range controllers.ComplianceScan.AllowedImageRepositories {
+		allErrs = append(allErrs, validateAllowedImageRepository(repository, complianceScanPath.Child("allowedImageRepositories").Index(i))...)
+	}
+
+	if maxConcurrentJobs := controllers.ComplianceScan.MaxConcurrentJobs; maxConcurrentJobs != nil && *maxConcurrentJobs < 1 {
+		allErrs = append(allErrs, field.Invalid(complianceScanPath.Child("maxConcurrentJobs"), *maxConcurrentJobs, "must be greater than 0"))
+	}
+
+	defaultReportOutputs := sets.New[string]()
+	for i, name := range controllers.ComplianceScan.DefaultReportOutputs {
+		outputPath := complianceScanPath.Child("defaultReportOutputs").Index(i)
+		for _, msg := range apivalidation.NameIsDNSSubdomain(name, false) {
+			allErrs = append(allErrs, field.Invalid(outputPath, name, msg))
+		}
+		if defaultReportOutputs.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(outputPath, name))
+		}
+		defaultReportOutputs.Insert(name)
+	}
+
+	allErrs = append(allErrs, validateControllerOptions(controllers.ScheduledComplianceScan.ControllerOptions, fldPath.Child("scheduledComplianceScan"))...)
+
+	namespaceComplianceScanPath := fldPath.Child("namespaceComplianceScan")
+	allErrs = append(allErrs, validateControllerOptions(controllers.NamespaceComplianceScan.ControllerOptions, namespaceComplianceScanPath)...)
+	if profile := controllers.NamespaceComplianceScan.RunnerProfile; profile != "" {
+		if _, ok := controllers.ComplianceScan.DikiRunnerProfiles[profile]; !ok {
+			allErrs = append(allErrs, field.NotSupported(namespaceComplianceScanPath.Child("runnerProfile"), profile, helper.DikiRunnerProfileNames(&controllers.ComplianceScan)))
+		}
+	}
+
+	garbageCollectorPath := fldPath.Child("garbageCollector")
+	allErrs = append(allErrs, validateControllerOptions(controllers.GarbageCollector.ControllerOptions, garbageCollectorPath)...)
+	allErrs = append(allErrs, validateSyncPeriod(controllers.GarbageCollector.SyncPeriod, garbageCollectorPath.Child("syncPeriod"))...)
+	if ttl := controllers.GarbageCollector.ComplianceScanTTLSecondsAfterFinished; ttl != nil && *ttl < 0 {
+		allErrs = append(allErrs, field.Invalid(garbageCollectorPath.Child("complianceScanTTLSecondsAfterFinished"), *ttl, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateControllerOptions validates the tuning options of a controller.
+func validateControllerOptions(options v1alpha1.ControllerOptions, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if options.ConcurrentSyncs != nil && *options.ConcurrentSyncs < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("concurrentSyncs"), *options.ConcurrentSyncs, "must be greater than 0"))
+	}
+
+	if options.ReconciliationTimeout != nil && options.ReconciliationTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("reconciliationTimeout"), options.ReconciliationTimeout, "must be greater than 0"))
+	}
+
+	if rateLimiter := options.RateLimiter; rateLimiter != nil {
+		rateLimiterPath := fldPath.Child("rateLimiter")
+
+		if rateLimiter.BaseDelay != nil && rateLimiter.BaseDelay.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(rateLimiterPath.Child("baseDelay"), rateLimiter.BaseDelay, "must be greater than 0"))
+		}
+		if rateLimiter.MaxDelay != nil {
+			if rateLimiter.MaxDelay.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(rateLimiterPath.Child("maxDelay"), rateLimiter.MaxDelay, "must be greater than 0"))
+			} else if rateLimiter.BaseDelay != nil && rateLimiter.MaxDelay.Duration < rateLimiter.BaseDelay.Duration {
+				allErrs = append(allErrs, field.Invalid(rateLimiterPath.Child("maxDelay"), rateLimiter.MaxDelay, "must not be less than baseDelay"))
+			}
+		}
+		if rateLimiter.QPS != nil && *rateLimiter.QPS < 1 {
+			allErrs = append(allErrs, field.Invalid(rateLimiterPath.Child("qps"), *rateLimiter.QPS, "must be greater than 0"))
+		}
+		if rateLimiter.Burst != nil && *rateLimiter.Burst < 1 {
+			allErrs = append(allErrs, field.Invalid(rateLimiterPath.Child("burst"), *rateLimiter.Burst, "must be greater than 0"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSyncPeriod validates the sync period of a controller.
+func validateSyncPeriod(syncPeriod *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if syncPeriod != nil && syncPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, syncPeriod, "must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// validateTokenRequest validates the TokenRequest configuration.
+func validateTokenRequest(tokenRequest *v1alpha1.TokenRequestConfig, podCompletionTimeout *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	serviceAccountPath := fldPath.Child("serviceAccount")
+	if tokenRequest.ServiceAccount.Name == "" {
+		allErrs = append(allErrs, field.Required(serviceAccountPath.Child("name"), "service account name is required"))
+	}
+	if tokenRequest.ServiceAccount.Namespace == "" {
+		allErrs = append(allErrs, field.Required(serviceAccountPath.Child("namespace"), "service account namespace is required"))
+	}
+
+	if tokenRequest.ExpirationSeconds != nil {
+		expirationPath := fldPath.Child("expirationSeconds")
+		if *tokenRequest.ExpirationSeconds < 600 {
+			allErrs = append(allErrs, field.Invalid(expirationPath, *tokenRequest.ExpirationSeconds, "must be at least 600 seconds"))
+		} else if podCompletionTimeout != nil && time.Duration(*tokenRequest.ExpirationSeconds)*time.Second < podCompletionTimeout.Duration {
+			allErrs = append(allErrs, field.Invalid(expirationPath, *tokenRequest.ExpirationSeconds, "must not be less than podCompletionTimeout"))
+		}
+	}
+
+	for i, audience := range tokenRequest.Audiences {
+		if audience == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("audiences").Index(i), "audience must not be empty"))
+		}
+	}
+
+	if tokenRequest.ManagementKubeconfigSecretRef != nil && tokenRequest.ManagementKubeconfigSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("managementKubeconfigSecretRef", "name"), "secret name is required"))
+	}
+
+	return allErrs
+}
+
+// validateAllowedImageRepository validates an entry of the allowed image repositories.
+func validateAllowedImageRepository(repository string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if repository == "" {
+		return append(allErrs, field.Required(fldPath, "must not be empty"))
+	}
+
+	if !strings.Contains(repository, "/") {
+		return allErrs
+	}
+
+	named, err := reference.ParseNormalizedNamed(repository)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, repository, fmt.Sprintf("must be a valid repository: %s", err.Error())))
+	}
+
+	if !reference.IsNameOnly(named) {
+		allErrs = append(allErrs, field.Invalid(fldPath, repository, "must not contain a tag or digest"))
+	}
 
 	return allErrs
 }
@@ -85,6 +260,144 @@ func validateDikiRunner(dikiRunner v1alpha1.DikiRunnerConfig, fldPath *field.Pat
 				allErrs = append(allErrs, field.Required(tokenRefPath.Child("name"), "secret name is required"))
 			}
 		}
+
+		if dikiRunner.TargetKubeconfig.TokenRequest != nil {
+			tokenRequestPath := kubeconfigPath.Child("tokenRequest")
+			if dikiRunner.TargetKubeconfig.TokenSecretRef != nil {
+				allErrs = append(allErrs, field.Forbidden(tokenRequestPath, "must not be set together with tokenSecretRef"))
+			}
+			allErrs = append(allErrs, validateTokenRequest(dikiRunner.TargetKubeconfig.TokenRequest, dikiRunner.PodCompletionTimeout, tokenRequestPath)...)
+		}
+	}
+
+	if dikiRunner.PodTemplate != nil {
+		allErrs = append(allErrs, validateDikiRunnerPodTemplate(dikiRunner.PodTemplate, fldPath.Child("podTemplate"))...)
+	}
+
+	if dikiRunner.NetworkPolicy != nil {
+		allErrs = append(allErrs, validateDikiRunnerNetworkPolicy(dikiRunner.NetworkPolicy, dikiRunner.TargetKubeconfig != nil, fldPath.Child("networkPolicy"))...)
+	}
+
+	if dikiRunner.JobLayout != "" && !supportedJobLayouts.Has(dikiRunner.JobLayout) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("jobLayout"), dikiRunner.JobLayout, sets.List(supportedJobLayouts)))
+	}
+
+	if dikiRunner.MaxConcurrentJobs != nil && *dikiRunner.MaxConcurrentJobs < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentJobs"), *dikiRunner.MaxConcurrentJobs, "must be greater than 0"))
+	}
+
+	allErrs = append(allErrs, validatePriorityClasses(dikiRunner.PriorityClasses, fldPath.Child("priorityClasses"))...)
+
+	return allErrs
+}
+
+// validateDikiRunnerNetworkPolicy validates the DikiRunner NetworkPolicy configuration.
+func validateDikiRunnerNetworkPolicy(networkPolicy *v1alpha1.DikiRunnerNetworkPolicy, hasTargetKubeconfig bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The endpoints of the default/kubernetes service can only be used if the DikiRunner scans the cluster it runs in.
+	if ptr.Deref(networkPolicy.Enabled, false) && hasTargetKubeconfig && len(networkPolicy.APIServerCIDRs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("apiServerCIDRs"), "must be set for a DikiRunner with a target kubeconfig unless the network policy is disabled"))
+	}
+
+	for i, cidr := range networkPolicy.APIServerCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiServerCIDRs").Index(i), cidr, "must be a valid CIDR"))
+		}
+	}
+
+	for i, port := range networkPolicy.APIServerPorts {
+		for _, msg := range utilvalidation.IsValidPortNum(int(port)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("apiServerPorts").Index(i), port, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePriorityClasses validates the mapping of ComplianceScan priorities to PriorityClasses.
+func validatePriorityClasses(priorityClasses []v1alpha1.PriorityClassMapping, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs       = field.ErrorList{}
+		minPriorities = sets.New[int32]()
+	)
+
+	for i, priorityClass := range priorityClasses {
+		idxPath := fldPath.Index(i)
+
+		if minPriorities.Has(priorityClass.MinPriority) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("minPriority"), priorityClass.MinPriority))
+		}
+		minPriorities.Insert(priorityClass.MinPriority)
+
+		if priorityClass.PriorityClassName == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("priorityClassName"), "priority class name is required"))
+			continue
+		}
+		for _, msg := range apivalidation.NameIsDNSSubdomain(priorityClass.PriorityClassName, false) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("priorityClassName"), priorityClass.PriorityClassName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDikiRunnerPodTemplate validates the DikiRunner pod template configuration.
+func validateDikiRunnerPodTemplate(podTemplate *v1alpha1.DikiRunnerPodTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabels(podTemplate.Labels, fldPath.Child("labels"))...)
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(podTemplate.Annotations, fldPath.Child("annotations"))...)
+	allErrs = append(allErrs, metav1validation.ValidateLabels(podTemplate.NodeSelector, fldPath.Child("nodeSelector"))...)
+
+	if podTemplate.Resources != nil {
+		resourcesPath := fldPath.Child("resources")
+		if podTemplate.Resources.DikiScan != nil {
+			allErrs = append(allErrs, validateResourceRequirements(podTemplate.Resources.DikiScan, resourcesPath.Child("dikiScan"))...)
+		}
+		if podTemplate.Resources.ReportExporter != nil {
+			allErrs = append(allErrs, validateResourceRequirements(podTemplate.Resources.ReportExporter, resourcesPath.Child("reportExporter"))...)
+		}
+	}
+
+	allErrs = append(allErrs, kubernetescorevalidation.ValidateTolerations(podTemplate.Tolerations, fldPath.Child("tolerations"))...)
+
+	if podTemplate.PriorityClassName != "" {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(podTemplate.PriorityClassName, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("priorityClassName"), podTemplate.PriorityClassName, msg))
+		}
+	}
+
+	for i, imagePullSecret := range podTemplate.ImagePullSecrets {
+		if imagePullSecret.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("imagePullSecrets").Index(i).Child("name"), "secret name is required"))
+		}
+	}
+
+	if podTemplate.RuntimeClassName != nil {
+		for _, msg := range apivalidation.NameIsDNSSubdomain(*podTemplate.RuntimeClassName, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("runtimeClassName"), *podTemplate.RuntimeClassName, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateResourceRequirements validates the given container resource requirements.
+func validateResourceRequirements(resources *corev1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for name, quantity := range resources.Limits {
+		allErrs = append(allErrs, kubernetescorevalidation.ValidateResourceQuantityValue(string(name), quantity, fldPath.Child("limits").Key(string(name)))...)
+	}
+
+	for name, quantity := range resources.Requests {
+		requestPath := fldPath.Child("requests").Key(string(name))
+		allErrs = append(allErrs, kubernetescorevalidation.ValidateResourceQuantityValue(string(name), quantity, requestPath)...)
+
+		if limit, ok := resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(requestPath, quantity.String(), fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+		}
 	}
 
 	return allErrs
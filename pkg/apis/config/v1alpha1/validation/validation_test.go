@@ -10,6 +10,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -193,6 +195,101 @@ var _ = Describe("#ValidateDikiOperatorConfiguration", func() {
 			}))))
 		})
 
+		It("should pass validation with a valid tokenRequest", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig = &v1alpha1.KubeconfigConfig{
+				SecretRef: v1alpha1.SecretRef{
+					Name: "target-kubeconfig",
+				},
+				TokenRequest: &v1alpha1.TokenRequestConfig{
+					ServiceAccount:                v1alpha1.ServiceAccountRef{Name: "diki", Namespace: "kube-system"},
+					ExpirationSeconds:             ptr.To[int64](3600),
+					Audiences:                     []string{"kubernetes"},
+					ManagementKubeconfigSecretRef: &v1alpha1.SecretRef{Name: "management-kubeconfig"},
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when tokenRequest is set together with tokenSecretRef", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig = &v1alpha1.KubeconfigConfig{
+				SecretRef: v1alpha1.SecretRef{
+					Name: "target-kubeconfig",
+				},
+				TokenSecretRef: &v1alpha1.SecretRef{
+					Name: "target-token",
+				},
+				TokenRequest: &v1alpha1.TokenRequestConfig{
+					ServiceAccount: v1alpha1.ServiceAccountRef{Name: "diki", Namespace: "kube-system"},
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest"),
+			}))))
+		})
+
+		It("should fail validation when tokenRequest contains invalid values", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig = &v1alpha1.KubeconfigConfig{
+				SecretRef: v1alpha1.SecretRef{
+					Name: "target-kubeconfig",
+				},
+				TokenRequest: &v1alpha1.TokenRequestConfig{
+					ExpirationSeconds:             ptr.To[int64](300),
+					Audiences:                     []string{""},
+					ManagementKubeconfigSecretRef: &v1alpha1.SecretRef{},
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest.serviceAccount.name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest.serviceAccount.namespace"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest.expirationSeconds"),
+					"Detail": Equal("must be at least 600 seconds"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest.audiences[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest.managementKubeconfigSecretRef.name"),
+				})),
+			))
+		})
+
+		It("should fail validation when the token expires before the pod completion timeout", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.PodCompletionTimeout = &metav1.Duration{Duration: time.Hour}
+			conf.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig = &v1alpha1.KubeconfigConfig{
+				SecretRef: v1alpha1.SecretRef{
+					Name: "target-kubeconfig",
+				},
+				TokenRequest: &v1alpha1.TokenRequestConfig{
+					ServiceAccount:    v1alpha1.ServiceAccountRef{Name: "diki", Namespace: "kube-system"},
+					ExpirationSeconds: ptr.To[int64](1800),
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("controllers.complianceScan.dikiRunner.targetKubeconfig.tokenRequest.expirationSeconds"),
+				"Detail": Equal("must not be less than podCompletionTimeout"),
+			}))))
+		})
+
 		It("should pass validation with valid absolute mountPath", func() {
 			conf.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig = &v1alpha1.KubeconfigConfig{
 				SecretRef: v1alpha1.SecretRef{
@@ -222,6 +319,468 @@ var _ = Describe("#ValidateDikiOperatorConfiguration", func() {
 		})
 	})
 
+	Describe("DikiRunnerProfiles validation", func() {
+		It("should pass validation with valid runner profiles", func() {
+			conf.Controllers.ComplianceScan.DikiRunnerProfiles = map[string]v1alpha1.DikiRunnerConfig{
+				"foo": {
+					Namespace:            "foo",
+					PodCompletionTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when a runner profile is invalid", func() {
+			conf.Controllers.ComplianceScan.DikiRunnerProfiles = map[string]v1alpha1.DikiRunnerConfig{
+				"Foo_Bar": {
+					Namespace:            "foo",
+					PodCompletionTimeout: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":     Equal(field.ErrorTypeInvalid),
+					"Field":    Equal("controllers.complianceScan.dikiRunnerProfiles[Foo_Bar]"),
+					"BadValue": Equal("Foo_Bar"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunnerProfiles[Foo_Bar].podCompletionTimeout"),
+				})),
+			))
+		})
+
+		It("should fail validation when the targetKubeconfig of a runner profile does not match the default", func() {
+			conf.Controllers.ComplianceScan.DikiRunnerProfiles = map[string]v1alpha1.DikiRunnerConfig{
+				"foo": {
+					Namespace: "foo",
+					TargetKubeconfig: &v1alpha1.KubeconfigConfig{
+						SecretRef: v1alpha1.SecretRef{Name: "target-kubeconfig"},
+					},
+				},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("controllers.complianceScan.dikiRunnerProfiles[foo].targetKubeconfig"),
+			}))))
+		})
+	})
+
+	Describe("AllowedImageRepositories validation", func() {
+		It("should pass validation with valid registries and repositories", func() {
+			conf.Controllers.ComplianceScan.AllowedImageRepositories = []string{
+				"europe-docker.pkg.dev",
+				"localhost:5000",
+				"europe-docker.pkg.dev/gardener-project/releases",
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when an entry is empty, invalid or contains a tag", func() {
+			conf.Controllers.ComplianceScan.AllowedImageRepositories = []string{
+				"",
+				"europe-docker.pkg.dev/Gardener",
+				"europe-docker.pkg.dev/gardener-project/diki:v1.0.0",
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.allowedImageRepositories[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.allowedImageRepositories[1]"),
+					"Detail": ContainSubstring("must be a valid repository"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.allowedImageRepositories[2]"),
+					"Detail": Equal("must not contain a tag or digest"),
+				})),
+			))
+		})
+	})
+
+	Describe("DefaultReportOutputs validation", func() {
+		It("should pass validation with valid ReportOutput names", func() {
+			conf.Controllers.ComplianceScan.DefaultReportOutputs = []string{"configmap-output", "other-output"}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when a name is invalid or duplicated", func() {
+			conf.Controllers.ComplianceScan.DefaultReportOutputs = []string{"configmap-output", "Invalid_Name", "configmap-output"}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.defaultReportOutputs[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("controllers.complianceScan.defaultReportOutputs[2]"),
+				})),
+			))
+		})
+	})
+
+	Describe("NamespaceComplianceScan validation", func() {
+		It("should pass validation when the runner profile exists", func() {
+			conf.Controllers.ComplianceScan.DikiRunnerProfiles = map[string]v1alpha1.DikiRunnerConfig{
+				"tenant": {Namespace: "tenant"},
+			}
+			conf.Controllers.NamespaceComplianceScan.RunnerProfile = "tenant"
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when the runner profile does not exist", func() {
+			conf.Controllers.NamespaceComplianceScan.RunnerProfile = "tenant"
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":     Equal(field.ErrorTypeNotSupported),
+				"Field":    Equal("controllers.namespaceComplianceScan.runnerProfile"),
+				"BadValue": Equal("tenant"),
+			}))))
+		})
+	})
+
+	Describe("PodTemplate validation", func() {
+		It("should pass validation with a valid pod template", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.PodTemplate = &v1alpha1.DikiRunnerPodTemplate{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"foo": "bar"},
+				Resources: &v1alpha1.DikiRunnerResources{
+					DikiScan: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+					},
+				},
+				NodeSelector: map[string]string{"worker.gardener.cloud/pool": "diki"},
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "diki", Effect: corev1.TaintEffectNoSchedule},
+				},
+				PriorityClassName: "diki-runner",
+				ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "pull-secret"}},
+				RuntimeClassName:  ptr.To("gvisor"),
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when the pod template contains invalid values", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.PodTemplate = &v1alpha1.DikiRunnerPodTemplate{
+				Labels:       map[string]string{"!invalid": "value"},
+				NodeSelector: map[string]string{"foo": "!invalid"},
+				Resources: &v1alpha1.DikiRunnerResources{
+					ReportExporter: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")},
+						Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					},
+				},
+				Tolerations: []corev1.Toleration{
+					{Key: "dedicated", Operator: "foo"},
+				},
+				PriorityClassName: "Invalid_Name",
+				ImagePullSecrets:  []corev1.LocalObjectReference{{Name: ""}},
+				RuntimeClassName:  ptr.To("Invalid_Name"),
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.labels"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.nodeSelector"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.resources.reportExporter.requests[cpu]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.tolerations[0].operator"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.priorityClassName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.imagePullSecrets[0].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.podTemplate.runtimeClassName"),
+				})),
+			))
+		})
+	})
+
+	Describe("MaxConcurrentJobs validation", func() {
+		It("should pass validation with positive limits", func() {
+			conf.Controllers.ComplianceScan.MaxConcurrentJobs = ptr.To(int32(10))
+			conf.Controllers.ComplianceScan.DikiRunner.MaxConcurrentJobs = ptr.To(int32(2))
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation with non-positive limits", func() {
+			conf.Controllers.ComplianceScan.MaxConcurrentJobs = ptr.To(int32(0))
+			conf.Controllers.ComplianceScan.DikiRunner.MaxConcurrentJobs = ptr.To(int32(-1))
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.maxConcurrentJobs"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.dikiRunner.maxConcurrentJobs"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+			))
+		})
+	})
+
+	Describe("Controller options validation", func() {
+		It("should pass validation with valid controller options", func() {
+			conf.Controllers.ComplianceScan.ControllerOptions = v1alpha1.ControllerOptions{
+				ConcurrentSyncs:       ptr.To(5),
+				ReconciliationTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+				RateLimiter: &v1alpha1.RateLimiterConfig{
+					BaseDelay: &metav1.Duration{Duration: 5 * time.Second},
+					MaxDelay:  &metav1.Duration{Duration: 5 * time.Second},
+					QPS:       ptr.To(int32(10)),
+					Burst:     ptr.To(int32(100)),
+				},
+			}
+			conf.Controllers.ComplianceScan.SyncPeriod = &metav1.Duration{Duration: 5 * time.Second}
+			conf.Controllers.ScheduledComplianceScan.ConcurrentSyncs = ptr.To(1)
+			conf.Controllers.GarbageCollector.SyncPeriod = &metav1.Duration{Duration: 2 * time.Minute}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation with invalid controller options", func() {
+			conf.Controllers.ComplianceScan.ControllerOptions = v1alpha1.ControllerOptions{
+				ConcurrentSyncs:       ptr.To(0),
+				ReconciliationTimeout: &metav1.Duration{Duration: -time.Minute},
+				RateLimiter: &v1alpha1.RateLimiterConfig{
+					BaseDelay: &metav1.Duration{Duration: 0},
+					QPS:       ptr.To(int32(0)),
+					Burst:     ptr.To(int32(-1)),
+				},
+			}
+			conf.Controllers.ComplianceScan.SyncPeriod = &metav1.Duration{Duration: 0}
+			conf.Controllers.ScheduledComplianceScan.RateLimiter = &v1alpha1.RateLimiterConfig{
+				BaseDelay: &metav1.Duration{Duration: time.Minute},
+				MaxDelay:  &metav1.Duration{Duration: time.Second},
+			}
+			conf.Controllers.GarbageCollector.ConcurrentSyncs = ptr.To(-1)
+			conf.Controllers.GarbageCollector.SyncPeriod = &metav1.Duration{Duration: -time.Minute}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.concurrentSyncs"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.reconciliationTimeout"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.rateLimiter.baseDelay"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.rateLimiter.qps"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.rateLimiter.burst"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.syncPeriod"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.scheduledComplianceScan.rateLimiter.maxDelay"),
+					"Detail": Equal("must not be less than baseDelay"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.garbageCollector.concurrentSyncs"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.garbageCollector.syncPeriod"),
+					"Detail": Equal("must be greater than 0"),
+				})),
+			))
+		})
+
+		It("should fail validation with a negative default ComplianceScan TTL", func() {
+			conf.Controllers.GarbageCollector.ComplianceScanTTLSecondsAfterFinished = ptr.To[int32](-1)
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.garbageCollector.complianceScanTTLSecondsAfterFinished"),
+					"Detail": Equal("must not be negative"),
+				})),
+			))
+		})
+	})
+
+	Describe("PriorityClasses validation", func() {
+		It("should pass validation with valid priority classes", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.PriorityClasses = []v1alpha1.PriorityClassMapping{
+				{MinPriority: 0, PriorityClassName: "diki-low"},
+				{MinPriority: 100, PriorityClassName: "diki-high"},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation with invalid priority classes", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.PriorityClasses = []v1alpha1.PriorityClassMapping{
+				{MinPriority: 0, PriorityClassName: "diki-low"},
+				{MinPriority: 0, PriorityClassName: "Invalid_Name"},
+				{MinPriority: 100},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("controllers.complianceScan.dikiRunner.priorityClasses[1].minPriority"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.priorityClasses[1].priorityClassName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.priorityClasses[2].priorityClassName"),
+				})),
+			))
+		})
+	})
+
+	Describe("JobLayout validation", func() {
+		It("should pass validation with a supported job layout", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.JobLayout = v1alpha1.JobLayoutSequential
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation with an unsupported job layout", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.JobLayout = "Parallel"
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("controllers.complianceScan.dikiRunner.jobLayout"),
+				})),
+			))
+		})
+	})
+
+	Describe("NetworkPolicy validation", func() {
+		It("should pass validation with valid API server CIDRs and ports", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.NetworkPolicy = &v1alpha1.DikiRunnerNetworkPolicy{
+				Enabled:        ptr.To(true),
+				APIServerCIDRs: []string{"10.0.0.1/32", "2001:db8::/64"},
+				APIServerPorts: []int32{443, 6443},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(BeEmpty())
+		})
+
+		It("should fail validation when API server CIDRs or ports are invalid", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.NetworkPolicy = &v1alpha1.DikiRunnerNetworkPolicy{
+				APIServerCIDRs: []string{"10.0.0.1/32", "10.0.0.1"},
+				APIServerPorts: []int32{0, 443, 70000},
+			}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("controllers.complianceScan.dikiRunner.networkPolicy.apiServerCIDRs[1]"),
+					"Detail": Equal("must be a valid CIDR"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.networkPolicy.apiServerPorts[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controllers.complianceScan.dikiRunner.networkPolicy.apiServerPorts[2]"),
+				})),
+			))
+		})
+
+		It("should fail validation when the network policy is enabled with a target kubeconfig but without API server CIDRs", func() {
+			conf.Controllers.ComplianceScan.DikiRunner.TargetKubeconfig = &v1alpha1.KubeconfigConfig{
+				SecretRef: v1alpha1.SecretRef{Name: "target-kubeconfig"},
+			}
+			conf.Controllers.ComplianceScan.DikiRunner.NetworkPolicy = &v1alpha1.DikiRunnerNetworkPolicy{Enabled: ptr.To(true)}
+
+			errorList := ValidateDikiOperatorConfiguration(conf)
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("controllers.complianceScan.dikiRunner.networkPolicy.apiServerCIDRs"),
+				})),
+			))
+
+			conf.Controllers.ComplianceScan.DikiRunner.NetworkPolicy.APIServerCIDRs = []string{"10.0.0.1/32"}
+			Expect(ValidateDikiOperatorConfiguration(conf)).To(BeEmpty())
+
+			conf.Controllers.ComplianceScan.DikiRunner.NetworkPolicy = &v1alpha1.DikiRunnerNetworkPolicy{Enabled: ptr.To(false)}
+			Expect(ValidateDikiOperatorConfiguration(conf)).To(BeEmpty())
+		})
+	})
+
 	Describe("ServerConfiguration", func() {
 		It("should forbid negative HealthProbes port", func() {
 			conf.Server.HealthProbes.Port = -1
@@ -10,6 +10,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -18,12 +19,35 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplianceScanConfig) DeepCopyInto(out *ComplianceScanConfig) {
 	*out = *in
+	in.ControllerOptions.DeepCopyInto(&out.ControllerOptions)
 	if in.SyncPeriod != nil {
 		in, out := &in.SyncPeriod, &out.SyncPeriod
 		*out = new(v1.Duration)
 		**out = **in
 	}
 	in.DikiRunner.DeepCopyInto(&out.DikiRunner)
+	if in.DikiRunnerProfiles != nil {
+		in, out := &in.DikiRunnerProfiles, &out.DikiRunnerProfiles
+		*out = make(map[string]DikiRunnerConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.AllowedImageRepositories != nil {
+		in, out := &in.AllowedImageRepositories, &out.AllowedImageRepositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrentJobs != nil {
+		in, out := &in.MaxConcurrentJobs, &out.MaxConcurrentJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DefaultReportOutputs != nil {
+		in, out := &in.DefaultReportOutputs, &out.DefaultReportOutputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -41,6 +65,9 @@ func (in *ComplianceScanConfig) DeepCopy() *ComplianceScanConfig {
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
 	in.ComplianceScan.DeepCopyInto(&out.ComplianceScan)
+	in.ScheduledComplianceScan.DeepCopyInto(&out.ScheduledComplianceScan)
+	in.GarbageCollector.DeepCopyInto(&out.GarbageCollector)
+	in.NamespaceComplianceScan.DeepCopyInto(&out.NamespaceComplianceScan)
 	return
 }
 
@@ -54,6 +81,37 @@ func (in *ControllerConfiguration) DeepCopy() *ControllerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerOptions) DeepCopyInto(out *ControllerOptions) {
+	*out = *in
+	if in.ConcurrentSyncs != nil {
+		in, out := &in.ConcurrentSyncs, &out.ConcurrentSyncs
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReconciliationTimeout != nil {
+		in, out := &in.ReconciliationTimeout, &out.ReconciliationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimiter != nil {
+		in, out := &in.RateLimiter, &out.RateLimiter
+		*out = new(RateLimiterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerOptions.
+func (in *ControllerOptions) DeepCopy() *ControllerOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DikiOperatorConfiguration) DeepCopyInto(out *DikiOperatorConfiguration) {
 	*out = *in
@@ -107,6 +165,26 @@ func (in *DikiRunnerConfig) DeepCopyInto(out *DikiRunnerConfig) {
 		*out = new(KubeconfigConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(DikiRunnerPodTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(DikiRunnerNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrentJobs != nil {
+		in, out := &in.MaxConcurrentJobs, &out.MaxConcurrentJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]PriorityClassMapping, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -120,6 +198,154 @@ func (in *DikiRunnerConfig) DeepCopy() *DikiRunnerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DikiRunnerNetworkPolicy) DeepCopyInto(out *DikiRunnerNetworkPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.APIServerCIDRs != nil {
+		in, out := &in.APIServerCIDRs, &out.APIServerCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIServerPorts != nil {
+		in, out := &in.APIServerPorts, &out.APIServerPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DikiRunnerNetworkPolicy.
+func (in *DikiRunnerNetworkPolicy) DeepCopy() *DikiRunnerNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DikiRunnerNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DikiRunnerPodTemplate) DeepCopyInto(out *DikiRunnerPodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(DikiRunnerResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DikiRunnerPodTemplate.
+func (in *DikiRunnerPodTemplate) DeepCopy() *DikiRunnerPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(DikiRunnerPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DikiRunnerResources) DeepCopyInto(out *DikiRunnerResources) {
+	*out = *in
+	if in.DikiScan != nil {
+		in, out := &in.DikiScan, &out.DikiScan
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReportExporter != nil {
+		in, out := &in.ReportExporter, &out.ReportExporter
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DikiRunnerResources.
+func (in *DikiRunnerResources) DeepCopy() *DikiRunnerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(DikiRunnerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollectorConfig) DeepCopyInto(out *GarbageCollectorConfig) {
+	*out = *in
+	in.ControllerOptions.DeepCopyInto(&out.ControllerOptions)
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ComplianceScanTTLSecondsAfterFinished != nil {
+		in, out := &in.ComplianceScanTTLSecondsAfterFinished, &out.ComplianceScanTTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollectorConfig.
+func (in *GarbageCollectorConfig) DeepCopy() *GarbageCollectorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollectorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPSServer) DeepCopyInto(out *HTTPSServer) {
 	*out = *in
@@ -147,6 +373,11 @@ func (in *KubeconfigConfig) DeepCopyInto(out *KubeconfigConfig) {
 		*out = new(SecretRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TokenRequest != nil {
+		in, out := &in.TokenRequest, &out.TokenRequest
+		*out = new(TokenRequestConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -176,6 +407,92 @@ func (in *Log) DeepCopy() *Log {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceComplianceScanConfig) DeepCopyInto(out *NamespaceComplianceScanConfig) {
+	*out = *in
+	in.ControllerOptions.DeepCopyInto(&out.ControllerOptions)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceComplianceScanConfig.
+func (in *NamespaceComplianceScanConfig) DeepCopy() *NamespaceComplianceScanConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceComplianceScanConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityClassMapping) DeepCopyInto(out *PriorityClassMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityClassMapping.
+func (in *PriorityClassMapping) DeepCopy() *PriorityClassMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityClassMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimiterConfig) DeepCopyInto(out *RateLimiterConfig) {
+	*out = *in
+	if in.BaseDelay != nil {
+		in, out := &in.BaseDelay, &out.BaseDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimiterConfig.
+func (in *RateLimiterConfig) DeepCopy() *RateLimiterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimiterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledComplianceScanConfig) DeepCopyInto(out *ScheduledComplianceScanConfig) {
+	*out = *in
+	in.ControllerOptions.DeepCopyInto(&out.ControllerOptions)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledComplianceScanConfig.
+func (in *ScheduledComplianceScanConfig) DeepCopy() *ScheduledComplianceScanConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledComplianceScanConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretRef) DeepCopyInto(out *SecretRef) {
 	*out = *in
@@ -240,6 +557,22 @@ func (in *ServerConfiguration) DeepCopy() *ServerConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountRef) DeepCopyInto(out *ServiceAccountRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountRef.
+func (in *ServiceAccountRef) DeepCopy() *ServiceAccountRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
@@ -255,3 +588,35 @@ func (in *TLS) DeepCopy() *TLS {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenRequestConfig) DeepCopyInto(out *TokenRequestConfig) {
+	*out = *in
+	out.ServiceAccount = in.ServiceAccount
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagementKubeconfigSecretRef != nil {
+		in, out := &in.ManagementKubeconfigSecretRef, &out.ManagementKubeconfigSecretRef
+		*out = new(SecretRef)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenRequestConfig.
+func (in *TokenRequestConfig) DeepCopy() *TokenRequestConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenRequestConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,6 +29,9 @@ func SetObjectDefaults_DikiOperatorConfiguration(in *DikiOperatorConfiguration)
 	}
 	SetDefaults_ComplianceScanConfig(&in.Controllers.ComplianceScan)
 	SetDefaults_DikiRunnerConfig(&in.Controllers.ComplianceScan.DikiRunner)
+	SetDefaults_ScheduledComplianceScanConfig(&in.Controllers.ScheduledComplianceScan)
+	SetDefaults_GarbageCollectorConfig(&in.Controllers.GarbageCollector)
+	SetDefaults_NamespaceComplianceScanConfig(&in.Controllers.NamespaceComplianceScan)
 	SetDefaults_ServerConfiguration(&in.Server)
 	SetDefaults_HTTPSServer(&in.Server.Webhooks)
 }
@@ -31,6 +31,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ComplianceScanList{},
 		&ScheduledComplianceScan{},
 		&ScheduledComplianceScanList{},
+		&NamespaceComplianceScan{},
+		&NamespaceComplianceScanList{},
 	)
 	return nil
 }
@@ -40,14 +40,46 @@ type ComplianceScanSpec struct {
 	Rulesets []RulesetConfig
 	// Outputs describe the outputs of the compliance scan.
 	Outputs []ReportOutputRef
+	// RunnerProfile is the name of the DikiRunner profile from the operator configuration that is used to run the compliance scan.
+	RunnerProfile string
+	// Image overrides the images used to run the compliance scan.
+	Image *ImageOverrides
+	// Parallelism is the maximum number of diki-run Jobs which scan the rulesets of the compliance scan in parallel.
+	Parallelism *int32
+	// Priority is the priority of the compliance scan. ComplianceScans with a higher priority are admitted first.
+	Priority *int32
+	// TTLSecondsAfterFinished is the duration in seconds after which a finished ComplianceScan is deleted.
+	TTLSecondsAfterFinished *int32
+	// TargetNamespace restricts the compliance scan to the given namespace.
+	TargetNamespace string
+}
+
+// ImageOverrides contains image references which override the default images used to run a compliance scan.
+type ImageOverrides struct {
+	// Diki is the image reference of the diki scanner.
+	Diki string
+	// ReportExporter is the image reference of the report exporter.
+	ReportExporter string
 }
 
 // ReportOutputRef describes a reference to a report output.
 type ReportOutputRef struct {
 	// Name is the name of the report output.
 	Name string
+	// DeletionPolicy specifies whether the artifacts exported to the report output are deleted together with the ComplianceScan.
+	DeletionPolicy DeletionPolicy
 }
 
+// DeletionPolicy specifies what happens to exported artifacts when a ComplianceScan is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the exported artifacts when the ComplianceScan is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDelete deletes the exported artifacts when the ComplianceScan is deleted.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+)
+
 // RulesetConfig describes the configuration of a ruleset.
 type RulesetConfig struct {
 	// ID is the identifier of the ruleset.
@@ -93,6 +125,73 @@ type ComplianceScanStatus struct {
 	Rulesets []RulesetSummary
 	// Outputs contain the output statuses of the ComplianceScan.
 	Outputs []OutputStatus
+	// Images contains the images which were used to run the ComplianceScan.
+	Images []ImageStatus
+	// QueuePosition is the position of the ComplianceScan in the queue of scans waiting to be admitted.
+	// It is only set while the ComplianceScan is in phase Queued.
+	QueuePosition *int32
+	// Snapshot contains the configuration which was resolved when the ComplianceScan was started.
+	Snapshot *ComplianceScanSnapshot
+}
+
+// ComplianceScanSnapshot contains the configuration which was resolved when a compliance scan was started.
+type ComplianceScanSnapshot struct {
+	// Images contains the images with which the diki-run Jobs were created.
+	Images SnapshotImages
+	// Rulesets contains the resolved options of the scanned rulesets.
+	Rulesets []RulesetSnapshot
+	// Outputs contains the specifications of the referenced report outputs.
+	Outputs []OutputSnapshot
+}
+
+// SnapshotImages contains the images with which the diki-run Jobs of a compliance scan were created.
+type SnapshotImages struct {
+	// Diki is the image reference of the diki scanner.
+	Diki string
+	// ReportExporter is the image reference of the report exporter.
+	ReportExporter string
+}
+
+// RulesetSnapshot contains the resolved options of a ruleset.
+type RulesetSnapshot struct {
+	// ID is the identifier of the ruleset.
+	ID string
+	// Version is the version of the ruleset.
+	Version string
+	// RulesetOptions contains the resolved global options of the ruleset.
+	RulesetOptions *OptionsSnapshot
+	// RuleOptions contains the resolved rule options of the ruleset.
+	RuleOptions *OptionsSnapshot
+}
+
+// OptionsSnapshot describes the options which were read from a ConfigMap.
+type OptionsSnapshot struct {
+	// ConfigMapRef is the reference to the ConfigMap key containing the options.
+	ConfigMapRef OptionsConfigMapRef
+	// ResourceVersion is the resource version of the ConfigMap when the options were read.
+	ResourceVersion string
+	// Hash is the SHA-256 hash of the options.
+	Hash string
+	// Data contains the options which were read from the ConfigMap. The diki config is built from it.
+	Data string
+}
+
+// OutputSnapshot contains the specification of a report output.
+type OutputSnapshot struct {
+	// Name is the name of the report output.
+	Name string
+	// Output is the specification of the report output.
+	Output Output
+}
+
+// ImageStatus contains the image which was used by a container of a compliance scan.
+type ImageStatus struct {
+	// Container is the name of the container.
+	Container string
+	// Image is the image reference of the container.
+	Image string
+	// Digest is the resolved digest of the image.
+	Digest string
 }
 
 // OutputStatus contains the status of a specific output of a compliance scan.
@@ -121,6 +220,9 @@ type ComplianceScanPhase string
 const (
 	// ComplianceScanPending means that the ComplianceScan is pending execution.
 	ComplianceScanPending ComplianceScanPhase = "Pending"
+	// ComplianceScanQueued means that the ComplianceScan waits until it is admitted because the
+	// maximum number of concurrently running ComplianceScans is reached.
+	ComplianceScanQueued ComplianceScanPhase = "Queued"
 	// ComplianceScanRunning means that the ComplianceScan is running.
 	ComplianceScanRunning ComplianceScanPhase = "Running"
 	// ComplianceScanCompleted means that the ComplianceScan has completed successfully.
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package diki
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceComplianceScan describes a compliance scan which is restricted to its namespace.
+type NamespaceComplianceScan struct {
+	metav1.TypeMeta
+	// Standard object metadata.
+	metav1.ObjectMeta
+
+	// Spec contains the specification of this namespace compliance scan.
+	Spec NamespaceComplianceScanSpec
+	// Status contains the status of this namespace compliance scan.
+	Status NamespaceComplianceScanStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceComplianceScanList describes a list of namespace compliance scans.
+type NamespaceComplianceScanList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	// Items contains the list of NamespaceComplianceScans.
+	Items []NamespaceComplianceScan
+}
+
+// NamespaceComplianceScanSpec is the specification of a NamespaceComplianceScan.
+type NamespaceComplianceScanSpec struct {
+	// Rulesets describe the rulesets to be applied during the compliance scan.
+	Rulesets []RulesetConfig
+	// Output configures the export of the report to ConfigMaps in the namespace of the NamespaceComplianceScan.
+	Output *NamespaceOutputConfigMap
+}
+
+// NamespaceOutputConfigMap contains the configuration for exporting the report to ConfigMaps in the namespace of a NamespaceComplianceScan.
+type NamespaceOutputConfigMap struct {
+	// NamePrefix is the prefix for the generated ConfigMap name.
+	NamePrefix string
+	// Retention contains the settings for pruning the exported report ConfigMaps.
+	Retention *OutputConfigMapRetention
+}
+
+// NamespaceComplianceScanStatus contains the status of a NamespaceComplianceScan.
+type NamespaceComplianceScanStatus struct {
+	// Conditions contains the conditions of the NamespaceComplianceScan.
+	Conditions []Condition
+	// Phase represents the current phase of the NamespaceComplianceScan.
+	Phase ComplianceScanPhase
+	// ComplianceScanName is the name of the ComplianceScan which runs the NamespaceComplianceScan.
+	ComplianceScanName string
+	// Rulesets contains the ruleset summaries of the NamespaceComplianceScan.
+	Rulesets []RulesetSummary
+	// Outputs contain the output statuses of the NamespaceComplianceScan.
+	Outputs []OutputStatus
+}
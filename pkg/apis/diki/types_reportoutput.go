@@ -51,4 +51,18 @@ type OutputConfigMap struct {
 	// NamePrefix is the prefix for the generated ConfigMap name.
 	// Defaults to "compliance-scan-report-".
 	NamePrefix string
+	// Retention contains the settings for pruning the exported report ConfigMaps.
+	// Report ConfigMaps are kept indefinitely if not set.
+	Retention *OutputConfigMapRetention
+}
+
+// OutputConfigMapRetention contains the settings for pruning the exported report ConfigMaps.
+type OutputConfigMapRetention struct {
+	// MaxCount is the maximum number of report ConfigMaps kept per ComplianceScan name.
+	// The reports of ComplianceScans created by a ScheduledComplianceScan are counted per ScheduledComplianceScan.
+	MaxCount *int32
+	// MaxAge is the maximum age of report ConfigMaps.
+	MaxAge *metav1.Duration
+	// DryRun only logs the report ConfigMaps which would be pruned instead of deleting them.
+	DryRun bool
 }
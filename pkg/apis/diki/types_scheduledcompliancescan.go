@@ -42,6 +42,8 @@ type ScheduledComplianceScanSpec struct {
 	SuccessfulScansHistoryLimit *int32
 	// FailedScansHistoryLimit is the number of failed compliance scans to keep.
 	FailedScansHistoryLimit *int32
+	// Suspend tells the controller to suspend subsequent scans. It does not apply to already started scans.
+	Suspend *bool
 	// ScanTemplate is the template for the ComplianceScan that will be created on each scheduled scan.
 	ScanTemplate ScheduledComplianceScanTemplate
 }
@@ -54,6 +56,8 @@ type ScheduledComplianceScanTemplate struct {
 
 // ScheduledComplianceScanStatus contains the status of a ScheduledComplianceScan.
 type ScheduledComplianceScanStatus struct {
+	// Conditions contains the conditions of the ScheduledComplianceScan.
+	Conditions []Condition
 	// Active is a reference to the currently active ComplianceScan, if any.
 	Active *corev1.ObjectReference
 	// LastScheduleTime is the last time a ComplianceScan was scheduled.
@@ -61,3 +65,12 @@ type ScheduledComplianceScanStatus struct {
 	// LastCompletionTime is the last time a scheduled ComplianceScan completed.
 	LastCompletionTime *metav1.Time
 }
+
+const (
+	// ConditionTypeScheduleValid indicates whether the schedule of the ScheduledComplianceScan is a valid cron expression.
+	ConditionTypeScheduleValid ConditionType = "ScheduleValid"
+	// ConditionTypeLastRunSucceeded indicates whether the last finished ComplianceScan of the ScheduledComplianceScan has completed successfully.
+	ConditionTypeLastRunSucceeded ConditionType = "LastRunSucceeded"
+	// ConditionTypeSuspended indicates whether the scheduling of new ComplianceScans is suspended.
+	ConditionTypeSuspended ConditionType = "Suspended"
+)
@@ -39,6 +39,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ComplianceScanList{},
 		&ScheduledComplianceScan{},
 		&ScheduledComplianceScanList{},
+		&NamespaceComplianceScan{},
+		&NamespaceComplianceScanList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -43,22 +43,81 @@ type ComplianceScanSpec struct {
 	// Rulesets describe the rulesets to be applied during the compliance scan.
 	Rulesets []RulesetConfig `json:"rulesets,omitempty"`
 	// Outputs describe the outputs of the compliance scan.
+	// Defaults to the default ReportOutputs of the operator configuration.
 	// +optional
 	Outputs []ReportOutputRef `json:"outputs,omitempty"`
+	// RunnerProfile is the name of the DikiRunner profile from the operator configuration that is used to run the compliance scan.
+	// The default DikiRunner configuration is used if it is not set.
+	// +optional
+	RunnerProfile string `json:"runnerProfile,omitempty"`
+	// Image overrides the images used to run the compliance scan.
+	// Only images from repositories allowed by the operator configuration are accepted.
+	// +optional
+	Image *ImageOverrides `json:"image,omitempty"`
+	// Parallelism is the maximum number of diki-run Jobs which scan the rulesets of the compliance scan in parallel.
+	// The rulesets are split across the Jobs and their partial reports are merged into a single report before it is exported.
+	// If there are more Jobs than rulesets, the rules of known ruleset versions are split into ranges which are scanned by different Jobs.
+	// Defaults to a single Job which scans all rulesets.
+	// +optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+	// Priority is the priority of the compliance scan. When the number of concurrently running diki-run Jobs is limited,
+	// queued ComplianceScans with a higher priority are admitted before ones with a lower priority.
+	// The priority can also be mapped to a PriorityClass of the diki-run pods by the operator configuration.
+	// Defaults to 0.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// TTLSecondsAfterFinished is the duration in seconds after which a finished (Completed or Failed) ComplianceScan
+	// is deleted by the garbage collector. The resources of the ComplianceScan are cleaned up by its finalizer as usual.
+	// If not set, the default of the operator configuration applies to ComplianceScans which are not created by a
+	// ScheduledComplianceScan, while the others are kept according to the history limits of their ScheduledComplianceScan.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// TargetNamespace restricts the compliance scan to the given namespace. The diki-run Job runs with a dedicated
+	// ServiceAccount which is only granted read access to the namespace, and the ConfigMaps of the ruleset options and
+	// of the report outputs have to reside in it. It is not supported by DikiRunner configurations with a target kubeconfig and cannot be
+	// combined with a parallelism greater than 1.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+// ImageOverrides contains image references which override the default images used to run a compliance scan.
+type ImageOverrides struct {
+	// Diki is the image reference of the diki scanner.
+	// +optional
+	Diki string `json:"diki,omitempty"`
+	// ReportExporter is the image reference of the report exporter.
+	// +optional
+	ReportExporter string `json:"reportExporter,omitempty"`
 }
 
 // ReportOutputRef describes a reference to a report output.
 type ReportOutputRef struct {
 	// Name is the name of the report output.
 	Name string `json:"name"`
+	// DeletionPolicy specifies whether the artifacts exported to the report output are deleted together with the ComplianceScan.
+	// Defaults to Retain.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy specifies what happens to exported artifacts when a ComplianceScan is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the exported artifacts when the ComplianceScan is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDelete deletes the exported artifacts when the ComplianceScan is deleted.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+)
+
 // RulesetConfig describes the configuration of a ruleset.
 type RulesetConfig struct {
 	// ID is the identifier of the ruleset.
 	ID string `json:"id"`
 	// Version is the version of the ruleset.
-	Version string `json:"version"`
+	// Defaults to the latest version of the ruleset supported by the operator.
+	// +optional
+	Version string `json:"version,omitempty"`
 	// Options are options for a ruleset.
 	// +optional
 	Options *RulesetOptions `json:"options,omitempty"`
@@ -89,6 +148,7 @@ type OptionsConfigMapRef struct {
 	// Namespace is the namespace of the ConfigMap.
 	Namespace string `json:"namespace"`
 	// Key is the key within the ConfigMap, where the options are stored.
+	// Defaults to the ID of the ruleset for ruleset options and to "<rulesetID>-rules" for rule options.
 	// +optional
 	Key *string `json:"key,omitempty"`
 }
@@ -106,6 +166,81 @@ type ComplianceScanStatus struct {
 	// Outputs contain the output statuses of the ComplianceScan.
 	// +optional
 	Outputs []OutputStatus `json:"outputs,omitempty"`
+	// Images contains the images which were used to run the ComplianceScan.
+	// +optional
+	Images []ImageStatus `json:"images,omitempty"`
+	// QueuePosition is the position of the ComplianceScan in the queue of scans waiting to be admitted.
+	// It is only set while the ComplianceScan is in phase Queued.
+	// +optional
+	QueuePosition *int32 `json:"queuePosition,omitempty"`
+	// Snapshot contains the configuration which was resolved when the ComplianceScan was started.
+	// +optional
+	Snapshot *ComplianceScanSnapshot `json:"snapshot,omitempty"`
+}
+
+// ComplianceScanSnapshot contains the configuration which was resolved when a compliance scan was started.
+type ComplianceScanSnapshot struct {
+	// Images contains the images with which the diki-run Jobs were created.
+	Images SnapshotImages `json:"images"`
+	// Rulesets contains the resolved options of the scanned rulesets.
+	// +optional
+	Rulesets []RulesetSnapshot `json:"rulesets,omitempty"`
+	// Outputs contains the specifications of the referenced report outputs.
+	// +optional
+	Outputs []OutputSnapshot `json:"outputs,omitempty"`
+}
+
+// SnapshotImages contains the images with which the diki-run Jobs of a compliance scan were created.
+type SnapshotImages struct {
+	// Diki is the image reference of the diki scanner.
+	Diki string `json:"diki"`
+	// ReportExporter is the image reference of the report exporter.
+	ReportExporter string `json:"reportExporter"`
+}
+
+// RulesetSnapshot contains the resolved options of a ruleset.
+type RulesetSnapshot struct {
+	// ID is the identifier of the ruleset.
+	ID string `json:"id"`
+	// Version is the version of the ruleset.
+	Version string `json:"version"`
+	// RulesetOptions contains the resolved global options of the ruleset.
+	// +optional
+	RulesetOptions *OptionsSnapshot `json:"rulesetOptions,omitempty"`
+	// RuleOptions contains the resolved rule options of the ruleset.
+	// +optional
+	RuleOptions *OptionsSnapshot `json:"ruleOptions,omitempty"`
+}
+
+// OptionsSnapshot describes the options which were read from a ConfigMap.
+type OptionsSnapshot struct {
+	// ConfigMapRef is the reference to the ConfigMap key containing the options.
+	ConfigMapRef OptionsConfigMapRef `json:"configMapRef"`
+	// ResourceVersion is the resource version of the ConfigMap when the options were read.
+	ResourceVersion string `json:"resourceVersion"`
+	// Hash is the SHA-256 hash of the options.
+	Hash string `json:"hash"`
+	// Data contains the options which were read from the ConfigMap. The diki config is built from it.
+	Data string `json:"data"`
+}
+
+// OutputSnapshot contains the specification of a report output.
+type OutputSnapshot struct {
+	// Name is the name of the report output.
+	Name string `json:"name"`
+	// Output is the specification of the report output.
+	Output Output `json:"output"`
+}
+
+// ImageStatus contains the image which was used by a container of a compliance scan.
+type ImageStatus struct {
+	// Container is the name of the container.
+	Container string `json:"container"`
+	// Image is the image reference of the container.
+	Image string `json:"image"`
+	// Digest is the resolved digest of the image.
+	// +optional
+	Digest string `json:"digest,omitempty"`
 }
 
 // OutputStatus contains the status of a specific output of a compliance scan.
@@ -135,6 +270,9 @@ type ComplianceScanPhase string
 const (
 	// ComplianceScanPending means that the ComplianceScan is pending execution.
 	ComplianceScanPending ComplianceScanPhase = "Pending"
+	// ComplianceScanQueued means that the ComplianceScan waits until it is admitted because the
+	// maximum number of concurrently running ComplianceScans is reached.
+	ComplianceScanQueued ComplianceScanPhase = "Queued"
 	// ComplianceScanRunning means that the ComplianceScan is running.
 	ComplianceScanRunning ComplianceScanPhase = "Running"
 	// ComplianceScanCompleted means that the ComplianceScan has completed successfully.
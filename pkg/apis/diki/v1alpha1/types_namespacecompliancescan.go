@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Namespaced,path=namespacecompliancescans,shortName=nscan,singular=namespacecompliancescan
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Current phase of the compliance scan"
+// +kubebuilder:printcolumn:name="ComplianceScan",type=string,JSONPath=`.status.complianceScanName`,description="Name of the ComplianceScan running the scan"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Creation timestamp"
+
+// NamespaceComplianceScan describes a compliance scan which is restricted to its namespace.
+// It is run by a ComplianceScan with the namespace as target namespace.
+type NamespaceComplianceScan struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object metadata.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec contains the specification of this namespace compliance scan.
+	Spec NamespaceComplianceScanSpec `json:"spec,omitempty"`
+	// Status contains the status of this namespace compliance scan.
+	Status NamespaceComplianceScanStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceComplianceScanList describes a list of namespace compliance scans.
+type NamespaceComplianceScanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items contains the list of NamespaceComplianceScans.
+	Items []NamespaceComplianceScan `json:"items"`
+}
+
+// NamespaceComplianceScanSpec is the specification of a NamespaceComplianceScan.
+type NamespaceComplianceScanSpec struct {
+	// Rulesets describe the rulesets to be applied during the compliance scan.
+	// The ConfigMaps containing their options have to reside in the namespace of the NamespaceComplianceScan.
+	Rulesets []RulesetConfig `json:"rulesets,omitempty"`
+	// Output configures the export of the report to ConfigMaps in the namespace of the NamespaceComplianceScan.
+	// The report is not exported if it is not set.
+	// +optional
+	Output *NamespaceOutputConfigMap `json:"output,omitempty"`
+}
+
+// NamespaceOutputConfigMap contains the configuration for exporting the report to ConfigMaps in the namespace of a NamespaceComplianceScan.
+type NamespaceOutputConfigMap struct {
+	// NamePrefix is the prefix for the generated ConfigMap name.
+	// Defaults to "compliance-scan-report-".
+	// +kubebuilder:default="compliance-scan-report-"
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Retention contains the settings for pruning the exported report ConfigMaps.
+	// Report ConfigMaps are kept indefinitely if not set.
+	// +optional
+	Retention *OutputConfigMapRetention `json:"retention,omitempty"`
+}
+
+// NamespaceComplianceScanStatus contains the status of a NamespaceComplianceScan.
+type NamespaceComplianceScanStatus struct {
+	// Conditions contains the conditions of the NamespaceComplianceScan.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Phase represents the current phase of the NamespaceComplianceScan.
+	// +optional
+	Phase ComplianceScanPhase `json:"phase,omitempty"`
+	// ComplianceScanName is the name of the ComplianceScan which runs the NamespaceComplianceScan.
+	// +optional
+	ComplianceScanName string `json:"complianceScanName,omitempty"`
+	// Rulesets contains the ruleset summaries of the NamespaceComplianceScan.
+	// +optional
+	Rulesets []RulesetSummary `json:"rulesets,omitempty"`
+	// Outputs contain the output statuses of the NamespaceComplianceScan.
+	// +optional
+	Outputs []OutputStatus `json:"outputs,omitempty"`
+}
@@ -55,4 +55,23 @@ type OutputConfigMap struct {
 	// Defaults to "compliance-scan-report-".
 	// +kubebuilder:default="compliance-scan-report-"
 	NamePrefix string `json:"namePrefix,omitempty"`
+	// Retention contains the settings for pruning the exported report ConfigMaps.
+	// Report ConfigMaps are kept indefinitely if not set.
+	// +optional
+	Retention *OutputConfigMapRetention `json:"retention,omitempty"`
+}
+
+// OutputConfigMapRetention contains the settings for pruning the exported report ConfigMaps.
+type OutputConfigMapRetention struct {
+	// MaxCount is the maximum number of report ConfigMaps kept per ComplianceScan name.
+	// The reports of ComplianceScans created by a ScheduledComplianceScan are counted per ScheduledComplianceScan.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+	// MaxAge is the maximum age of report ConfigMaps.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// DryRun only logs the report ConfigMaps which would be pruned instead of deleting them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
@@ -13,6 +13,7 @@ import (
 // +kubebuilder:resource:scope=Cluster,path=scheduledcompliancescans,shortName=scscan,singular=scheduledcompliancescan
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Schedule",type=string,JSONPath=`.spec.schedule`,description="Cron schedule of the compliance scan"
+// +kubebuilder:printcolumn:name="Suspend",type=boolean,JSONPath=`.spec.suspend`,description="Whether scheduling of new compliance scans is suspended"
 // +kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.active.name`,description="Name of the currently active ComplianceScan"
 // +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`,description="Last time a ComplianceScan was scheduled"
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Creation timestamp"
@@ -51,6 +52,10 @@ type ScheduledComplianceScanSpec struct {
 	// FailedScansHistoryLimit is the number of failed compliance scans to keep.
 	// +optional
 	FailedScansHistoryLimit *int32 `json:"failedScansHistoryLimit,omitempty"`
+	// Suspend tells the controller to suspend subsequent scans. It does not apply to already started scans.
+	// Defaults to false.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
 	// ScanTemplate is the template for the ComplianceScan that will be created on each scheduled scan.
 	ScanTemplate ScheduledComplianceScanTemplate `json:"scanTemplate"`
 }
@@ -63,6 +68,9 @@ type ScheduledComplianceScanTemplate struct {
 
 // ScheduledComplianceScanStatus contains the status of a ScheduledComplianceScan.
 type ScheduledComplianceScanStatus struct {
+	// Conditions contains the conditions of the ScheduledComplianceScan.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
 	// Active is a reference to the currently active ComplianceScan, if any.
 	// +optional
 	Active *corev1.ObjectReference `json:"active,omitempty"`
@@ -73,3 +81,12 @@ type ScheduledComplianceScanStatus struct {
 	// +optional
 	LastCompletionTime *metav1.Time `json:"lastCompletionTime,omitempty"`
 }
+
+const (
+	// ConditionTypeScheduleValid indicates whether the schedule of the ScheduledComplianceScan is a valid cron expression.
+	ConditionTypeScheduleValid ConditionType = "ScheduleValid"
+	// ConditionTypeLastRunSucceeded indicates whether the last finished ComplianceScan of the ScheduledComplianceScan has completed successfully.
+	ConditionTypeLastRunSucceeded ConditionType = "LastRunSucceeded"
+	// ConditionTypeSuspended indicates whether the scheduling of new ComplianceScans is suspended.
+	ConditionTypeSuspended ConditionType = "Suspended"
+)
@@ -13,8 +13,8 @@ import (
 	unsafe "unsafe"
 
 	diki "github.com/gardener/diki-operator/pkg/apis/diki"
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -46,6 +46,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ComplianceScanSnapshot)(nil), (*diki.ComplianceScanSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ComplianceScanSnapshot_To_diki_ComplianceScanSnapshot(a.(*ComplianceScanSnapshot), b.(*diki.ComplianceScanSnapshot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.ComplianceScanSnapshot)(nil), (*ComplianceScanSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_ComplianceScanSnapshot_To_v1alpha1_ComplianceScanSnapshot(a.(*diki.ComplianceScanSnapshot), b.(*ComplianceScanSnapshot), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ComplianceScanSpec)(nil), (*diki.ComplianceScanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ComplianceScanSpec_To_diki_ComplianceScanSpec(a.(*ComplianceScanSpec), b.(*diki.ComplianceScanSpec), scope)
 	}); err != nil {
@@ -76,6 +86,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ImageOverrides)(nil), (*diki.ImageOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageOverrides_To_diki_ImageOverrides(a.(*ImageOverrides), b.(*diki.ImageOverrides), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.ImageOverrides)(nil), (*ImageOverrides)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_ImageOverrides_To_v1alpha1_ImageOverrides(a.(*diki.ImageOverrides), b.(*ImageOverrides), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageStatus)(nil), (*diki.ImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ImageStatus_To_diki_ImageStatus(a.(*ImageStatus), b.(*diki.ImageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.ImageStatus)(nil), (*ImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_ImageStatus_To_v1alpha1_ImageStatus(a.(*diki.ImageStatus), b.(*ImageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceComplianceScan)(nil), (*diki.NamespaceComplianceScan)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NamespaceComplianceScan_To_diki_NamespaceComplianceScan(a.(*NamespaceComplianceScan), b.(*diki.NamespaceComplianceScan), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.NamespaceComplianceScan)(nil), (*NamespaceComplianceScan)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_NamespaceComplianceScan_To_v1alpha1_NamespaceComplianceScan(a.(*diki.NamespaceComplianceScan), b.(*NamespaceComplianceScan), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceComplianceScanList)(nil), (*diki.NamespaceComplianceScanList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NamespaceComplianceScanList_To_diki_NamespaceComplianceScanList(a.(*NamespaceComplianceScanList), b.(*diki.NamespaceComplianceScanList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.NamespaceComplianceScanList)(nil), (*NamespaceComplianceScanList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_NamespaceComplianceScanList_To_v1alpha1_NamespaceComplianceScanList(a.(*diki.NamespaceComplianceScanList), b.(*NamespaceComplianceScanList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceComplianceScanSpec)(nil), (*diki.NamespaceComplianceScanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NamespaceComplianceScanSpec_To_diki_NamespaceComplianceScanSpec(a.(*NamespaceComplianceScanSpec), b.(*diki.NamespaceComplianceScanSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.NamespaceComplianceScanSpec)(nil), (*NamespaceComplianceScanSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_NamespaceComplianceScanSpec_To_v1alpha1_NamespaceComplianceScanSpec(a.(*diki.NamespaceComplianceScanSpec), b.(*NamespaceComplianceScanSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceComplianceScanStatus)(nil), (*diki.NamespaceComplianceScanStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NamespaceComplianceScanStatus_To_diki_NamespaceComplianceScanStatus(a.(*NamespaceComplianceScanStatus), b.(*diki.NamespaceComplianceScanStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.NamespaceComplianceScanStatus)(nil), (*NamespaceComplianceScanStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_NamespaceComplianceScanStatus_To_v1alpha1_NamespaceComplianceScanStatus(a.(*diki.NamespaceComplianceScanStatus), b.(*NamespaceComplianceScanStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NamespaceOutputConfigMap)(nil), (*diki.NamespaceOutputConfigMap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NamespaceOutputConfigMap_To_diki_NamespaceOutputConfigMap(a.(*NamespaceOutputConfigMap), b.(*diki.NamespaceOutputConfigMap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.NamespaceOutputConfigMap)(nil), (*NamespaceOutputConfigMap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_NamespaceOutputConfigMap_To_v1alpha1_NamespaceOutputConfigMap(a.(*diki.NamespaceOutputConfigMap), b.(*NamespaceOutputConfigMap), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Options)(nil), (*diki.Options)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Options_To_diki_Options(a.(*Options), b.(*diki.Options), scope)
 	}); err != nil {
@@ -96,6 +176,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OptionsSnapshot)(nil), (*diki.OptionsSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OptionsSnapshot_To_diki_OptionsSnapshot(a.(*OptionsSnapshot), b.(*diki.OptionsSnapshot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.OptionsSnapshot)(nil), (*OptionsSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_OptionsSnapshot_To_v1alpha1_OptionsSnapshot(a.(*diki.OptionsSnapshot), b.(*OptionsSnapshot), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Output)(nil), (*diki.Output)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Output_To_diki_Output(a.(*Output), b.(*diki.Output), scope)
 	}); err != nil {
@@ -116,6 +206,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OutputConfigMapRetention)(nil), (*diki.OutputConfigMapRetention)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OutputConfigMapRetention_To_diki_OutputConfigMapRetention(a.(*OutputConfigMapRetention), b.(*diki.OutputConfigMapRetention), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.OutputConfigMapRetention)(nil), (*OutputConfigMapRetention)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_OutputConfigMapRetention_To_v1alpha1_OutputConfigMapRetention(a.(*diki.OutputConfigMapRetention), b.(*OutputConfigMapRetention), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OutputSnapshot)(nil), (*diki.OutputSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OutputSnapshot_To_diki_OutputSnapshot(a.(*OutputSnapshot), b.(*diki.OutputSnapshot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.OutputSnapshot)(nil), (*OutputSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_OutputSnapshot_To_v1alpha1_OutputSnapshot(a.(*diki.OutputSnapshot), b.(*OutputSnapshot), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*OutputStatus)(nil), (*diki.OutputStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_OutputStatus_To_diki_OutputStatus(a.(*OutputStatus), b.(*diki.OutputStatus), scope)
 	}); err != nil {
@@ -226,6 +336,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RulesetSnapshot)(nil), (*diki.RulesetSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RulesetSnapshot_To_diki_RulesetSnapshot(a.(*RulesetSnapshot), b.(*diki.RulesetSnapshot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.RulesetSnapshot)(nil), (*RulesetSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_RulesetSnapshot_To_v1alpha1_RulesetSnapshot(a.(*diki.RulesetSnapshot), b.(*RulesetSnapshot), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RulesetSummary)(nil), (*diki.RulesetSummary)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RulesetSummary_To_diki_RulesetSummary(a.(*RulesetSummary), b.(*diki.RulesetSummary), scope)
 	}); err != nil {
@@ -286,6 +406,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SnapshotImages)(nil), (*diki.SnapshotImages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_SnapshotImages_To_diki_SnapshotImages(a.(*SnapshotImages), b.(*diki.SnapshotImages), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*diki.SnapshotImages)(nil), (*SnapshotImages)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_diki_SnapshotImages_To_v1alpha1_SnapshotImages(a.(*diki.SnapshotImages), b.(*SnapshotImages), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -343,9 +473,43 @@ func Convert_diki_ComplianceScanList_To_v1alpha1_ComplianceScanList(in *diki.Com
 	return autoConvert_diki_ComplianceScanList_To_v1alpha1_ComplianceScanList(in, out, s)
 }
 
+func autoConvert_v1alpha1_ComplianceScanSnapshot_To_diki_ComplianceScanSnapshot(in *ComplianceScanSnapshot, out *diki.ComplianceScanSnapshot, s conversion.Scope) error {
+	if err := Convert_v1alpha1_SnapshotImages_To_diki_SnapshotImages(&in.Images, &out.Images, s); err != nil {
+		return err
+	}
+	out.Rulesets = *(*[]diki.RulesetSnapshot)(unsafe.Pointer(&in.Rulesets))
+	out.Outputs = *(*[]diki.OutputSnapshot)(unsafe.Pointer(&in.Outputs))
+	return nil
+}
+
+// Convert_v1alpha1_ComplianceScanSnapshot_To_diki_ComplianceScanSnapshot is an autogenerated conversion function.
+func Convert_v1alpha1_ComplianceScanSnapshot_To_diki_ComplianceScanSnapshot(in *ComplianceScanSnapshot, out *diki.ComplianceScanSnapshot, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ComplianceScanSnapshot_To_diki_ComplianceScanSnapshot(in, out, s)
+}
+
+func autoConvert_diki_ComplianceScanSnapshot_To_v1alpha1_ComplianceScanSnapshot(in *diki.ComplianceScanSnapshot, out *ComplianceScanSnapshot, s conversion.Scope) error {
+	if err := Convert_diki_SnapshotImages_To_v1alpha1_SnapshotImages(&in.Images, &out.Images, s); err != nil {
+		return err
+	}
+	out.Rulesets = *(*[]RulesetSnapshot)(unsafe.Pointer(&in.Rulesets))
+	out.Outputs = *(*[]OutputSnapshot)(unsafe.Pointer(&in.Outputs))
+	return nil
+}
+
+// Convert_diki_ComplianceScanSnapshot_To_v1alpha1_ComplianceScanSnapshot is an autogenerated conversion function.
+func Convert_diki_ComplianceScanSnapshot_To_v1alpha1_ComplianceScanSnapshot(in *diki.ComplianceScanSnapshot, out *ComplianceScanSnapshot, s conversion.Scope) error {
+	return autoConvert_diki_ComplianceScanSnapshot_To_v1alpha1_ComplianceScanSnapshot(in, out, s)
+}
+
 func autoConvert_v1alpha1_ComplianceScanSpec_To_diki_ComplianceScanSpec(in *ComplianceScanSpec, out *diki.ComplianceScanSpec, s conversion.Scope) error {
 	out.Rulesets = *(*[]diki.RulesetConfig)(unsafe.Pointer(&in.Rulesets))
 	out.Outputs = *(*[]diki.ReportOutputRef)(unsafe.Pointer(&in.Outputs))
+	out.RunnerProfile = in.RunnerProfile
+	out.Image = (*diki.ImageOverrides)(unsafe.Pointer(in.Image))
+	out.Parallelism = (*int32)(unsafe.Pointer(in.Parallelism))
+	out.Priority = (*int32)(unsafe.Pointer(in.Priority))
+	out.TTLSecondsAfterFinished = (*int32)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	out.TargetNamespace = in.TargetNamespace
 	return nil
 }
 
@@ -357,6 +521,12 @@ func Convert_v1alpha1_ComplianceScanSpec_To_diki_ComplianceScanSpec(in *Complian
 func autoConvert_diki_ComplianceScanSpec_To_v1alpha1_ComplianceScanSpec(in *diki.ComplianceScanSpec, out *ComplianceScanSpec, s conversion.Scope) error {
 	out.Rulesets = *(*[]RulesetConfig)(unsafe.Pointer(&in.Rulesets))
 	out.Outputs = *(*[]ReportOutputRef)(unsafe.Pointer(&in.Outputs))
+	out.RunnerProfile = in.RunnerProfile
+	out.Image = (*ImageOverrides)(unsafe.Pointer(in.Image))
+	out.Parallelism = (*int32)(unsafe.Pointer(in.Parallelism))
+	out.Priority = (*int32)(unsafe.Pointer(in.Priority))
+	out.TTLSecondsAfterFinished = (*int32)(unsafe.Pointer(in.TTLSecondsAfterFinished))
+	out.TargetNamespace = in.TargetNamespace
 	return nil
 }
 
@@ -370,6 +540,9 @@ func autoConvert_v1alpha1_ComplianceScanStatus_To_diki_ComplianceScanStatus(in *
 	out.Phase = diki.ComplianceScanPhase(in.Phase)
 	out.Rulesets = *(*[]diki.RulesetSummary)(unsafe.Pointer(&in.Rulesets))
 	out.Outputs = *(*[]diki.OutputStatus)(unsafe.Pointer(&in.Outputs))
+	out.Images = *(*[]diki.ImageStatus)(unsafe.Pointer(&in.Images))
+	out.QueuePosition = (*int32)(unsafe.Pointer(in.QueuePosition))
+	out.Snapshot = (*diki.ComplianceScanSnapshot)(unsafe.Pointer(in.Snapshot))
 	return nil
 }
 
@@ -383,6 +556,9 @@ func autoConvert_diki_ComplianceScanStatus_To_v1alpha1_ComplianceScanStatus(in *
 	out.Phase = ComplianceScanPhase(in.Phase)
 	out.Rulesets = *(*[]RulesetSummary)(unsafe.Pointer(&in.Rulesets))
 	out.Outputs = *(*[]OutputStatus)(unsafe.Pointer(&in.Outputs))
+	out.Images = *(*[]ImageStatus)(unsafe.Pointer(&in.Images))
+	out.QueuePosition = (*int32)(unsafe.Pointer(in.QueuePosition))
+	out.Snapshot = (*ComplianceScanSnapshot)(unsafe.Pointer(in.Snapshot))
 	return nil
 }
 
@@ -421,6 +597,178 @@ func Convert_diki_Condition_To_v1alpha1_Condition(in *diki.Condition, out *Condi
 	return autoConvert_diki_Condition_To_v1alpha1_Condition(in, out, s)
 }
 
+func autoConvert_v1alpha1_ImageOverrides_To_diki_ImageOverrides(in *ImageOverrides, out *diki.ImageOverrides, s conversion.Scope) error {
+	out.Diki = in.Diki
+	out.ReportExporter = in.ReportExporter
+	return nil
+}
+
+// Convert_v1alpha1_ImageOverrides_To_diki_ImageOverrides is an autogenerated conversion function.
+func Convert_v1alpha1_ImageOverrides_To_diki_ImageOverrides(in *ImageOverrides, out *diki.ImageOverrides, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageOverrides_To_diki_ImageOverrides(in, out, s)
+}
+
+func autoConvert_diki_ImageOverrides_To_v1alpha1_ImageOverrides(in *diki.ImageOverrides, out *ImageOverrides, s conversion.Scope) error {
+	out.Diki = in.Diki
+	out.ReportExporter = in.ReportExporter
+	return nil
+}
+
+// Convert_diki_ImageOverrides_To_v1alpha1_ImageOverrides is an autogenerated conversion function.
+func Convert_diki_ImageOverrides_To_v1alpha1_ImageOverrides(in *diki.ImageOverrides, out *ImageOverrides, s conversion.Scope) error {
+	return autoConvert_diki_ImageOverrides_To_v1alpha1_ImageOverrides(in, out, s)
+}
+
+func autoConvert_v1alpha1_ImageStatus_To_diki_ImageStatus(in *ImageStatus, out *diki.ImageStatus, s conversion.Scope) error {
+	out.Container = in.Container
+	out.Image = in.Image
+	out.Digest = in.Digest
+	return nil
+}
+
+// Convert_v1alpha1_ImageStatus_To_diki_ImageStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ImageStatus_To_diki_ImageStatus(in *ImageStatus, out *diki.ImageStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ImageStatus_To_diki_ImageStatus(in, out, s)
+}
+
+func autoConvert_diki_ImageStatus_To_v1alpha1_ImageStatus(in *diki.ImageStatus, out *ImageStatus, s conversion.Scope) error {
+	out.Container = in.Container
+	out.Image = in.Image
+	out.Digest = in.Digest
+	return nil
+}
+
+// Convert_diki_ImageStatus_To_v1alpha1_ImageStatus is an autogenerated conversion function.
+func Convert_diki_ImageStatus_To_v1alpha1_ImageStatus(in *diki.ImageStatus, out *ImageStatus, s conversion.Scope) error {
+	return autoConvert_diki_ImageStatus_To_v1alpha1_ImageStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_NamespaceComplianceScan_To_diki_NamespaceComplianceScan(in *NamespaceComplianceScan, out *diki.NamespaceComplianceScan, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_NamespaceComplianceScanSpec_To_diki_NamespaceComplianceScanSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NamespaceComplianceScanStatus_To_diki_NamespaceComplianceScanStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_NamespaceComplianceScan_To_diki_NamespaceComplianceScan is an autogenerated conversion function.
+func Convert_v1alpha1_NamespaceComplianceScan_To_diki_NamespaceComplianceScan(in *NamespaceComplianceScan, out *diki.NamespaceComplianceScan, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NamespaceComplianceScan_To_diki_NamespaceComplianceScan(in, out, s)
+}
+
+func autoConvert_diki_NamespaceComplianceScan_To_v1alpha1_NamespaceComplianceScan(in *diki.NamespaceComplianceScan, out *NamespaceComplianceScan, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_diki_NamespaceComplianceScanSpec_To_v1alpha1_NamespaceComplianceScanSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_diki_NamespaceComplianceScanStatus_To_v1alpha1_NamespaceComplianceScanStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_diki_NamespaceComplianceScan_To_v1alpha1_NamespaceComplianceScan is an autogenerated conversion function.
+func Convert_diki_NamespaceComplianceScan_To_v1alpha1_NamespaceComplianceScan(in *diki.NamespaceComplianceScan, out *NamespaceComplianceScan, s conversion.Scope) error {
+	return autoConvert_diki_NamespaceComplianceScan_To_v1alpha1_NamespaceComplianceScan(in, out, s)
+}
+
+func autoConvert_v1alpha1_NamespaceComplianceScanList_To_diki_NamespaceComplianceScanList(in *NamespaceComplianceScanList, out *diki.NamespaceComplianceScanList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]diki.NamespaceComplianceScan)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_NamespaceComplianceScanList_To_diki_NamespaceComplianceScanList is an autogenerated conversion function.
+func Convert_v1alpha1_NamespaceComplianceScanList_To_diki_NamespaceComplianceScanList(in *NamespaceComplianceScanList, out *diki.NamespaceComplianceScanList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NamespaceComplianceScanList_To_diki_NamespaceComplianceScanList(in, out, s)
+}
+
+func autoConvert_diki_NamespaceComplianceScanList_To_v1alpha1_NamespaceComplianceScanList(in *diki.NamespaceComplianceScanList, out *NamespaceComplianceScanList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]NamespaceComplianceScan)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_diki_NamespaceComplianceScanList_To_v1alpha1_NamespaceComplianceScanList is an autogenerated conversion function.
+func Convert_diki_NamespaceComplianceScanList_To_v1alpha1_NamespaceComplianceScanList(in *diki.NamespaceComplianceScanList, out *NamespaceComplianceScanList, s conversion.Scope) error {
+	return autoConvert_diki_NamespaceComplianceScanList_To_v1alpha1_NamespaceComplianceScanList(in, out, s)
+}
+
+func autoConvert_v1alpha1_NamespaceComplianceScanSpec_To_diki_NamespaceComplianceScanSpec(in *NamespaceComplianceScanSpec, out *diki.NamespaceComplianceScanSpec, s conversion.Scope) error {
+	out.Rulesets = *(*[]diki.RulesetConfig)(unsafe.Pointer(&in.Rulesets))
+	out.Output = (*diki.NamespaceOutputConfigMap)(unsafe.Pointer(in.Output))
+	return nil
+}
+
+// Convert_v1alpha1_NamespaceComplianceScanSpec_To_diki_NamespaceComplianceScanSpec is an autogenerated conversion function.
+func Convert_v1alpha1_NamespaceComplianceScanSpec_To_diki_NamespaceComplianceScanSpec(in *NamespaceComplianceScanSpec, out *diki.NamespaceComplianceScanSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NamespaceComplianceScanSpec_To_diki_NamespaceComplianceScanSpec(in, out, s)
+}
+
+func autoConvert_diki_NamespaceComplianceScanSpec_To_v1alpha1_NamespaceComplianceScanSpec(in *diki.NamespaceComplianceScanSpec, out *NamespaceComplianceScanSpec, s conversion.Scope) error {
+	out.Rulesets = *(*[]RulesetConfig)(unsafe.Pointer(&in.Rulesets))
+	out.Output = (*NamespaceOutputConfigMap)(unsafe.Pointer(in.Output))
+	return nil
+}
+
+// Convert_diki_NamespaceComplianceScanSpec_To_v1alpha1_NamespaceComplianceScanSpec is an autogenerated conversion function.
+func Convert_diki_NamespaceComplianceScanSpec_To_v1alpha1_NamespaceComplianceScanSpec(in *diki.NamespaceComplianceScanSpec, out *NamespaceComplianceScanSpec, s conversion.Scope) error {
+	return autoConvert_diki_NamespaceComplianceScanSpec_To_v1alpha1_NamespaceComplianceScanSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_NamespaceComplianceScanStatus_To_diki_NamespaceComplianceScanStatus(in *NamespaceComplianceScanStatus, out *diki.NamespaceComplianceScanStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]diki.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Phase = diki.ComplianceScanPhase(in.Phase)
+	out.ComplianceScanName = in.ComplianceScanName
+	out.Rulesets = *(*[]diki.RulesetSummary)(unsafe.Pointer(&in.Rulesets))
+	out.Outputs = *(*[]diki.OutputStatus)(unsafe.Pointer(&in.Outputs))
+	return nil
+}
+
+// Convert_v1alpha1_NamespaceComplianceScanStatus_To_diki_NamespaceComplianceScanStatus is an autogenerated conversion function.
+func Convert_v1alpha1_NamespaceComplianceScanStatus_To_diki_NamespaceComplianceScanStatus(in *NamespaceComplianceScanStatus, out *diki.NamespaceComplianceScanStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NamespaceComplianceScanStatus_To_diki_NamespaceComplianceScanStatus(in, out, s)
+}
+
+func autoConvert_diki_NamespaceComplianceScanStatus_To_v1alpha1_NamespaceComplianceScanStatus(in *diki.NamespaceComplianceScanStatus, out *NamespaceComplianceScanStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]Condition)(unsafe.Pointer(&in.Conditions))
+	out.Phase = ComplianceScanPhase(in.Phase)
+	out.ComplianceScanName = in.ComplianceScanName
+	out.Rulesets = *(*[]RulesetSummary)(unsafe.Pointer(&in.Rulesets))
+	out.Outputs = *(*[]OutputStatus)(unsafe.Pointer(&in.Outputs))
+	return nil
+}
+
+// Convert_diki_NamespaceComplianceScanStatus_To_v1alpha1_NamespaceComplianceScanStatus is an autogenerated conversion function.
+func Convert_diki_NamespaceComplianceScanStatus_To_v1alpha1_NamespaceComplianceScanStatus(in *diki.NamespaceComplianceScanStatus, out *NamespaceComplianceScanStatus, s conversion.Scope) error {
+	return autoConvert_diki_NamespaceComplianceScanStatus_To_v1alpha1_NamespaceComplianceScanStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_NamespaceOutputConfigMap_To_diki_NamespaceOutputConfigMap(in *NamespaceOutputConfigMap, out *diki.NamespaceOutputConfigMap, s conversion.Scope) error {
+	out.NamePrefix = in.NamePrefix
+	out.Retention = (*diki.OutputConfigMapRetention)(unsafe.Pointer(in.Retention))
+	return nil
+}
+
+// Convert_v1alpha1_NamespaceOutputConfigMap_To_diki_NamespaceOutputConfigMap is an autogenerated conversion function.
+func Convert_v1alpha1_NamespaceOutputConfigMap_To_diki_NamespaceOutputConfigMap(in *NamespaceOutputConfigMap, out *diki.NamespaceOutputConfigMap, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NamespaceOutputConfigMap_To_diki_NamespaceOutputConfigMap(in, out, s)
+}
+
+func autoConvert_diki_NamespaceOutputConfigMap_To_v1alpha1_NamespaceOutputConfigMap(in *diki.NamespaceOutputConfigMap, out *NamespaceOutputConfigMap, s conversion.Scope) error {
+	out.NamePrefix = in.NamePrefix
+	out.Retention = (*OutputConfigMapRetention)(unsafe.Pointer(in.Retention))
+	return nil
+}
+
+// Convert_diki_NamespaceOutputConfigMap_To_v1alpha1_NamespaceOutputConfigMap is an autogenerated conversion function.
+func Convert_diki_NamespaceOutputConfigMap_To_v1alpha1_NamespaceOutputConfigMap(in *diki.NamespaceOutputConfigMap, out *NamespaceOutputConfigMap, s conversion.Scope) error {
+	return autoConvert_diki_NamespaceOutputConfigMap_To_v1alpha1_NamespaceOutputConfigMap(in, out, s)
+}
+
 func autoConvert_v1alpha1_Options_To_diki_Options(in *Options, out *diki.Options, s conversion.Scope) error {
 	out.ConfigMapRef = (*diki.OptionsConfigMapRef)(unsafe.Pointer(in.ConfigMapRef))
 	return nil
@@ -465,6 +813,36 @@ func Convert_diki_OptionsConfigMapRef_To_v1alpha1_OptionsConfigMapRef(in *diki.O
 	return autoConvert_diki_OptionsConfigMapRef_To_v1alpha1_OptionsConfigMapRef(in, out, s)
 }
 
+func autoConvert_v1alpha1_OptionsSnapshot_To_diki_OptionsSnapshot(in *OptionsSnapshot, out *diki.OptionsSnapshot, s conversion.Scope) error {
+	if err := Convert_v1alpha1_OptionsConfigMapRef_To_diki_OptionsConfigMapRef(&in.ConfigMapRef, &out.ConfigMapRef, s); err != nil {
+		return err
+	}
+	out.ResourceVersion = in.ResourceVersion
+	out.Hash = in.Hash
+	out.Data = in.Data
+	return nil
+}
+
+// Convert_v1alpha1_OptionsSnapshot_To_diki_OptionsSnapshot is an autogenerated conversion function.
+func Convert_v1alpha1_OptionsSnapshot_To_diki_OptionsSnapshot(in *OptionsSnapshot, out *diki.OptionsSnapshot, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OptionsSnapshot_To_diki_OptionsSnapshot(in, out, s)
+}
+
+func autoConvert_diki_OptionsSnapshot_To_v1alpha1_OptionsSnapshot(in *diki.OptionsSnapshot, out *OptionsSnapshot, s conversion.Scope) error {
+	if err := Convert_diki_OptionsConfigMapRef_To_v1alpha1_OptionsConfigMapRef(&in.ConfigMapRef, &out.ConfigMapRef, s); err != nil {
+		return err
+	}
+	out.ResourceVersion = in.ResourceVersion
+	out.Hash = in.Hash
+	out.Data = in.Data
+	return nil
+}
+
+// Convert_diki_OptionsSnapshot_To_v1alpha1_OptionsSnapshot is an autogenerated conversion function.
+func Convert_diki_OptionsSnapshot_To_v1alpha1_OptionsSnapshot(in *diki.OptionsSnapshot, out *OptionsSnapshot, s conversion.Scope) error {
+	return autoConvert_diki_OptionsSnapshot_To_v1alpha1_OptionsSnapshot(in, out, s)
+}
+
 func autoConvert_v1alpha1_Output_To_diki_Output(in *Output, out *diki.Output, s conversion.Scope) error {
 	out.ConfigMap = (*diki.OutputConfigMap)(unsafe.Pointer(in.ConfigMap))
 	return nil
@@ -488,6 +866,7 @@ func Convert_diki_Output_To_v1alpha1_Output(in *diki.Output, out *Output, s conv
 func autoConvert_v1alpha1_OutputConfigMap_To_diki_OutputConfigMap(in *OutputConfigMap, out *diki.OutputConfigMap, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.NamePrefix = in.NamePrefix
+	out.Retention = (*diki.OutputConfigMapRetention)(unsafe.Pointer(in.Retention))
 	return nil
 }
 
@@ -499,6 +878,7 @@ func Convert_v1alpha1_OutputConfigMap_To_diki_OutputConfigMap(in *OutputConfigMa
 func autoConvert_diki_OutputConfigMap_To_v1alpha1_OutputConfigMap(in *diki.OutputConfigMap, out *OutputConfigMap, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.NamePrefix = in.NamePrefix
+	out.Retention = (*OutputConfigMapRetention)(unsafe.Pointer(in.Retention))
 	return nil
 }
 
@@ -507,6 +887,56 @@ func Convert_diki_OutputConfigMap_To_v1alpha1_OutputConfigMap(in *diki.OutputCon
 	return autoConvert_diki_OutputConfigMap_To_v1alpha1_OutputConfigMap(in, out, s)
 }
 
+func autoConvert_v1alpha1_OutputConfigMapRetention_To_diki_OutputConfigMapRetention(in *OutputConfigMapRetention, out *diki.OutputConfigMapRetention, s conversion.Scope) error {
+	out.MaxCount = (*int32)(unsafe.Pointer(in.MaxCount))
+	out.MaxAge = (*v1.Duration)(unsafe.Pointer(in.MaxAge))
+	out.DryRun = in.DryRun
+	return nil
+}
+
+// Convert_v1alpha1_OutputConfigMapRetention_To_diki_OutputConfigMapRetention is an autogenerated conversion function.
+func Convert_v1alpha1_OutputConfigMapRetention_To_diki_OutputConfigMapRetention(in *OutputConfigMapRetention, out *diki.OutputConfigMapRetention, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OutputConfigMapRetention_To_diki_OutputConfigMapRetention(in, out, s)
+}
+
+func autoConvert_diki_OutputConfigMapRetention_To_v1alpha1_OutputConfigMapRetention(in *diki.OutputConfigMapRetention, out *OutputConfigMapRetention, s conversion.Scope) error {
+	out.MaxCount = (*int32)(unsafe.Pointer(in.MaxCount))
+	out.MaxAge = (*v1.Duration)(unsafe.Pointer(in.MaxAge))
+	out.DryRun = in.DryRun
+	return nil
+}
+
+// Convert_diki_OutputConfigMapRetention_To_v1alpha1_OutputConfigMapRetention is an autogenerated conversion function.
+func Convert_diki_OutputConfigMapRetention_To_v1alpha1_OutputConfigMapRetention(in *diki.OutputConfigMapRetention, out *OutputConfigMapRetention, s conversion.Scope) error {
+	return autoConvert_diki_OutputConfigMapRetention_To_v1alpha1_OutputConfigMapRetention(in, out, s)
+}
+
+func autoConvert_v1alpha1_OutputSnapshot_To_diki_OutputSnapshot(in *OutputSnapshot, out *diki.OutputSnapshot, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := Convert_v1alpha1_Output_To_diki_Output(&in.Output, &out.Output, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_OutputSnapshot_To_diki_OutputSnapshot is an autogenerated conversion function.
+func Convert_v1alpha1_OutputSnapshot_To_diki_OutputSnapshot(in *OutputSnapshot, out *diki.OutputSnapshot, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OutputSnapshot_To_diki_OutputSnapshot(in, out, s)
+}
+
+func autoConvert_diki_OutputSnapshot_To_v1alpha1_OutputSnapshot(in *diki.OutputSnapshot, out *OutputSnapshot, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := Convert_diki_Output_To_v1alpha1_Output(&in.Output, &out.Output, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_diki_OutputSnapshot_To_v1alpha1_OutputSnapshot is an autogenerated conversion function.
+func Convert_diki_OutputSnapshot_To_v1alpha1_OutputSnapshot(in *diki.OutputSnapshot, out *OutputSnapshot, s conversion.Scope) error {
+	return autoConvert_diki_OutputSnapshot_To_v1alpha1_OutputSnapshot(in, out, s)
+}
+
 func autoConvert_v1alpha1_OutputStatus_To_diki_OutputStatus(in *OutputStatus, out *diki.OutputStatus, s conversion.Scope) error {
 	out.OutputName = in.OutputName
 	out.Phase = diki.OutputStatusPhase(in.Phase)
@@ -581,6 +1011,7 @@ func Convert_diki_ReportOutputList_To_v1alpha1_ReportOutputList(in *diki.ReportO
 
 func autoConvert_v1alpha1_ReportOutputRef_To_diki_ReportOutputRef(in *ReportOutputRef, out *diki.ReportOutputRef, s conversion.Scope) error {
 	out.Name = in.Name
+	out.DeletionPolicy = diki.DeletionPolicy(in.DeletionPolicy)
 	return nil
 }
 
@@ -591,6 +1022,7 @@ func Convert_v1alpha1_ReportOutputRef_To_diki_ReportOutputRef(in *ReportOutputRe
 
 func autoConvert_diki_ReportOutputRef_To_v1alpha1_ReportOutputRef(in *diki.ReportOutputRef, out *ReportOutputRef, s conversion.Scope) error {
 	out.Name = in.Name
+	out.DeletionPolicy = DeletionPolicy(in.DeletionPolicy)
 	return nil
 }
 
@@ -771,6 +1203,32 @@ func Convert_diki_RulesetOptions_To_v1alpha1_RulesetOptions(in *diki.RulesetOpti
 	return autoConvert_diki_RulesetOptions_To_v1alpha1_RulesetOptions(in, out, s)
 }
 
+func autoConvert_v1alpha1_RulesetSnapshot_To_diki_RulesetSnapshot(in *RulesetSnapshot, out *diki.RulesetSnapshot, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Version = in.Version
+	out.RulesetOptions = (*diki.OptionsSnapshot)(unsafe.Pointer(in.RulesetOptions))
+	out.RuleOptions = (*diki.OptionsSnapshot)(unsafe.Pointer(in.RuleOptions))
+	return nil
+}
+
+// Convert_v1alpha1_RulesetSnapshot_To_diki_RulesetSnapshot is an autogenerated conversion function.
+func Convert_v1alpha1_RulesetSnapshot_To_diki_RulesetSnapshot(in *RulesetSnapshot, out *diki.RulesetSnapshot, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RulesetSnapshot_To_diki_RulesetSnapshot(in, out, s)
+}
+
+func autoConvert_diki_RulesetSnapshot_To_v1alpha1_RulesetSnapshot(in *diki.RulesetSnapshot, out *RulesetSnapshot, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Version = in.Version
+	out.RulesetOptions = (*OptionsSnapshot)(unsafe.Pointer(in.RulesetOptions))
+	out.RuleOptions = (*OptionsSnapshot)(unsafe.Pointer(in.RuleOptions))
+	return nil
+}
+
+// Convert_diki_RulesetSnapshot_To_v1alpha1_RulesetSnapshot is an autogenerated conversion function.
+func Convert_diki_RulesetSnapshot_To_v1alpha1_RulesetSnapshot(in *diki.RulesetSnapshot, out *RulesetSnapshot, s conversion.Scope) error {
+	return autoConvert_diki_RulesetSnapshot_To_v1alpha1_RulesetSnapshot(in, out, s)
+}
+
 func autoConvert_v1alpha1_RulesetSummary_To_diki_RulesetSummary(in *RulesetSummary, out *diki.RulesetSummary, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Version = in.Version
@@ -857,6 +1315,7 @@ func autoConvert_v1alpha1_ScheduledComplianceScanSpec_To_diki_ScheduledComplianc
 	out.Schedule = in.Schedule
 	out.SuccessfulScansHistoryLimit = (*int32)(unsafe.Pointer(in.SuccessfulScansHistoryLimit))
 	out.FailedScansHistoryLimit = (*int32)(unsafe.Pointer(in.FailedScansHistoryLimit))
+	out.Suspend = (*bool)(unsafe.Pointer(in.Suspend))
 	if err := Convert_v1alpha1_ScheduledComplianceScanTemplate_To_diki_ScheduledComplianceScanTemplate(&in.ScanTemplate, &out.ScanTemplate, s); err != nil {
 		return err
 	}
@@ -872,6 +1331,7 @@ func autoConvert_diki_ScheduledComplianceScanSpec_To_v1alpha1_ScheduledComplianc
 	out.Schedule = in.Schedule
 	out.SuccessfulScansHistoryLimit = (*int32)(unsafe.Pointer(in.SuccessfulScansHistoryLimit))
 	out.FailedScansHistoryLimit = (*int32)(unsafe.Pointer(in.FailedScansHistoryLimit))
+	out.Suspend = (*bool)(unsafe.Pointer(in.Suspend))
 	if err := Convert_diki_ScheduledComplianceScanTemplate_To_v1alpha1_ScheduledComplianceScanTemplate(&in.ScanTemplate, &out.ScanTemplate, s); err != nil {
 		return err
 	}
@@ -884,9 +1344,10 @@ func Convert_diki_ScheduledComplianceScanSpec_To_v1alpha1_ScheduledComplianceSca
 }
 
 func autoConvert_v1alpha1_ScheduledComplianceScanStatus_To_diki_ScheduledComplianceScanStatus(in *ScheduledComplianceScanStatus, out *diki.ScheduledComplianceScanStatus, s conversion.Scope) error {
-	out.Active = (*v1.ObjectReference)(unsafe.Pointer(in.Active))
-	out.LastScheduleTime = (*metav1.Time)(unsafe.Pointer(in.LastScheduleTime))
-	out.LastCompletionTime = (*metav1.Time)(unsafe.Pointer(in.LastCompletionTime))
+	out.Conditions = *(*[]diki.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Active = (*corev1.ObjectReference)(unsafe.Pointer(in.Active))
+	out.LastScheduleTime = (*v1.Time)(unsafe.Pointer(in.LastScheduleTime))
+	out.LastCompletionTime = (*v1.Time)(unsafe.Pointer(in.LastCompletionTime))
 	return nil
 }
 
@@ -896,9 +1357,10 @@ func Convert_v1alpha1_ScheduledComplianceScanStatus_To_diki_ScheduledComplianceS
 }
 
 func autoConvert_diki_ScheduledComplianceScanStatus_To_v1alpha1_ScheduledComplianceScanStatus(in *diki.ScheduledComplianceScanStatus, out *ScheduledComplianceScanStatus, s conversion.Scope) error {
-	out.Active = (*v1.ObjectReference)(unsafe.Pointer(in.Active))
-	out.LastScheduleTime = (*metav1.Time)(unsafe.Pointer(in.LastScheduleTime))
-	out.LastCompletionTime = (*metav1.Time)(unsafe.Pointer(in.LastCompletionTime))
+	out.Conditions = *(*[]Condition)(unsafe.Pointer(&in.Conditions))
+	out.Active = (*corev1.ObjectReference)(unsafe.Pointer(in.Active))
+	out.LastScheduleTime = (*v1.Time)(unsafe.Pointer(in.LastScheduleTime))
+	out.LastCompletionTime = (*v1.Time)(unsafe.Pointer(in.LastCompletionTime))
 	return nil
 }
 
@@ -930,3 +1392,25 @@ func autoConvert_diki_ScheduledComplianceScanTemplate_To_v1alpha1_ScheduledCompl
 func Convert_diki_ScheduledComplianceScanTemplate_To_v1alpha1_ScheduledComplianceScanTemplate(in *diki.ScheduledComplianceScanTemplate, out *ScheduledComplianceScanTemplate, s conversion.Scope) error {
 	return autoConvert_diki_ScheduledComplianceScanTemplate_To_v1alpha1_ScheduledComplianceScanTemplate(in, out, s)
 }
+
+func autoConvert_v1alpha1_SnapshotImages_To_diki_SnapshotImages(in *SnapshotImages, out *diki.SnapshotImages, s conversion.Scope) error {
+	out.Diki = in.Diki
+	out.ReportExporter = in.ReportExporter
+	return nil
+}
+
+// Convert_v1alpha1_SnapshotImages_To_diki_SnapshotImages is an autogenerated conversion function.
+func Convert_v1alpha1_SnapshotImages_To_diki_SnapshotImages(in *SnapshotImages, out *diki.SnapshotImages, s conversion.Scope) error {
+	return autoConvert_v1alpha1_SnapshotImages_To_diki_SnapshotImages(in, out, s)
+}
+
+func autoConvert_diki_SnapshotImages_To_v1alpha1_SnapshotImages(in *diki.SnapshotImages, out *SnapshotImages, s conversion.Scope) error {
+	out.Diki = in.Diki
+	out.ReportExporter = in.ReportExporter
+	return nil
+}
+
+// Convert_diki_SnapshotImages_To_v1alpha1_SnapshotImages is an autogenerated conversion function.
+func Convert_diki_SnapshotImages_To_v1alpha1_SnapshotImages(in *diki.SnapshotImages, out *SnapshotImages, s conversion.Scope) error {
+	return autoConvert_diki_SnapshotImages_To_v1alpha1_SnapshotImages(in, out, s)
+}
@@ -10,7 +10,8 @@
 package diki
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -75,6 +76,37 @@ func (in *ComplianceScanList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceScanSnapshot) DeepCopyInto(out *ComplianceScanSnapshot) {
+	*out = *in
+	out.Images = in.Images
+	if in.Rulesets != nil {
+		in, out := &in.Rulesets, &out.Rulesets
+		*out = make([]RulesetSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceScanSnapshot.
+func (in *ComplianceScanSnapshot) DeepCopy() *ComplianceScanSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceScanSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComplianceScanSpec) DeepCopyInto(out *ComplianceScanSpec) {
 	*out = *in
@@ -90,6 +122,26 @@ func (in *ComplianceScanSpec) DeepCopyInto(out *ComplianceScanSpec) {
 		*out = make([]ReportOutputRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(ImageOverrides)
+		**out = **in
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -127,6 +179,21 @@ func (in *ComplianceScanStatus) DeepCopyInto(out *ComplianceScanStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueuePosition != nil {
+		in, out := &in.QueuePosition, &out.QueuePosition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(ComplianceScanSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -158,6 +225,185 @@ func (in *Condition) DeepCopy() *Condition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverrides) DeepCopyInto(out *ImageOverrides) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrides.
+func (in *ImageOverrides) DeepCopy() *ImageOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageStatus.
+func (in *ImageStatus) DeepCopy() *ImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceComplianceScan) DeepCopyInto(out *NamespaceComplianceScan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceComplianceScan.
+func (in *NamespaceComplianceScan) DeepCopy() *NamespaceComplianceScan {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceComplianceScan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceComplianceScan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceComplianceScanList) DeepCopyInto(out *NamespaceComplianceScanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceComplianceScan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceComplianceScanList.
+func (in *NamespaceComplianceScanList) DeepCopy() *NamespaceComplianceScanList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceComplianceScanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceComplianceScanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceComplianceScanSpec) DeepCopyInto(out *NamespaceComplianceScanSpec) {
+	*out = *in
+	if in.Rulesets != nil {
+		in, out := &in.Rulesets, &out.Rulesets
+		*out = make([]RulesetConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(NamespaceOutputConfigMap)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceComplianceScanSpec.
+func (in *NamespaceComplianceScanSpec) DeepCopy() *NamespaceComplianceScanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceComplianceScanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceComplianceScanStatus) DeepCopyInto(out *NamespaceComplianceScanStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rulesets != nil {
+		in, out := &in.Rulesets, &out.Rulesets
+		*out = make([]RulesetSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]OutputStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceComplianceScanStatus.
+func (in *NamespaceComplianceScanStatus) DeepCopy() *NamespaceComplianceScanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceComplianceScanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceOutputConfigMap) DeepCopyInto(out *NamespaceOutputConfigMap) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(OutputConfigMapRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceOutputConfigMap.
+func (in *NamespaceOutputConfigMap) DeepCopy() *NamespaceOutputConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceOutputConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Options) DeepCopyInto(out *Options) {
 	*out = *in
@@ -200,13 +446,30 @@ func (in *OptionsConfigMapRef) DeepCopy() *OptionsConfigMapRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionsSnapshot) DeepCopyInto(out *OptionsSnapshot) {
+	*out = *in
+	in.ConfigMapRef.DeepCopyInto(&out.ConfigMapRef)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionsSnapshot.
+func (in *OptionsSnapshot) DeepCopy() *OptionsSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(OptionsSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Output) DeepCopyInto(out *Output) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
 		*out = new(OutputConfigMap)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
@@ -224,6 +487,11 @@ func (in *Output) DeepCopy() *Output {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputConfigMap) DeepCopyInto(out *OutputConfigMap) {
 	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(OutputConfigMapRetention)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -237,6 +505,49 @@ func (in *OutputConfigMap) DeepCopy() *OutputConfigMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputConfigMapRetention) DeepCopyInto(out *OutputConfigMapRetention) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputConfigMapRetention.
+func (in *OutputConfigMapRetention) DeepCopy() *OutputConfigMapRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputConfigMapRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputSnapshot) DeepCopyInto(out *OutputSnapshot) {
+	*out = *in
+	in.Output.DeepCopyInto(&out.Output)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputSnapshot.
+func (in *OutputSnapshot) DeepCopy() *OutputSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputStatus) DeepCopyInto(out *OutputStatus) {
 	*out = *in
@@ -479,6 +790,32 @@ func (in *RulesetOptions) DeepCopy() *RulesetOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RulesetSnapshot) DeepCopyInto(out *RulesetSnapshot) {
+	*out = *in
+	if in.RulesetOptions != nil {
+		in, out := &in.RulesetOptions, &out.RulesetOptions
+		*out = new(OptionsSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuleOptions != nil {
+		in, out := &in.RuleOptions, &out.RuleOptions
+		*out = new(OptionsSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RulesetSnapshot.
+func (in *RulesetSnapshot) DeepCopy() *RulesetSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(RulesetSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RulesetSummary) DeepCopyInto(out *RulesetSummary) {
 	*out = *in
@@ -570,6 +907,11 @@ func (in *ScheduledComplianceScanSpec) DeepCopyInto(out *ScheduledComplianceScan
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
 	in.ScanTemplate.DeepCopyInto(&out.ScanTemplate)
 	return
 }
@@ -587,9 +929,16 @@ func (in *ScheduledComplianceScanSpec) DeepCopy() *ScheduledComplianceScanSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduledComplianceScanStatus) DeepCopyInto(out *ScheduledComplianceScanStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Active != nil {
 		in, out := &in.Active, &out.Active
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.LastScheduleTime != nil {
@@ -629,3 +978,19 @@ func (in *ScheduledComplianceScanTemplate) DeepCopy() *ScheduledComplianceScanTe
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotImages) DeepCopyInto(out *SnapshotImages) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotImages.
+func (in *SnapshotImages) DeepCopy() *SnapshotImages {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotImages)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -16,7 +16,8 @@ type ReportExporterConfiguration struct {
 	metav1.TypeMeta `json:",inline"`
 
 	// ReportPath is the path to the Diki report file to be exported.
-	ReportPath string `json:"reportPath"`
+	// +optional
+	ReportPath string `json:"reportPath,omitempty"`
 	// ComplianceScanName is the name of the compliance scan, which generated the report.
 	ComplianceScanName string `json:"complianceScanName"`
 	// WaitForReport specifies whether the exporter should wait for the report file to appear before reading it.
@@ -26,10 +27,26 @@ type ReportExporterConfiguration struct {
 	// Only used when WaitForReport is true. If not set, the exporter waits indefinitely.
 	// +optional
 	ReportWaitTimeout *metav1.Duration `json:"reportWaitTimeout,omitempty"`
+	// PartialReport configures the exporter to store the report as a partial report of a sharded compliance scan.
+	// If set, the report is not exported to the outputs and the ComplianceScan status is not updated.
+	// +optional
+	PartialReport *ConfigMapReference `json:"partialReport,omitempty"`
+	// PartialReports are the partial reports of a sharded compliance scan.
+	// If set, the partial reports are merged into a single report which is exported instead of the report found at ReportPath.
+	// +optional
+	PartialReports []ConfigMapReference `json:"partialReports,omitempty"`
 	// Outputs contains the list of output configurations.
 	Outputs []Output `json:"outputs"`
 }
 
+// ConfigMapReference is a reference to a ConfigMap.
+type ConfigMapReference struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace is the namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+}
+
 // Output describes a specific output.
 type Output struct {
 	// Type is the type of the output.
@@ -41,6 +58,20 @@ type Output struct {
 	Config runtime.RawExtension `json:"config,omitempty"`
 }
 
+// TerminationMessage is the structured message which the report-exporter writes to its
+// termination message path when it fails.
+type TerminationMessage struct {
+	// Error is the error which caused the report-exporter to fail.
+	Error string `json:"error"`
+}
+
+// PartialReportKey is the key of the gzip compressed partial report in the binary data of a partial report ConfigMap.
+const PartialReportKey = "report.json.gz"
+
+// ShardSkippedRuleJustification is the justification of rules which are skipped by a shard of a sharded compliance scan
+// because they are scanned by another shard. These rules are removed from the partial report of the shard.
+const ShardSkippedRuleJustification = "The rule is evaluated by another shard of the compliance scan."
+
 // OutputType is an alias for string representing the type of an exporter.
 type OutputType string
 
@@ -14,6 +14,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Output) DeepCopyInto(out *Output) {
 	*out = *in
@@ -40,6 +56,16 @@ func (in *ReportExporterConfiguration) DeepCopyInto(out *ReportExporterConfigura
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.PartialReport != nil {
+		in, out := &in.PartialReport, &out.PartialReport
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.PartialReports != nil {
+		in, out := &in.PartialReports, &out.PartialReports
+		*out = make([]ConfigMapReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Outputs != nil {
 		in, out := &in.Outputs, &out.Outputs
 		*out = make([]Output, len(*in))
@@ -67,3 +93,19 @@ func (in *ReportExporterConfiguration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationMessage) DeepCopyInto(out *TerminationMessage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationMessage.
+func (in *TerminationMessage) DeepCopy() *TerminationMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationMessage)
+	in.DeepCopyInto(out)
+	return out
+}